@@ -19,11 +19,24 @@ CREATE TABLE IF NOT EXISTS materials (
     name VARCHAR(255) NOT NULL,
     type VARCHAR(255),
     status VARCHAR(50) NOT NULL, -- available, in_use, reserved, maintenance
+    unit_weight DOUBLE PRECISION NOT NULL DEFAULT 0, -- grams, 0 disables weight verification
+    weight_tolerance DOUBLE PRECISION NOT NULL DEFAULT 0, -- grams, 0 uses 5% of unit_weight
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS unit_weight DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS weight_tolerance DOUBLE PRECISION NOT NULL DEFAULT 0;
 CREATE INDEX IF NOT EXISTS idx_materials_barcode ON materials(barcode);
 CREATE INDEX IF NOT EXISTS idx_materials_status ON materials(status);
+CREATE INDEX IF NOT EXISTS idx_materials_type ON materials(type);
+-- Material search: the full-text index matches whole words, the trigram indexes serve ILIKE '%fragment%'.
+-- The expression must stay identical to materialSearchDocument in material_repo_impl.go.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_materials_search ON materials USING GIN (
+    to_tsvector('simple', coalesce(barcode, '') || ' ' || coalesce(name, '') || ' ' || coalesce(type, ''))
+);
+CREATE INDEX IF NOT EXISTS idx_materials_barcode_trgm ON materials USING GIN (barcode gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_materials_name_trgm ON materials USING GIN (name gin_trgm_ops);
 
 -- Table for Shelves and Slots
 -- Stores the layout and status of each slot on every smart shelf.
@@ -58,6 +71,30 @@ CREATE INDEX IF NOT EXISTS idx_operations_material_id ON operations(material_id)
 CREATE INDEX IF NOT EXISTS idx_operations_slot_id ON operations(slot_id);
 CREATE INDEX IF NOT EXISTS idx_operations_operator_id ON operations(operator_id);
 CREATE INDEX IF NOT EXISTS idx_operations_timestamp ON operations(timestamp DESC);
+-- Keyset pagination orders by (column, id), the common filters get the same tie-breaker
+CREATE INDEX IF NOT EXISTS idx_operations_timestamp_id ON operations(timestamp, id);
+CREATE INDEX IF NOT EXISTS idx_operations_shelf_timestamp_id ON operations(shelf_id, timestamp, id);
+CREATE INDEX IF NOT EXISTS idx_operations_status_timestamp_id ON operations(status, timestamp, id);
+
+-- Table for Operation Transitions
+-- Append-only log of operation status changes. operations only holds the latest status,
+-- this table keeps the timeline and is the source for rebuilding slot occupancy.
+CREATE TABLE IF NOT EXISTS operation_transitions (
+    id BIGSERIAL PRIMARY KEY, -- log order
+    operation_id VARCHAR(255) NOT NULL REFERENCES operations(id),
+    operation_type VARCHAR(50) NOT NULL,
+    from_status VARCHAR(50), -- empty for the transition that created the operation
+    to_status VARCHAR(50) NOT NULL, -- requested, pending_physical_confirmation, pending_removal_confirmation, completed, failed
+    actor VARCHAR(255) NOT NULL, -- operator ID, shelf:<shelf_id> or system
+    reason TEXT,
+    material_id VARCHAR(255) NOT NULL,
+    slot_id VARCHAR(255) NOT NULL,
+    from_slot_id VARCHAR(255), -- source slot of a move
+    shelf_id VARCHAR(255) NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_operation_transitions_operation_id ON operation_transitions(operation_id);
+CREATE INDEX IF NOT EXISTS idx_operation_transitions_material_id ON operation_transitions(material_id);
 
 -- Table for Alerts
 -- Stores system-generated alerts for issues like low stock, slot errors, etc.
@@ -93,6 +130,121 @@ CREATE TABLE IF NOT EXISTS failed_events (
 );
 CREATE INDEX IF NOT EXISTS idx_failed_events_resolved_created_at ON failed_events(resolved, created_at ASC);
 
+-- Table for Shelf States
+-- Persisted online/offline/maintenance/degraded status of each shelf, driven by MQTT status messages and heartbeats.
+CREATE TABLE IF NOT EXISTS shelf_states (
+    shelf_id VARCHAR(255) PRIMARY KEY,
+    status VARCHAR(50) NOT NULL, -- online, offline, maintenance, degraded
+    last_heartbeat_at TIMESTAMPTZ NOT NULL,
+    status_changed_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_shelf_states_status_heartbeat ON shelf_states(status, last_heartbeat_at);
+
+-- Table for Shelf Commands
+-- Downlink commands published to the shelves over MQTT and their acknowledgement status.
+CREATE TABLE IF NOT EXISTS shelf_commands (
+    id VARCHAR(255) PRIMARY KEY, -- also the correlation ID echoed back by the shelf
+    shelf_id VARCHAR(255) NOT NULL,
+    slot_id VARCHAR(255),
+    type VARCHAR(50) NOT NULL, -- light_up_slot, blink_error, lock_slot, unlock_slot, rescan
+    params JSONB,
+    operation_id VARCHAR(255),
+    status VARCHAR(50) NOT NULL, -- pending, sent, acknowledged, failed, timed_out
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ,
+    acknowledged_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_shelf_commands_shelf_id ON shelf_commands(shelf_id);
+CREATE INDEX IF NOT EXISTS idx_shelf_commands_status_sent_at ON shelf_commands(status, sent_at);
+
+-- Table for Maintenance Tickets
+-- Tracks slots taken out of service until they are returned to service.
+CREATE TABLE IF NOT EXISTS maintenance_tickets (
+    id VARCHAR(255) PRIMARY KEY,
+    slot_id VARCHAR(255) NOT NULL REFERENCES slots(id),
+    shelf_id VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(50) NOT NULL, -- open, in_progress, completed
+    assignee VARCHAR(255),
+    notes TEXT,
+    material_id VARCHAR(255) REFERENCES materials(id) ON DELETE SET NULL,
+    previous_slot_status VARCHAR(50), -- slot status before maintenance, restored on completion
+    suggested_slot_ids JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_maintenance_tickets_slot_id ON maintenance_tickets(slot_id);
+CREATE INDEX IF NOT EXISTS idx_maintenance_tickets_shelf_status ON maintenance_tickets(shelf_id, status);
+-- At most one active ticket per slot
+CREATE UNIQUE INDEX IF NOT EXISTS idx_maintenance_tickets_active_slot ON maintenance_tickets(slot_id) WHERE status <> 'completed';
+
+-- Table for Sensor Readings
+-- Stores raw telemetry (weight, temperature, humidity, light) reported by the smart shelves.
+-- Partitioned by month on recorded_at so old partitions can be detached or dropped cheaply.
+CREATE TABLE IF NOT EXISTS sensor_readings (
+    id VARCHAR(255) NOT NULL,
+    shelf_id VARCHAR(255) NOT NULL,
+    slot_id VARCHAR(255) NOT NULL DEFAULT '', -- empty for shelf level readings
+    weight DOUBLE PRECISION,
+    temperature DOUBLE PRECISION,
+    humidity DOUBLE PRECISION,
+    light_level INT,
+    recorded_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, recorded_at)
+) PARTITION BY RANGE (recorded_at);
+CREATE INDEX IF NOT EXISTS idx_sensor_readings_shelf_slot_recorded_at ON sensor_readings(shelf_id, slot_id, recorded_at);
+
+-- Catch-all partition for readings outside of the pre-created monthly partitions
+CREATE TABLE IF NOT EXISTS sensor_readings_default PARTITION OF sensor_readings DEFAULT;
+
+-- Creates the monthly partition containing the given timestamp
+CREATE OR REPLACE FUNCTION create_sensor_readings_partition(ts TIMESTAMPTZ)
+RETURNS VOID AS $$
+DECLARE
+  start_date DATE := date_trunc('month', ts)::DATE;
+  end_date DATE := (date_trunc('month', ts) + INTERVAL '1 month')::DATE;
+  partition_name TEXT := 'sensor_readings_' || to_char(start_date, 'YYYY_MM');
+BEGIN
+  EXECUTE format(
+    'CREATE TABLE IF NOT EXISTS %I PARTITION OF sensor_readings FOR VALUES FROM (%L) TO (%L)',
+    partition_name, start_date, end_date
+  );
+END;
+$$ LANGUAGE plpgsql;
+
+-- inventory-service creates the partitions of the coming months while it runs, see
+-- TelemetryService.EnsureSensorReadingPartitions; these cover the time until it first starts
+SELECT create_sensor_readings_partition(NOW());
+SELECT create_sensor_readings_partition(NOW() + INTERVAL '1 month');
+
+-- Table for Sensor Reading Rollups
+-- Hourly and daily aggregates of sensor_readings, maintained by the inventory-service rollup job.
+CREATE TABLE IF NOT EXISTS sensor_reading_rollups (
+    shelf_id VARCHAR(255) NOT NULL,
+    slot_id VARCHAR(255) NOT NULL DEFAULT '',
+    resolution VARCHAR(20) NOT NULL, -- hour, day
+    bucket_start TIMESTAMPTZ NOT NULL,
+    sample_count BIGINT NOT NULL,
+    avg_weight DOUBLE PRECISION,
+    min_weight DOUBLE PRECISION,
+    max_weight DOUBLE PRECISION,
+    avg_temperature DOUBLE PRECISION,
+    min_temperature DOUBLE PRECISION,
+    max_temperature DOUBLE PRECISION,
+    avg_humidity DOUBLE PRECISION,
+    min_humidity DOUBLE PRECISION,
+    max_humidity DOUBLE PRECISION,
+    avg_light_level DOUBLE PRECISION,
+    PRIMARY KEY (shelf_id, slot_id, resolution, bucket_start)
+);
+CREATE INDEX IF NOT EXISTS idx_sensor_reading_rollups_resolution_bucket ON sensor_reading_rollups(resolution, bucket_start);
+
 -- Function to automatically update updated_at timestamps
 CREATE OR REPLACE FUNCTION trigger_set_timestamp()
 RETURNS TRIGGER AS $$
@@ -118,5 +270,24 @@ BEFORE UPDATE ON alerts
 FOR EACH ROW
 EXECUTE FUNCTION trigger_set_timestamp();
 
+CREATE TRIGGER set_maintenance_tickets_timestamp
+BEFORE UPDATE ON maintenance_tickets
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+
+-- Transitions are immutable
+CREATE OR REPLACE FUNCTION reject_operation_transition_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'operation_transitions is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER operation_transitions_append_only
+BEFORE UPDATE OR DELETE ON operation_transitions
+FOR EACH ROW
+EXECUTE FUNCTION reject_operation_transition_change();
+
 
 -- End of script
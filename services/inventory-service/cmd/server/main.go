@@ -11,13 +11,21 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"WMS/shared/events"
 	"WMS/services/inventory-service/internal/config"
 	"WMS/services/inventory-service/internal/infrastructure/cache"
 	"WMS/services/inventory-service/internal/infrastructure/database"
+	"WMS/services/inventory-service/internal/infrastructure/location"
+	"WMS/services/inventory-service/internal/infrastructure/messaging"
+	"WMS/services/inventory-service/internal/infrastructure/mqttbroker"
 	"WMS/services/inventory-service/internal/application/commands"
 	"WMS/services/inventory-service/internal/application/queries"
+	"WMS/services/inventory-service/internal/domain/entities"
 	"WMS/services/inventory-service/internal/domain/repositories"
 	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/interfaces/consumer"
+	grpcserver "WMS/services/inventory-service/internal/interfaces/grpc"
+	grpchandlers "WMS/services/inventory-service/internal/interfaces/grpc/handlers"
 	"WMS/services/inventory-service/internal/interfaces/http/handlers"
 	"WMS/services/inventory-service/internal/interfaces/http/router"
 	"WMS/services/inventory-service/internal/interfaces/mqtt"
@@ -42,11 +50,19 @@ func main() {
 	redisClient := cache.NewRedisClient(cfg.Redis)
 	defer redisClient.Close()
 
-	// Initialize Kafka event service
-	eventService, err := services.NewEventService(cfg.Kafka.Brokers, cfg.Kafka.Topic)
+	// Initialize event service on the configured message bus
+	messageBus, err := messaging.NewMessageBus(context.Background(), cfg.MessageBus, cfg.Kafka)
 	if err != nil {
-		log.Fatal("Failed to initialize event service:", err)
+		log.Fatal("Failed to initialize message bus:", err)
 	}
+	var eventSchemas *events.SchemaRegistry
+	if cfg.MessageBus.SchemaValidation {
+		eventSchemas, err = events.NewSchemaRegistry()
+		if err != nil {
+			log.Fatal("Failed to load event schemas:", err)
+		}
+	}
+	eventService := services.NewEventService(messageBus, cfg.Kafka.Topic, eventSchemas)
 	defer eventService.Close()
 
 	// Initialize all other services
@@ -60,14 +76,56 @@ func main() {
 	materialRepo := repositories.NewMaterialRepository(db)
 	slotRepo := repositories.NewSlotRepository(db)
 	operationRepo := repositories.NewOperationRepository(db)
+	operationTransitionRepo := repositories.NewOperationTransitionRepository(db)
 	alertRepo := repositories.NewAlertRepository(db)
 	failedEventRepo := repositories.NewFailedEventRepository(db)
+	sensorReadingRepo := repositories.NewSensorReadingRepository(db)
+	maintenanceTicketRepo := repositories.NewMaintenanceTicketRepository(db)
+	shelfStateRepo := repositories.NewShelfStateRepository(db)
+	shelfCommandRepo := repositories.NewShelfCommandRepository(db)
+
+	telemetryService := services.NewTelemetryService(sensorReadingRepo)
+
+	// MQTT client shared by the shelf event handler and the shelf command publisher
+	if cfg.MQTT.QoS > 2 {
+		log.Fatal("Invalid MQTT_QOS: must be 0, 1 or 2")
+	}
+	if cfg.MQTT.EmbeddedBroker {
+		broker, err := mqttbroker.Start(cfg.MQTT)
+		if err != nil {
+			log.Fatal("Failed to start embedded MQTT broker:", err)
+		}
+		defer broker.Close()
+		cfg.MQTT.BrokerURL = broker.URL()
+	}
+	mqttTopics, err := mqtt.NewTopics(cfg.MQTT)
+	if err != nil {
+		log.Fatal("Invalid MQTT topic configuration:", err)
+	}
+	mqttClient, err := mqtt.NewClient(cfg.MQTT)
+	if err != nil {
+		log.Fatal("Failed to create MQTT client:", err)
+	}
+	shelfCommandService := services.NewShelfCommandService(shelfCommandRepo, mqtt.NewShelfCommandPublisher(mqttClient, mqttTopics, cfg.MQTT.QoS))
+	eventGuardService := services.NewEventGuardService(redisClient, slotRepo, cfg.Service.ShelfEventDedupTTL, cfg.Service.ShelfEventClockSkew)
+
+	locationClient, err := location.NewGRPCClient(cfg.Location)
+	if err != nil {
+		log.Fatal("Failed to create location-service client:", err)
+	}
+	defer locationClient.Close()
+
+	slotErrorRemediations, err := entities.ParseSlotErrorRemediations(cfg.Service.SlotErrorRemediations)
+	if err != nil {
+		log.Fatal("Invalid SLOT_ERROR_REMEDIATIONS:", err)
+	}
 
 	// Initialize inventory service
 	inventoryService := services.NewInventoryService(
 		materialRepo,
 		slotRepo,
 		operationRepo,
+		operationTransitionRepo,
 		alertRepo,
 		lockService,
 		eventService,
@@ -76,6 +134,12 @@ func main() {
 		alertService,
 		retryService,
 		failedEventRepo,
+		telemetryService,
+		maintenanceTicketRepo,
+		shelfStateRepo,
+		shelfCommandService,
+		locationClient,
+		slotErrorRemediations,
 	)
 
 	// Initialize command and query handlers
@@ -86,27 +150,65 @@ func main() {
 	batchPlaceMaterialsHandler := commands.NewBatchPlaceMaterialsCommandHandler(inventoryService)
 	handleSlotErrorHandler := commands.NewHandleSlotErrorCommandHandler(inventoryService)
 	updateShelfStatusHandler := commands.NewUpdateShelfStatusCommandHandler(inventoryService)
+	startSlotMaintenanceHandler := commands.NewStartSlotMaintenanceCommandHandler(inventoryService)
+	completeSlotMaintenanceHandler := commands.NewCompleteSlotMaintenanceCommandHandler(inventoryService)
+	sendShelfCommandHandler := commands.NewSendShelfCommandCommandHandler(shelfCommandService)
+	reconcileLocationSlotsHandler := commands.NewReconcileLocationSlotsCommandHandler(inventoryService)
 
 	getShelfStatusHandler := queries.NewGetShelfStatusQueryHandler(inventoryService)
 	findOptimalSlotHandler := queries.NewFindOptimalSlotQueryHandler(inventoryService)
-	searchMaterialsHandler := queries.NewSearchMaterialsQueryHandler(inventoryService)
+	searchMaterialsHandler := queries.NewSearchMaterialsQueryHandler(materialRepo)
+	getMaterialTraceHandler := queries.NewGetMaterialTraceQueryHandler(inventoryService)
 	healthCheckShelfHandler := queries.NewHealthCheckShelfQueryHandler(inventoryService)
 	getOperationsHandler := queries.NewGetOperationsQueryHandler(operationRepo)
+	getOperationTimelineHandler := queries.NewGetOperationTimelineQueryHandler(inventoryService)
+	getSensorReadingsHandler := queries.NewGetSensorReadingsQueryHandler(sensorReadingRepo)
+	getMaintenanceTicketsHandler := queries.NewGetMaintenanceTicketsQueryHandler(maintenanceTicketRepo)
+	getShelfCommandHandler := queries.NewGetShelfCommandQueryHandler(shelfCommandService)
 
 	// Initialize MQTT handler
 	mqttHandler := mqtt.NewMQTTHandler(
-		cfg.MQTT.BrokerURL,
+		mqttClient,
+		mqttTopics,
+		cfg.MQTT.QoS,
 		placeMaterialHandler,
 		removeMaterialHandler,
 		handleSlotErrorHandler,
 		updateShelfStatusHandler,
 		inventoryService, // Pass inventoryService here
+		telemetryService,
+		eventGuardService,
+		shelfCommandService,
 		retryService,
 	)
 	if err := mqttHandler.Connect(); err != nil {
 		log.Fatal("Failed to connect to MQTT broker:", err)
 	}
 
+	// Consume commands sent by other services, e.g. the ERP adapter and the location service
+	consumerCtx, stopConsumers := context.WithCancel(context.Background())
+	defer stopConsumers()
+	if cfg.Kafka.CommandTopic != "" {
+		commandConsumer := consumer.NewCommandConsumer(
+			messageBus,
+			cfg.Kafka.CommandTopic,
+			cfg.Kafka.ConsumerGroup,
+			cfg.Kafka.CommandMaxAttempts,
+			placeMaterialHandler,
+			batchPlaceMaterialsHandler,
+			removeMaterialHandler,
+			moveMaterialHandler,
+			reserveSlotsHandler,
+			inventoryService,
+			services.NewCommandGuardService(redisClient, cfg.Kafka.CommandDedupTTL),
+		)
+		go func() {
+			if err := commandConsumer.Run(consumerCtx); err != nil {
+				logger.Error("Command consumer stopped", err)
+			}
+		}()
+	}
+
 	// Timeout Scheduler for Pending Physical Confirmations
 	go func() {
 		ticker := time.NewTicker(cfg.Service.PhysicalOperationTimeoutCheckInterval)
@@ -141,14 +243,81 @@ func main() {
 		}
 	}()
 
+	// Downsample sensor telemetry into hourly and daily rollups and keep the monthly partitions ahead of the readings
+	go func() {
+		if err := telemetryService.EnsureSensorReadingPartitions(context.Background(), time.Now()); err != nil {
+			logger.Error("Failed to create sensor reading partitions", err)
+		}
+
+		ticker := time.NewTicker(cfg.Service.TelemetryRollupInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := telemetryService.RollupSensorReadings(context.Background(), time.Now()); err != nil {
+				logger.Error("Failed to roll up sensor readings", err)
+			}
+			if err := telemetryService.EnsureSensorReadingPartitions(context.Background(), time.Now()); err != nil {
+				logger.Error("Failed to create sensor reading partitions", err)
+			}
+		}
+	}()
+
+	// Mark shelves offline when they stop sending status messages and events
+	go func() {
+		ticker := time.NewTicker(cfg.Service.ShelfHeartbeatCheckInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := inventoryService.MarkStaleShelvesOffline(context.Background(), cfg.Service.ShelfHeartbeatTimeout); err != nil {
+				logger.Error("Failed to check shelf heartbeats", err)
+			}
+		}
+	}()
+
+	// Time out shelf commands that were never acknowledged
+	go func() {
+		ticker := time.NewTicker(cfg.Service.ShelfCommandAckTimeout)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := shelfCommandService.MarkUnacknowledgedTimedOut(context.Background(), cfg.Service.ShelfCommandAckTimeout); err != nil {
+				logger.Error("Failed to check shelf command acknowledgements", err)
+			}
+		}
+	}()
+
+	// Compare slot state with location-service, which keeps its own copy for placement suggestions
+	if cfg.Location.ReconcileInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.Location.ReconcileInterval)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				report, err := inventoryService.ReconcileLocationSlots(context.Background(), nil, cfg.Location.ReconcileRepair)
+				if err != nil {
+					logger.Error("Failed to reconcile slots with location-service", err)
+					continue
+				}
+				if report.DriftCount > 0 || report.FailedShelves > 0 {
+					logger.Info(fmt.Sprintf("Slot reconciliation with location-service found %d drifted slots, repaired %d, %d shelves could not be compared",
+						report.DriftCount, report.RepairedCount, report.FailedShelves))
+				}
+			}
+		}()
+	}
+
 	// Initialize HTTP handlers
-	materialHandler := handlers.NewMaterialHandler(placeMaterialHandler, removeMaterialHandler, moveMaterialHandler, searchMaterialsHandler)
+	materialHandler := handlers.NewMaterialHandler(placeMaterialHandler, removeMaterialHandler, moveMaterialHandler, searchMaterialsHandler, getMaterialTraceHandler)
 	slotHandler := handlers.NewSlotHandler(reserveSlotsHandler, findOptimalSlotHandler, getShelfStatusHandler, healthCheckShelfHandler)
-	operationHandler := handlers.NewOperationHandler(getOperationsHandler)
+	operationHandler := handlers.NewOperationHandler(getOperationsHandler, getOperationTimelineHandler)
+	telemetryHandler := handlers.NewTelemetryHandler(getSensorReadingsHandler)
+	maintenanceHandler := handlers.NewMaintenanceHandler(startSlotMaintenanceHandler, completeSlotMaintenanceHandler, getMaintenanceTicketsHandler)
+	shelfCommandHandler := handlers.NewShelfCommandHandler(sendShelfCommandHandler, getShelfCommandHandler)
+	reconciliationHandler := handlers.NewReconciliationHandler(reconcileLocationSlotsHandler)
 
 	// Initialize http router
 	gin.SetMode(cfg.Server.Mode)
-	r := router.SetupRoutes(gin.Default(), materialHandler, slotHandler, operationHandler)
+	r := router.SetupRoutes(gin.Default(), materialHandler, slotHandler, operationHandler, telemetryHandler, maintenanceHandler, shelfCommandHandler, reconciliationHandler)
 
 	// configure http server
 	srv := &http.Server{
@@ -166,6 +335,18 @@ func main() {
 		}
 	}()
 
+	// serve the gRPC API next to HTTP, backed by the same handlers
+	inventoryServer := grpchandlers.NewInventoryServer(placeMaterialHandler, removeMaterialHandler, getShelfStatusHandler, searchMaterialsHandler, getOperationsHandler)
+	grpcSrv, err := grpcserver.NewServer(":"+cfg.Server.GRPCPort, inventoryServer)
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC: %v", err)
+	}
+	go func() {
+		if err := grpcSrv.Start(); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
+
 	logger.Info("Inventory service started successfully")
 
 	// handle shutdown signals
@@ -174,10 +355,12 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopConsumers()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	grpcSrv.Stop()
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server forced to shutdown:", err)
 	}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"WMS/services/inventory-service/internal/config"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/infrastructure/messaging"
+	"WMS/services/inventory-service/internal/interfaces/mqtt"
+	"WMS/services/inventory-service/internal/simulator"
+	"WMS/services/inventory-service/pkg/utils"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// shelf-simulator emulates smart shelves of the seed layout so the MQTT flow can be exercised without hardware.
+// Broker and message bus settings are read from the same environment variables as the inventory service.
+func main() {
+	var simCfg simulator.Config
+	flag.IntVar(&simCfg.ShelfCount, "shelves", 10, "number of shelves to simulate, starting at SHELF001")
+	flag.DurationVar(&simCfg.HeartbeatInterval, "heartbeat", 10*time.Second, "interval between shelf status heartbeats")
+	flag.DurationVar(&simCfg.PlacementDelay, "placement-delay", 3*time.Second, "delay before requested material is detected")
+	flag.DurationVar(&simCfg.RemovalDelay, "removal-delay", 3*time.Second, "delay before material guided out by a light-up command is removed")
+	flag.Float64Var(&simCfg.ErrorRate, "error-rate", 0, "probability per shelf and heartbeat of reporting a slot error")
+	flag.Float64Var(&simCfg.UnplannedRemovalRate, "unplanned-removal-rate", 0, "probability per shelf and heartbeat of removing material without a request")
+	flag.Int64Var(&simCfg.Seed, "seed", time.Now().UnixNano(), "random seed for fault injection")
+	clientID := flag.String("client-id", "", "MQTT client ID (default shelf-simulator-<random>)")
+	consumeEvents := flag.Bool("events", true, "react to placement requests published on the message bus")
+	flag.Parse()
+
+	cfg := config.Load()
+	logger.Init(cfg.LogLevel)
+
+	// never reuse the inventory service's client ID, the broker would disconnect one of the two
+	cfg.MQTT.ClientID = *clientID
+	if cfg.MQTT.ClientID == "" {
+		cfg.MQTT.ClientID = fmt.Sprintf("shelf-simulator-%s", utils.GenerateUUID()[:8])
+	}
+
+	topics, err := mqtt.NewTopics(cfg.MQTT)
+	if err != nil {
+		log.Fatal("Invalid MQTT topic configuration:", err)
+	}
+	client, err := mqtt.NewClient(cfg.MQTT)
+	if err != nil {
+		log.Fatal("Failed to create MQTT client:", err)
+	}
+	if token := client.Connect(); token.Wait() && token.Error() != nil {
+		log.Fatal("Failed to connect to MQTT broker:", token.Error())
+	}
+	defer client.Disconnect(250)
+
+	sim := simulator.New(simCfg, simulator.NewMQTTTransport(client, cfg.MQTT.QoS), topics)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *consumeEvents {
+		messageBus, err := messaging.NewMessageBus(ctx, cfg.MessageBus, cfg.Kafka)
+		if err != nil {
+			log.Fatal("Failed to initialize message bus:", err)
+		}
+		// the simulator only consumes, so there is nothing to validate
+		eventService := services.NewEventService(messageBus, cfg.Kafka.Topic, nil)
+		defer eventService.Close()
+
+		go func() {
+			err := eventService.Subscribe(ctx, "shelf-simulator", func(ctx context.Context, msg services.BusMessage) error {
+				sim.HandleInventoryEvent(ctx, msg.Payload)
+				return nil
+			})
+			if err != nil {
+				logger.Error("Failed to consume inventory events", err)
+			}
+		}()
+	}
+
+	if err := sim.Run(ctx); err != nil {
+		log.Fatal("Shelf simulator failed:", err)
+	}
+	logger.Info("Shelf simulator stopped")
+}
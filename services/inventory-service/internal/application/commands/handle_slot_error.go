@@ -3,11 +3,13 @@ package commands
 import (
 	"context"
 	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/pkg/errors"
 )
 
 type HandleSlotErrorCommand struct {
 	SlotID    string
-	ErrorType string
+	ErrorType string                 // one of entities.SlotErrorType, legacy names are normalized
+	Details   map[string]interface{} // raw diagnostics reported by the shelf
 }
 
 type HandleSlotErrorCommandHandler struct {
@@ -19,5 +21,8 @@ func NewHandleSlotErrorCommandHandler(inventoryService *services.InventoryServic
 }
 
 func (h *HandleSlotErrorCommandHandler) Handle(ctx context.Context, cmd HandleSlotErrorCommand) error {
-	return h.inventoryService.HandleSlotError(ctx, cmd.SlotID, cmd.ErrorType)
+	if cmd.SlotID == "" {
+		return errors.NewValidationError("slot ID is required", nil)
+	}
+	return h.inventoryService.HandleSlotError(ctx, cmd.SlotID, cmd.ErrorType, cmd.Details)
 }
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"context"
+
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+// ReconcileLocationSlotsCommand compares slot state with location-service, every shelf when ShelfIDs is empty.
+// Repair overwrites location-service's copy of drifted slots with inventory's.
+type ReconcileLocationSlotsCommand struct {
+	ShelfIDs []string `json:"shelf_ids"`
+	Repair   bool     `json:"repair"`
+}
+
+type ReconcileLocationSlotsCommandHandler struct {
+	inventoryService *services.InventoryService
+}
+
+func NewReconcileLocationSlotsCommandHandler(inventoryService *services.InventoryService) *ReconcileLocationSlotsCommandHandler {
+	return &ReconcileLocationSlotsCommandHandler{inventoryService: inventoryService}
+}
+
+func (h *ReconcileLocationSlotsCommandHandler) Handle(ctx context.Context, cmd ReconcileLocationSlotsCommand) (*services.LocationReconciliationReport, error) {
+	return h.inventoryService.ReconcileLocationSlots(ctx, cmd.ShelfIDs, cmd.Repair)
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"context"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+type SendShelfCommandCommand struct {
+	ShelfID     string                 `json:"-"`
+	SlotID      string                 `json:"slot_id"`
+	CommandType string                 `json:"command_type" binding:"required"`
+	Params      map[string]interface{} `json:"params"`
+}
+
+type SendShelfCommandCommandHandler struct {
+	shelfCommandService *services.ShelfCommandService
+}
+
+func NewSendShelfCommandCommandHandler(shelfCommandService *services.ShelfCommandService) *SendShelfCommandCommandHandler {
+	return &SendShelfCommandCommandHandler{shelfCommandService: shelfCommandService}
+}
+
+func (h *SendShelfCommandCommandHandler) Handle(ctx context.Context, cmd SendShelfCommandCommand) (*entities.ShelfCommand, error) {
+	return h.shelfCommandService.SendCommand(ctx, services.SendShelfCommandParams{
+		ShelfID: cmd.ShelfID,
+		SlotID:  cmd.SlotID,
+		Type:    entities.ShelfCommandType(cmd.CommandType),
+		Params:  cmd.Params,
+	})
+}
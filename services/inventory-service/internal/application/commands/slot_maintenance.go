@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	"context"
+)
+
+type StartSlotMaintenanceCommand struct {
+	SlotID   string `json:"-"`
+	Reason   string `json:"reason"`
+	Assignee string `json:"assignee" binding:"required"`
+	Notes    string `json:"notes"`
+}
+
+type CompleteSlotMaintenanceCommand struct {
+	SlotID     string `json:"-"`
+	OperatorID string `json:"operator_id" binding:"required"`
+	Notes      string `json:"notes"`
+}
+
+type StartSlotMaintenanceCommandHandler struct {
+	inventoryService *services.InventoryService
+}
+
+func NewStartSlotMaintenanceCommandHandler(inventoryService *services.InventoryService) *StartSlotMaintenanceCommandHandler {
+	return &StartSlotMaintenanceCommandHandler{inventoryService: inventoryService}
+}
+
+func (h *StartSlotMaintenanceCommandHandler) Handle(ctx context.Context, cmd StartSlotMaintenanceCommand) (*entities.MaintenanceTicket, error) {
+	return h.inventoryService.StartSlotMaintenance(ctx, services.StartSlotMaintenanceParams{
+		SlotID:   cmd.SlotID,
+		Reason:   cmd.Reason,
+		Assignee: cmd.Assignee,
+		Notes:    cmd.Notes,
+	})
+}
+
+type CompleteSlotMaintenanceCommandHandler struct {
+	inventoryService *services.InventoryService
+}
+
+func NewCompleteSlotMaintenanceCommandHandler(inventoryService *services.InventoryService) *CompleteSlotMaintenanceCommandHandler {
+	return &CompleteSlotMaintenanceCommandHandler{inventoryService: inventoryService}
+}
+
+func (h *CompleteSlotMaintenanceCommandHandler) Handle(ctx context.Context, cmd CompleteSlotMaintenanceCommand) (*entities.MaintenanceTicket, error) {
+	return h.inventoryService.CompleteSlotMaintenance(ctx, services.CompleteSlotMaintenanceParams{
+		SlotID:     cmd.SlotID,
+		OperatorID: cmd.OperatorID,
+		Notes:      cmd.Notes,
+	})
+}
@@ -9,6 +9,9 @@ import (
 type FindOptimalSlotQuery struct {
 	MaterialType string
 	ShelfID      string
+	ZoneID       string
+	StorageClass string
+	Role         string
 }
 
 type FindOptimalSlotQueryHandler struct {
@@ -20,5 +23,11 @@ func NewFindOptimalSlotQueryHandler(inventoryService *services.InventoryService)
 }
 
 func (h *FindOptimalSlotQueryHandler) Handle(ctx context.Context, query FindOptimalSlotQuery) (*entities.Slot, error) {
-	return h.inventoryService.FindOptimalSlot(ctx, query.MaterialType, query.ShelfID)
+	return h.inventoryService.FindOptimalSlot(ctx, services.FindOptimalSlotParams{
+		MaterialType: query.MaterialType,
+		ShelfID:      query.ShelfID,
+		ZoneID:       query.ZoneID,
+		StorageClass: query.StorageClass,
+		Role:         query.Role,
+	})
 }
@@ -0,0 +1,47 @@
+package queries
+
+import (
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/pkg/errors"
+	"context"
+)
+
+type GetMaintenanceTicketsQuery struct {
+	SlotID  string
+	ShelfID string
+	Status  entities.MaintenanceTicketStatus
+	Limit   int
+	Offset  int
+}
+
+type GetMaintenanceTicketsQueryHandler struct {
+	maintenanceTicketRepo repositories.MaintenanceTicketRepository
+}
+
+func NewGetMaintenanceTicketsQueryHandler(maintenanceTicketRepo repositories.MaintenanceTicketRepository) *GetMaintenanceTicketsQueryHandler {
+	return &GetMaintenanceTicketsQueryHandler{maintenanceTicketRepo: maintenanceTicketRepo}
+}
+
+func (h *GetMaintenanceTicketsQueryHandler) Handle(ctx context.Context, query GetMaintenanceTicketsQuery) ([]*entities.MaintenanceTicket, error) {
+	switch query.Status {
+	case "", entities.MaintenanceTicketStatusOpen, entities.MaintenanceTicketStatusInProgress, entities.MaintenanceTicketStatusCompleted:
+	default:
+		return nil, errors.NewValidationError("unsupported maintenance ticket status: "+string(query.Status), nil)
+	}
+	if query.Limit <= 0 {
+		query.Limit = 20
+	}
+
+	tickets, err := h.maintenanceTicketRepo.List(ctx, repositories.MaintenanceTicketFilter{
+		SlotID:  query.SlotID,
+		ShelfID: query.ShelfID,
+		Status:  query.Status,
+		Limit:   query.Limit,
+		Offset:  query.Offset,
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list maintenance tickets", err)
+	}
+	return tickets, nil
+}
@@ -0,0 +1,22 @@
+package queries
+
+import (
+	"context"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+type GetMaterialTraceQuery struct {
+	Barcode string
+}
+
+type GetMaterialTraceQueryHandler struct {
+	inventoryService *services.InventoryService
+}
+
+func NewGetMaterialTraceQueryHandler(inventoryService *services.InventoryService) *GetMaterialTraceQueryHandler {
+	return &GetMaterialTraceQueryHandler{inventoryService: inventoryService}
+}
+
+func (h *GetMaterialTraceQueryHandler) Handle(ctx context.Context, query GetMaterialTraceQuery) (*services.MaterialTrace, error) {
+	return h.inventoryService.TraceMaterial(ctx, query.Barcode)
+}
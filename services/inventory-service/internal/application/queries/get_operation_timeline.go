@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"context"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+type GetOperationTimelineQuery struct {
+	OperationID string
+}
+
+type GetOperationTimelineQueryHandler struct {
+	inventoryService *services.InventoryService
+}
+
+func NewGetOperationTimelineQueryHandler(inventoryService *services.InventoryService) *GetOperationTimelineQueryHandler {
+	return &GetOperationTimelineQueryHandler{inventoryService: inventoryService}
+}
+
+func (h *GetOperationTimelineQueryHandler) Handle(ctx context.Context, query GetOperationTimelineQuery) ([]*entities.OperationTransition, error) {
+	return h.inventoryService.GetOperationTimeline(ctx, query.OperationID)
+}
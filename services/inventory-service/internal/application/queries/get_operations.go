@@ -2,13 +2,53 @@ package queries
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
 	"WMS/services/inventory-service/internal/domain/entities"
 	"WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/pkg/errors"
+)
+
+const (
+	defaultOperationsLimit = 20
+	maxOperationsLimit     = 500
 )
 
+// GetOperationsQuery lists operations matching every non-empty filter. Pages are either addressed by
+// Offset, or by the Cursor returned with the previous page, which stays fast for deep history.
 type GetOperationsQuery struct {
-	Limit  int
-	Offset int
+	ShelfID    string
+	SlotID     string
+	OperatorID string
+	MaterialID string
+	Type       entities.OperationType
+	Status     entities.OperationStatus
+	From       time.Time
+	To         time.Time
+	SortBy     string // one of the repositories.OperationSortField values, defaults to timestamp
+	Order      string // asc or desc, defaults to desc
+	Cursor     string
+	Limit      int
+	Offset     int
+}
+
+// OperationsPage is one page of operations. NextCursor is empty on the last page.
+type OperationsPage struct {
+	Operations []*entities.Operation `json:"operations"`
+	TotalCount int64                 `json:"total_count"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+// operationsCursor is the decoded form of GetOperationsQuery.Cursor. It records the sort it was issued
+// for, since the key means nothing under a different order.
+type operationsCursor struct {
+	SortBy     repositories.OperationSortField `json:"s"`
+	Descending bool                            `json:"d"`
+	SortValue  string                          `json:"v"`
+	ID         string                          `json:"id"`
 }
 
 type GetOperationsQueryHandler struct {
@@ -19,6 +59,132 @@ func NewGetOperationsQueryHandler(operationRepo repositories.OperationRepository
 	return &GetOperationsQueryHandler{operationRepo: operationRepo}
 }
 
-func (h *GetOperationsQueryHandler) Handle(ctx context.Context, query GetOperationsQuery) ([]*entities.Operation, error) {
-	return h.operationRepo.List(ctx, query.Limit, query.Offset)
+func (h *GetOperationsQueryHandler) Handle(ctx context.Context, query GetOperationsQuery) (*OperationsPage, error) {
+	options, err := h.listOptions(query)
+	if err != nil {
+		return nil, err
+	}
+
+	operations, err := h.operationRepo.Find(ctx, options)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list operations", err)
+	}
+
+	total, err := h.operationRepo.Count(ctx, options.Filter)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to count operations", err)
+	}
+
+	page := &OperationsPage{Operations: operations, TotalCount: total}
+	if operations == nil {
+		page.Operations = []*entities.Operation{}
+	}
+	// a short page is the last one, a full one may be followed by more
+	if len(operations) == options.Limit {
+		last := operations[len(operations)-1]
+		page.NextCursor = encodeOperationsCursor(operationsCursor{
+			SortBy:     options.SortBy,
+			Descending: options.Descending,
+			SortValue:  options.SortBy.ValueOf(last),
+			ID:         last.ID,
+		})
+	}
+	return page, nil
+}
+
+func (h *GetOperationsQueryHandler) listOptions(query GetOperationsQuery) (repositories.OperationListOptions, error) {
+	options := repositories.OperationListOptions{
+		Filter: repositories.OperationFilter{
+			ShelfID:    query.ShelfID,
+			SlotID:     query.SlotID,
+			OperatorID: query.OperatorID,
+			MaterialID: query.MaterialID,
+			Type:       query.Type,
+			Status:     query.Status,
+			From:       query.From,
+			To:         query.To,
+		},
+		SortBy: repositories.OperationSortField(query.SortBy),
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}
+
+	switch query.Type {
+	case "", entities.OperationTypePlacement, entities.OperationTypeRemoval, entities.OperationTypeMove, entities.OperationTypeReservation:
+	default:
+		return options, errors.NewValidationError(fmt.Sprintf("unsupported operation type: %s", query.Type), nil)
+	}
+	switch query.Status {
+	case "", entities.OperationStatusPending, entities.OperationStatusCompleted, entities.OperationStatusFailed, entities.OperationStatusCancelled,
+		entities.OperationStatusPendingPhysicalConfirmation, entities.OperationStatusPendingRemovalConfirmation:
+	default:
+		return options, errors.NewValidationError(fmt.Sprintf("unsupported operation status: %s", query.Status), nil)
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return options, errors.NewValidationError("from must be before to", nil)
+	}
+
+	if options.SortBy == "" {
+		options.SortBy = repositories.OperationSortByTimestamp
+	}
+	if !options.SortBy.IsValid() {
+		return options, errors.NewValidationError(fmt.Sprintf("unsupported sort field: %s", query.SortBy), nil)
+	}
+	switch query.Order {
+	case "", "desc":
+		options.Descending = true
+	case "asc":
+	default:
+		return options, errors.NewValidationError(fmt.Sprintf("unsupported sort order: %s", query.Order), nil)
+	}
+
+	if options.Limit <= 0 {
+		options.Limit = defaultOperationsLimit
+	}
+	if options.Limit > maxOperationsLimit {
+		options.Limit = maxOperationsLimit
+	}
+	if options.Offset < 0 {
+		return options, errors.NewValidationError("offset must not be negative", nil)
+	}
+
+	if query.Cursor != "" {
+		if options.Offset > 0 {
+			return options, errors.NewValidationError("cursor and offset cannot be combined", nil)
+		}
+		cursor, err := decodeOperationsCursor(query.Cursor)
+		if err != nil {
+			return options, errors.NewValidationError("invalid cursor", err)
+		}
+		if cursor.SortBy != options.SortBy || cursor.Descending != options.Descending {
+			return options, errors.NewValidationError("cursor was issued for a different sort order", nil)
+		}
+		if cursor.SortBy == repositories.OperationSortByTimestamp {
+			if _, err := time.Parse(time.RFC3339Nano, cursor.SortValue); err != nil {
+				return options, errors.NewValidationError("invalid cursor", err)
+			}
+		}
+		options.After = &repositories.OperationKey{SortValue: cursor.SortValue, ID: cursor.ID}
+	}
+	return options, nil
+}
+
+func encodeOperationsCursor(cursor operationsCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeOperationsCursor(value string) (operationsCursor, error) {
+	var cursor operationsCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, err
+	}
+	if cursor.ID == "" {
+		return cursor, fmt.Errorf("cursor has no operation ID")
+	}
+	return cursor, nil
 }
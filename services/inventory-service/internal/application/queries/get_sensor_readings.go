@@ -0,0 +1,106 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/pkg/errors"
+)
+
+const (
+	defaultSensorReadingsWindow = 24 * time.Hour
+	defaultSensorReadingsLimit  = 1000
+	maxSensorReadingsLimit      = 10000
+)
+
+type GetSensorReadingsQuery struct {
+	ShelfID    string
+	SlotID     string
+	From       time.Time
+	To         time.Time
+	Resolution entities.SensorResolution
+	Limit      int
+}
+
+// SensorReadingsResult carries raw readings for the raw resolution and
+// aggregated buckets for every other resolution.
+type SensorReadingsResult struct {
+	ShelfID    string                          `json:"shelf_id"`
+	SlotID     string                          `json:"slot_id,omitempty"`
+	Resolution entities.SensorResolution       `json:"resolution"`
+	From       time.Time                       `json:"from"`
+	To         time.Time                       `json:"to"`
+	Readings   []*entities.SensorReading       `json:"readings,omitempty"`
+	Buckets    []*entities.SensorReadingRollup `json:"buckets,omitempty"`
+}
+
+type GetSensorReadingsQueryHandler struct {
+	sensorReadingRepo repositories.SensorReadingRepository
+}
+
+func NewGetSensorReadingsQueryHandler(sensorReadingRepo repositories.SensorReadingRepository) *GetSensorReadingsQueryHandler {
+	return &GetSensorReadingsQueryHandler{sensorReadingRepo: sensorReadingRepo}
+}
+
+func (h *GetSensorReadingsQueryHandler) Handle(ctx context.Context, query GetSensorReadingsQuery) (*SensorReadingsResult, error) {
+	if query.ShelfID == "" {
+		return nil, errors.NewValidationError("shelf ID is required", nil)
+	}
+	if query.Resolution == "" {
+		query.Resolution = entities.SensorResolutionRaw
+	}
+	if !query.Resolution.IsValid() {
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported resolution: %s", query.Resolution), nil)
+	}
+	if query.To.IsZero() {
+		query.To = time.Now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultSensorReadingsWindow)
+	}
+	if !query.From.Before(query.To) {
+		return nil, errors.NewValidationError("from must be before to", nil)
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSensorReadingsLimit
+	}
+	if query.Limit > maxSensorReadingsLimit {
+		query.Limit = maxSensorReadingsLimit
+	}
+
+	filter := repositories.SensorReadingFilter{
+		ShelfID:    query.ShelfID,
+		SlotID:     query.SlotID,
+		From:       query.From,
+		To:         query.To,
+		Resolution: query.Resolution,
+		Limit:      query.Limit,
+	}
+
+	result := &SensorReadingsResult{
+		ShelfID:    query.ShelfID,
+		SlotID:     query.SlotID,
+		Resolution: query.Resolution,
+		From:       query.From,
+		To:         query.To,
+	}
+
+	if query.Resolution == entities.SensorResolutionRaw {
+		readings, err := h.sensorReadingRepo.ListReadings(ctx, filter)
+		if err != nil {
+			return nil, errors.NewInternalError("failed to list sensor readings", err)
+		}
+		result.Readings = readings
+		return result, nil
+	}
+
+	buckets, err := h.sensorReadingRepo.ListRollups(ctx, filter)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list sensor reading rollups", err)
+	}
+	result.Buckets = buckets
+	return result, nil
+}
@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"context"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+type GetShelfCommandQuery struct {
+	CommandID string
+}
+
+type GetShelfCommandQueryHandler struct {
+	shelfCommandService *services.ShelfCommandService
+}
+
+func NewGetShelfCommandQueryHandler(shelfCommandService *services.ShelfCommandService) *GetShelfCommandQueryHandler {
+	return &GetShelfCommandQueryHandler{shelfCommandService: shelfCommandService}
+}
+
+func (h *GetShelfCommandQueryHandler) Handle(ctx context.Context, query GetShelfCommandQuery) (*entities.ShelfCommand, error) {
+	return h.shelfCommandService.GetCommand(ctx, query.CommandID)
+}
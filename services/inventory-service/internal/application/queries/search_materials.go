@@ -2,24 +2,87 @@ package queries
 
 import (
 	"context"
+	"fmt"
+
 	"WMS/services/inventory-service/internal/domain/entities"
-	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/pkg/errors"
+)
+
+const (
+	defaultMaterialSearchLimit = 20
+	maxMaterialSearchLimit     = 100
 )
 
+// SearchMaterialsQuery finds materials by barcode, name or type. Every non-empty filter must match;
+// an empty Query lists the filtered materials newest first.
 type SearchMaterialsQuery struct {
-	Query  string
-	Limit  int
-	Offset int
+	Query   string
+	Type    string
+	Status  entities.MaterialStatus
+	ShelfID string
+	Limit   int
+	Offset  int
+}
+
+// MaterialSearchPage is one page of matches, with the total and facet counts over all of them.
+// Nothing matching is an empty page, not an error.
+type MaterialSearchPage struct {
+	Materials  []*entities.Material        `json:"materials"`
+	TotalCount int64                        `json:"total_count"`
+	Facets     *repositories.MaterialFacets `json:"facets"`
 }
 
 type SearchMaterialsQueryHandler struct {
-	inventoryService *services.InventoryService
+	materialRepo repositories.MaterialRepository
 }
 
-func NewSearchMaterialsQueryHandler(inventoryService *services.InventoryService) *SearchMaterialsQueryHandler {
-	return &SearchMaterialsQueryHandler{inventoryService: inventoryService}
+func NewSearchMaterialsQueryHandler(materialRepo repositories.MaterialRepository) *SearchMaterialsQueryHandler {
+	return &SearchMaterialsQueryHandler{materialRepo: materialRepo}
 }
 
-func (h *SearchMaterialsQueryHandler) Handle(ctx context.Context, query SearchMaterialsQuery) ([]*entities.Material, error) {
-	return h.inventoryService.SearchMaterials(ctx, query.Query, query.Limit, query.Offset)
+func (h *SearchMaterialsQueryHandler) Handle(ctx context.Context, query SearchMaterialsQuery) (*MaterialSearchPage, error) {
+	switch query.Status {
+	case "", entities.MaterialStatusAvailable, entities.MaterialStatusInUse, entities.MaterialStatusReserved, entities.MaterialStatusMaintenance:
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported material status: %s", query.Status), nil)
+	}
+	if query.Offset < 0 {
+		return nil, errors.NewValidationError("offset must not be negative", nil)
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultMaterialSearchLimit
+	}
+	if limit > maxMaterialSearchLimit {
+		limit = maxMaterialSearchLimit
+	}
+
+	filter := repositories.MaterialSearchFilter{
+		Query:   query.Query,
+		Type:    query.Type,
+		Status:  query.Status,
+		ShelfID: query.ShelfID,
+	}
+
+	materials, err := h.materialRepo.Search(ctx, filter, limit, query.Offset)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to search materials", err)
+	}
+
+	total, err := h.materialRepo.CountSearch(ctx, filter)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to count materials", err)
+	}
+
+	facets, err := h.materialRepo.SearchFacets(ctx, filter)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to count material facets", err)
+	}
+
+	page := &MaterialSearchPage{Materials: materials, TotalCount: total, Facets: facets}
+	if materials == nil {
+		page.Materials = []*entities.Material{}
+	}
+	return page, nil
 }
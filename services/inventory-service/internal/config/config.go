@@ -12,13 +12,16 @@ type Config struct {
 	Database    DatabaseConfig
 	Redis       RedisConfig
 	Kafka       KafkaConfig
+	MessageBus  MessageBusConfig
 	LogLevel    string
 	Service     ServiceConfig
 	MQTT		MQTTConfig
+	Location    LocationServiceConfig
 }
 
 type ServerConfig struct {
 	Port         string
+	GRPCPort     string // the gRPC API is served next to HTTP on this port
 	Mode         string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
@@ -41,12 +44,56 @@ type RedisConfig struct {
 }
 
 type KafkaConfig struct {
-	Brokers []string
-	Topic   string
+	Brokers            []string
+	Topic              string
+	CommandTopic       string // commands from other services, consuming is disabled when empty
+	ConsumerGroup      string
+	CommandMaxAttempts int           // deliveries of a failing command before it is moved to failed_events
+	CommandDedupTTL    time.Duration // how long handled command IDs are remembered to skip their redeliveries
+}
+
+// MessageBusConfig selects the event backend. The Kafka topic name is used as the topic on every backend.
+type MessageBusConfig struct {
+	Backend          string // "kafka", "nats" or "memory"
+	NATSURL          string
+	NATSStream       string
+	SchemaValidation bool // validate events against their schema in shared/events/schemas before publishing
+}
+
+// LocationServiceConfig points at the location-service gRPC API. After FailureThreshold consecutive
+// failures calls are not attempted for OpenTimeout, the inventory service falls back to local data meanwhile.
+type LocationServiceConfig struct {
+	Addr              string
+	Timeout           time.Duration
+	FailureThreshold  int
+	OpenTimeout       time.Duration
+	ReconcileInterval time.Duration // how often slot state is compared with location-service, 0 disables it
+	ReconcileRepair   bool          // let the periodic reconciliation overwrite drifted slots in location-service
 }
 
 type MQTTConfig struct {
-	BrokerURL string
+	BrokerURL             string
+	ClientID              string // generated per instance when empty
+	Username              string
+	Password              string
+	TLSEnabled            bool
+	TLSCAFile             string
+	TLSCertFile           string
+	TLSKeyFile            string
+	TLSInsecureSkipVerify bool
+	KeepAlive             time.Duration
+	QoS                   byte
+	// SharedSubscriptionGroup lets replicas share the shelf subscriptions through $share/<group>/
+	SharedSubscriptionGroup string
+	TopicPrefix             string
+	// Topic templates, each containing {shelf_id} and optionally {prefix}
+	EventTopic      string
+	StatusTopic     string
+	CommandTopic    string
+	CommandAckTopic string
+	// EmbeddedBroker starts an in-process broker on EmbeddedBrokerAddress and connects to it instead of BrokerURL
+	EmbeddedBroker        bool
+	EmbeddedBrokerAddress string
 }
 
 type ServiceConfig struct {
@@ -55,6 +102,13 @@ type ServiceConfig struct {
 	AllowedOrigins                       string
 	PhysicalOperationTimeout        time.Duration
 	PhysicalOperationTimeoutCheckInterval time.Duration
+	TelemetryRollupInterval              time.Duration
+	SlotErrorRemediations                string // overrides such as "jammed=investigation,door_open=auto_clear"
+	ShelfHeartbeatTimeout                time.Duration
+	ShelfHeartbeatCheckInterval          time.Duration
+	ShelfCommandAckTimeout               time.Duration
+	ShelfEventDedupTTL                   time.Duration
+	ShelfEventClockSkew                  time.Duration // tolerated clock difference between shelves and the service
 }
 
 func Load() *Config {
@@ -62,6 +116,7 @@ func Load() *Config {
 		Environment: getEnv("ENVIRONMENT", "development"),
 		Server: ServerConfig{
 			Port:         getEnv("SERVER_PORT", "8080"),
+			GRPCPort:     getEnv("GRPC_PORT", "50051"),
 			Mode:         getEnv("GIN_MODE", "debug"),
 			ReadTimeout:  15 * time.Second,
 			WriteTimeout: 15 * time.Second,
@@ -81,8 +136,18 @@ func Load() *Config {
 			DB:       0,
 		},
 		Kafka: KafkaConfig{
-			Brokers: []string{getEnv("KAFKA_BROKERS", "localhost:9092")},
-			Topic:   getEnv("KAFKA_TOPIC", "inventory_events"),
+			Brokers:            []string{getEnv("KAFKA_BROKERS", "localhost:9092")},
+			Topic:              getEnv("KAFKA_TOPIC", "inventory_events"),
+			CommandTopic:       getEnv("KAFKA_COMMAND_TOPIC", "inventory_commands"),
+			ConsumerGroup:      getEnv("KAFKA_CONSUMER_GROUP", "inventory-service"),
+			CommandMaxAttempts: parseInt(getEnv("COMMAND_MAX_ATTEMPTS", "5")),
+			CommandDedupTTL:    parseDuration(getEnv("COMMAND_DEDUP_TTL", "24h")),
+		},
+		MessageBus: MessageBusConfig{
+			Backend:          getEnv("MESSAGE_BUS", "kafka"),
+			NATSURL:          getEnv("NATS_URL", "nats://localhost:4222"),
+			NATSStream:       getEnv("NATS_STREAM", "INVENTORY"),
+			SchemaValidation: parseBool(getEnv("EVENT_SCHEMA_VALIDATION", "true")),
 		},
 		LogLevel: getEnv("LOG_LEVEL", "info"),
 		Service: ServiceConfig{
@@ -91,9 +156,42 @@ func Load() *Config {
 			AllowedOrigins:                       getEnv("ALLOW_ORIGINS", "*"),
 			PhysicalOperationTimeout:        parseDuration(getEnv("PHYSICAL_OPERATION_TIMEOUT", "5m")),
 			PhysicalOperationTimeoutCheckInterval: parseDuration(getEnv("PHYSICAL_OPERATION_TIMEOUT_CHECK_INTERVAL", "1m")),
+			TelemetryRollupInterval:              parseDuration(getEnv("TELEMETRY_ROLLUP_INTERVAL", "5m")),
+			SlotErrorRemediations:                getEnv("SLOT_ERROR_REMEDIATIONS", ""),
+			ShelfHeartbeatTimeout:                parseDuration(getEnv("SHELF_HEARTBEAT_TIMEOUT", "60s")),
+			ShelfHeartbeatCheckInterval:          parseDuration(getEnv("SHELF_HEARTBEAT_CHECK_INTERVAL", "15s")),
+			ShelfCommandAckTimeout:               parseDuration(getEnv("SHELF_COMMAND_ACK_TIMEOUT", "10s")),
+			ShelfEventDedupTTL:                   parseDuration(getEnv("SHELF_EVENT_DEDUP_TTL", "24h")),
+			ShelfEventClockSkew:                  parseDuration(getEnv("SHELF_EVENT_CLOCK_SKEW", "2s")),
 		},
 		MQTT: MQTTConfig{
-			BrokerURL: getEnv("MQTT_BROKER_URL", "tcp://localhost:1883"),
+			BrokerURL:               getEnv("MQTT_BROKER_URL", "tcp://localhost:1883"),
+			ClientID:                getEnv("MQTT_CLIENT_ID", ""),
+			Username:                getEnv("MQTT_USERNAME", ""),
+			Password:                getEnv("MQTT_PASSWORD", ""),
+			TLSEnabled:              parseBool(getEnv("MQTT_TLS_ENABLED", "false")),
+			TLSCAFile:               getEnv("MQTT_TLS_CA_FILE", ""),
+			TLSCertFile:             getEnv("MQTT_TLS_CERT_FILE", ""),
+			TLSKeyFile:              getEnv("MQTT_TLS_KEY_FILE", ""),
+			TLSInsecureSkipVerify:   parseBool(getEnv("MQTT_TLS_INSECURE_SKIP_VERIFY", "false")),
+			KeepAlive:               parseDuration(getEnv("MQTT_KEEP_ALIVE", "60s")),
+			QoS:                     byte(parseInt(getEnv("MQTT_QOS", "1"))),
+			SharedSubscriptionGroup: getEnv("MQTT_SHARED_SUBSCRIPTION_GROUP", ""),
+			TopicPrefix:             getEnv("MQTT_TOPIC_PREFIX", "WMS/services/inventory-service/shelf"),
+			EventTopic:              getEnv("MQTT_EVENT_TOPIC", ""),
+			StatusTopic:             getEnv("MQTT_STATUS_TOPIC", ""),
+			CommandTopic:            getEnv("MQTT_COMMAND_TOPIC", ""),
+			CommandAckTopic:         getEnv("MQTT_COMMAND_ACK_TOPIC", ""),
+			EmbeddedBroker:          parseBool(getEnv("MQTT_EMBEDDED_BROKER", "false")),
+			EmbeddedBrokerAddress:   getEnv("MQTT_EMBEDDED_BROKER_ADDRESS", ":1883"),
+		},
+		Location: LocationServiceConfig{
+			Addr:              getEnv("LOCATION_SERVICE_ADDR", "localhost:50052"),
+			Timeout:           parseDuration(getEnv("LOCATION_SERVICE_TIMEOUT", "2s")),
+			FailureThreshold:  parseInt(getEnv("LOCATION_SERVICE_FAILURE_THRESHOLD", "5")),
+			OpenTimeout:       parseDuration(getEnv("LOCATION_SERVICE_OPEN_TIMEOUT", "30s")),
+			ReconcileInterval: parseDuration(getEnv("LOCATION_RECONCILE_INTERVAL", "1h")),
+			ReconcileRepair:   parseBool(getEnv("LOCATION_RECONCILE_REPAIR", "false")),
 		},
 	}
 }
@@ -120,3 +218,11 @@ func parseDuration(s string) time.Duration {
 	}
 	return val
 }
+
+func parseBool(s string) bool {
+	val, err := strconv.ParseBool(s)
+	if err != nil {
+		return false
+	}
+	return val
+}
@@ -13,6 +13,9 @@ const (
 	AlertTypeShelfHealth AlertType = "shelf_health"
 	AlertTypeSlotError   AlertType = "slot_error"
 	AlertTypeSystem      AlertType = "system_alert"
+
+	// AlertTypeUnplannedChange is raised when a sensor finds a slot in a state no operation explains
+	AlertTypeUnplannedChange AlertType = "unplanned_slot_change"
 )
 
 type AlertSeverity string
@@ -46,16 +49,6 @@ type Alert struct {
 	Metadata   JSON        `json:"metadata" gorm:"type:jsonb"`
 }
 
-type SystemAlertEvent struct {
-	ID    		string    `json:"alert_id"`
-	Type  		AlertType `json:"alert_type"`
-	Severity    AlertSeverity `json:"severity"`
-	Message 	string    `json:"message"`
-	Timestamp 	time.Time `json:"timestamp"`
-	Details  	JSON      `json:"details" gorm:"type:jsonb"`
-}
-	
-
 // JSON type for GORM
 type JSON map[string]interface{}
 
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type MaintenanceTicketStatus string
+
+const (
+	MaintenanceTicketStatusOpen       MaintenanceTicketStatus = "open"
+	MaintenanceTicketStatusInProgress MaintenanceTicketStatus = "in_progress"
+	MaintenanceTicketStatusCompleted  MaintenanceTicketStatus = "completed"
+)
+
+// MaintenanceTicket tracks a slot from the moment it is taken out of service until it is returned to service.
+// A slot has at most one ticket that is not completed.
+type MaintenanceTicket struct {
+	ID         string                  `json:"id" gorm:"primaryKey"`
+	SlotID     string                  `json:"slot_id" gorm:"index"`
+	ShelfID    string                  `json:"shelf_id" gorm:"index"`
+	Reason     string                  `json:"reason"`
+	Status     MaintenanceTicketStatus `json:"status"`
+	Assignee   string                  `json:"assignee,omitempty"`
+	Notes      string                  `json:"notes,omitempty"`
+	MaterialID *string                 `json:"material_id,omitempty"` // material left in the slot when it went into maintenance
+	// PreviousSlotStatus is the status the slot had when it was taken out of service, e.g. reserved
+	PreviousSlotStatus SlotStatus `json:"previous_slot_status,omitempty"`
+	// SuggestedSlotIDs are empty slots the material can be relocated to while the slot is out of service
+	SuggestedSlotIDs datatypes.JSONSlice[string] `json:"suggested_slot_ids,omitempty"`
+	CreatedAt        time.Time                   `json:"created_at"`
+	StartedAt        *time.Time                  `json:"started_at,omitempty"`
+	CompletedAt      *time.Time                  `json:"completed_at,omitempty"`
+	UpdatedAt        time.Time                   `json:"updated_at"`
+}
+
+func (MaintenanceTicket) TableName() string {
+	return "maintenance_tickets"
+}
+
+func (t *MaintenanceTicket) IsClosed() bool {
+	return t.Status == MaintenanceTicketStatusCompleted
+}
+
+// ReturnStatus is the status the slot goes back to when the ticket is completed: the status it had before,
+// unless material was put into or taken out of the slot in the meantime
+func (t *MaintenanceTicket) ReturnStatus(materialID *string) SlotStatus {
+	switch t.PreviousSlotStatus {
+	case SlotStatusReserved:
+		return SlotStatusReserved
+	case SlotStatusOccupied, SlotStatusRemovalPending:
+		if materialID != nil {
+			return t.PreviousSlotStatus
+		}
+	}
+	if materialID != nil {
+		return SlotStatusOccupied
+	}
+	return SlotStatusEmpty
+}
+
+// AddNote appends a timestamped note so the ticket keeps the full history of the work done
+func (t *MaintenanceTicket) AddNote(author, note string, at time.Time) {
+	if note == "" {
+		return
+	}
+	entry := at.Format(time.RFC3339)
+	if author != "" {
+		entry += " " + author
+	}
+	entry += ": " + note
+
+	if t.Notes != "" {
+		t.Notes += "\n"
+	}
+	t.Notes += entry
+}
@@ -14,13 +14,15 @@ const (
 )
 
 type Material struct {
-	ID        string         `json:"id" gorm:"primaryKey"`
-	Barcode   string         `json:"barcode" gorm:"uniqueIndex"`
-	Name      string         `json:"name"`
-	Type      string         `json:"type"`
-	Status    MaterialStatus `json:"status"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
+	ID              string         `json:"id" gorm:"primaryKey"`
+	Barcode         string         `json:"barcode" gorm:"uniqueIndex"`
+	Name            string         `json:"name"`
+	Type            string         `json:"type"`
+	Status          MaterialStatus `json:"status"`
+	UnitWeight      float64        `json:"unit_weight"`      // expected weight in grams, 0 if unknown
+	WeightTolerance float64        `json:"weight_tolerance"` // allowed deviation in grams, 0 uses the default ratio
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
 }
 
 func (Material) TableName() string {
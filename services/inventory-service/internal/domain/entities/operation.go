@@ -16,6 +16,7 @@ const (
 type OperationStatus string
 
 const (
+	OperationStatusRequested                 OperationStatus = "requested" // only recorded as the first transition of an operation
 	OperationStatusPending                   OperationStatus = "pending"
 	OperationStatusCompleted                 OperationStatus = "completed"
 	OperationStatusFailed                    OperationStatus = "failed"
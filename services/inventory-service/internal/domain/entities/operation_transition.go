@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+)
+
+// SystemActor is the actor recorded for transitions nobody triggered by hand, such as confirmation timeouts
+const SystemActor = "system"
+
+// ShelfActor returns the actor recorded for transitions reported by a smart shelf
+func ShelfActor(shelfID string) string {
+	return "shelf:" + shelfID
+}
+
+// OperationTransition is an immutable record of an operation changing status. Operations themselves only
+// keep their latest status, the transitions keep the full timeline and are never updated or deleted.
+type OperationTransition struct {
+	ID            int64           `json:"id" gorm:"primaryKey;autoIncrement"` // orders the log, timestamps may collide
+	OperationID   string          `json:"operation_id" gorm:"index"`
+	OperationType OperationType   `json:"operation_type"`
+	FromStatus    OperationStatus `json:"from_status,omitempty"` // empty for the transition that created the operation
+	ToStatus      OperationStatus `json:"to_status"`
+	Actor         string          `json:"actor"`
+	Reason        string          `json:"reason,omitempty"`
+	MaterialID    string          `json:"material_id" gorm:"index"`
+	SlotID        string          `json:"slot_id"`
+	FromSlotID    string          `json:"from_slot_id,omitempty"` // source slot of a move, SlotID is the target
+	ShelfID       string          `json:"shelf_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+func (OperationTransition) TableName() string {
+	return "operation_transitions"
+}
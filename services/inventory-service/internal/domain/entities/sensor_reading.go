@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"time"
+)
+
+type SensorResolution string
+
+const (
+	SensorResolutionRaw    SensorResolution = "raw"
+	SensorResolutionMinute SensorResolution = "minute"
+	SensorResolutionHour   SensorResolution = "hour"
+	SensorResolutionDay    SensorResolution = "day"
+)
+
+// IsValid reports whether the resolution is one of the supported values.
+func (r SensorResolution) IsValid() bool {
+	switch r {
+	case SensorResolutionRaw, SensorResolutionMinute, SensorResolutionHour, SensorResolutionDay:
+		return true
+	}
+	return false
+}
+
+// SensorReading is a single telemetry sample reported by a smart shelf.
+// Readings are stored in a table partitioned by recorded_at.
+type SensorReading struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	ShelfID     string    `json:"shelf_id"`
+	SlotID      string    `json:"slot_id,omitempty"`
+	Weight      *float64  `json:"weight,omitempty"`
+	Temperature *float64  `json:"temperature,omitempty"`
+	Humidity    *float64  `json:"humidity,omitempty"`
+	LightLevel  *int      `json:"light_level,omitempty"`
+	RecordedAt  time.Time `json:"recorded_at" gorm:"primaryKey"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (SensorReading) TableName() string {
+	return "sensor_readings"
+}
+
+// SensorReadingRollup holds aggregated readings for one slot over a time bucket.
+type SensorReadingRollup struct {
+	ShelfID        string           `json:"shelf_id" gorm:"primaryKey"`
+	SlotID         string           `json:"slot_id" gorm:"primaryKey"`
+	Resolution     SensorResolution `json:"resolution" gorm:"primaryKey"`
+	BucketStart    time.Time        `json:"bucket_start" gorm:"primaryKey"`
+	SampleCount    int64            `json:"sample_count"`
+	AvgWeight      *float64         `json:"avg_weight,omitempty"`
+	MinWeight      *float64         `json:"min_weight,omitempty"`
+	MaxWeight      *float64         `json:"max_weight,omitempty"`
+	AvgTemperature *float64         `json:"avg_temperature,omitempty"`
+	MinTemperature *float64         `json:"min_temperature,omitempty"`
+	MaxTemperature *float64         `json:"max_temperature,omitempty"`
+	AvgHumidity    *float64         `json:"avg_humidity,omitempty"`
+	MinHumidity    *float64         `json:"min_humidity,omitempty"`
+	MaxHumidity    *float64         `json:"max_humidity,omitempty"`
+	AvgLightLevel  *float64         `json:"avg_light_level,omitempty"`
+}
+
+func (SensorReadingRollup) TableName() string {
+	return "sensor_reading_rollups"
+}
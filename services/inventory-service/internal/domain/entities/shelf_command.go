@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"time"
+)
+
+// ShelfCommandType is an instruction sent down to a smart shelf
+type ShelfCommandType string
+
+const (
+	ShelfCommandLightUpSlot ShelfCommandType = "light_up_slot" // pick-to-light, guides the worker to the slot
+	ShelfCommandBlinkError  ShelfCommandType = "blink_error"
+	ShelfCommandLockSlot    ShelfCommandType = "lock_slot"
+	ShelfCommandUnlockSlot  ShelfCommandType = "unlock_slot"
+	ShelfCommandRescan      ShelfCommandType = "rescan"
+)
+
+func (t ShelfCommandType) IsValid() bool {
+	switch t {
+	case ShelfCommandLightUpSlot, ShelfCommandBlinkError, ShelfCommandLockSlot, ShelfCommandUnlockSlot, ShelfCommandRescan:
+		return true
+	}
+	return false
+}
+
+// RequiresSlot reports whether the command targets a single slot rather than the whole shelf
+func (t ShelfCommandType) RequiresSlot() bool {
+	return t != ShelfCommandRescan
+}
+
+type ShelfCommandStatus string
+
+const (
+	ShelfCommandStatusPending      ShelfCommandStatus = "pending"
+	ShelfCommandStatusSent         ShelfCommandStatus = "sent"
+	ShelfCommandStatusAcknowledged ShelfCommandStatus = "acknowledged"
+	ShelfCommandStatusFailed       ShelfCommandStatus = "failed"
+	ShelfCommandStatusTimedOut     ShelfCommandStatus = "timed_out"
+)
+
+// ShelfCommand is a command published to a shelf. Its ID doubles as the correlation ID the shelf echoes back
+// in its acknowledgement.
+type ShelfCommand struct {
+	ID             string             `json:"id" gorm:"primaryKey"`
+	ShelfID        string             `json:"shelf_id" gorm:"index"`
+	SlotID         string             `json:"slot_id,omitempty"`
+	Type           ShelfCommandType   `json:"type"`
+	Params         JSON               `json:"params,omitempty" gorm:"type:jsonb"`
+	OperationID    string             `json:"operation_id,omitempty"` // operation the command was issued for, if any
+	Status         ShelfCommandStatus `json:"status" gorm:"index"`
+	Error          string             `json:"error,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+	SentAt         *time.Time         `json:"sent_at,omitempty"`
+	AcknowledgedAt *time.Time         `json:"acknowledged_at,omitempty"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+func (ShelfCommand) TableName() string {
+	return "shelf_commands"
+}
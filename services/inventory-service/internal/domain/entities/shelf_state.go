@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"time"
+)
+
+// ShelfStateStatus is the connectivity and operational status of a physical shelf
+type ShelfStateStatus string
+
+const (
+	ShelfStateOnline      ShelfStateStatus = "online"
+	ShelfStateOffline     ShelfStateStatus = "offline"
+	ShelfStateMaintenance ShelfStateStatus = "maintenance"
+	ShelfStateDegraded    ShelfStateStatus = "degraded"
+)
+
+// shelfStateTransitions lists the statuses reachable from each status.
+// A shelf in maintenance has to come back online before it can report itself as degraded.
+var shelfStateTransitions = map[ShelfStateStatus][]ShelfStateStatus{
+	ShelfStateOnline:      {ShelfStateOffline, ShelfStateMaintenance, ShelfStateDegraded},
+	ShelfStateDegraded:    {ShelfStateOnline, ShelfStateOffline, ShelfStateMaintenance},
+	ShelfStateOffline:     {ShelfStateOnline, ShelfStateDegraded, ShelfStateMaintenance},
+	ShelfStateMaintenance: {ShelfStateOnline, ShelfStateOffline},
+}
+
+func (s ShelfStateStatus) IsValid() bool {
+	_, ok := shelfStateTransitions[s]
+	return ok
+}
+
+func (s ShelfStateStatus) CanTransitionTo(next ShelfStateStatus) bool {
+	for _, allowed := range shelfStateTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsPlacements reports whether material can be placed on a shelf in this status
+func (s ShelfStateStatus) AcceptsPlacements() bool {
+	return s == ShelfStateOnline || s == ShelfStateDegraded
+}
+
+// ShelfState is the persisted status of a shelf, driven by the status messages and heartbeats the shelf sends over MQTT
+type ShelfState struct {
+	ShelfID         string           `json:"shelf_id" gorm:"primaryKey"`
+	Status          ShelfStateStatus `json:"status"`
+	LastHeartbeatAt time.Time        `json:"last_heartbeat_at" gorm:"index"`
+	StatusChangedAt time.Time        `json:"status_changed_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+func (ShelfState) TableName() string {
+	return "shelf_states"
+}
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SlotErrorType classifies the faults a smart shelf can report for a slot
+type SlotErrorType string
+
+const (
+	SlotErrorTypeSensorFault     SlotErrorType = "sensor_fault"
+	SlotErrorTypeJammed          SlotErrorType = "jammed"
+	SlotErrorTypeLEDFault        SlotErrorType = "led_fault"
+	SlotErrorTypeRFIDReadFailure SlotErrorType = "rfid_read_failure"
+	SlotErrorTypeDoorOpen        SlotErrorType = "door_open"
+	SlotErrorTypeWeightMismatch  SlotErrorType = "weight_mismatch"
+	SlotErrorTypeUnknown         SlotErrorType = "unknown"
+)
+
+// legacySlotErrorTypes maps error names used by older shelf firmware onto the current taxonomy
+var legacySlotErrorTypes = map[string]SlotErrorType{
+	"sensor_error": SlotErrorTypeSensorFault,
+}
+
+// ParseSlotErrorType normalizes a reported error type, falling back to SlotErrorTypeUnknown
+func ParseSlotErrorType(value string) SlotErrorType {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if errorType, ok := legacySlotErrorTypes[value]; ok {
+		return errorType
+	}
+
+	switch errorType := SlotErrorType(value); errorType {
+	case SlotErrorTypeSensorFault, SlotErrorTypeJammed, SlotErrorTypeLEDFault, SlotErrorTypeRFIDReadFailure,
+		SlotErrorTypeDoorOpen, SlotErrorTypeWeightMismatch:
+		return errorType
+	default:
+		return SlotErrorTypeUnknown
+	}
+}
+
+// SlotErrorRemediation is the action taken when a slot error is reported
+type SlotErrorRemediation string
+
+const (
+	SlotErrorRemediationMaintenance   SlotErrorRemediation = "maintenance"   // take the slot out of service
+	SlotErrorRemediationVerification  SlotErrorRemediation = "verification"  // ask an operator to check the slot contents
+	SlotErrorRemediationInvestigation SlotErrorRemediation = "investigation" // raise an alert, keep the slot in service
+	SlotErrorRemediationAutoClear     SlotErrorRemediation = "auto_clear"    // record the error and resolve it immediately
+)
+
+func (r SlotErrorRemediation) IsValid() bool {
+	switch r {
+	case SlotErrorRemediationMaintenance, SlotErrorRemediationVerification,
+		SlotErrorRemediationInvestigation, SlotErrorRemediationAutoClear:
+		return true
+	}
+	return false
+}
+
+// DefaultSlotErrorRemediations returns the remediation used for each error type unless overridden by configuration
+func DefaultSlotErrorRemediations() map[SlotErrorType]SlotErrorRemediation {
+	return map[SlotErrorType]SlotErrorRemediation{
+		SlotErrorTypeSensorFault:     SlotErrorRemediationMaintenance,
+		SlotErrorTypeJammed:          SlotErrorRemediationMaintenance,
+		SlotErrorTypeLEDFault:        SlotErrorRemediationMaintenance,
+		SlotErrorTypeRFIDReadFailure: SlotErrorRemediationVerification,
+		SlotErrorTypeDoorOpen:        SlotErrorRemediationAutoClear,
+		SlotErrorTypeWeightMismatch:  SlotErrorRemediationVerification,
+		SlotErrorTypeUnknown:         SlotErrorRemediationInvestigation,
+	}
+}
+
+// ParseSlotErrorRemediations parses overrides in the form "jammed=investigation,door_open=auto_clear"
+func ParseSlotErrorRemediations(spec string) (map[SlotErrorType]SlotErrorRemediation, error) {
+	remediations := make(map[SlotErrorType]SlotErrorRemediation)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid slot error remediation %q, expected <error_type>=<remediation>", pair)
+		}
+
+		errorType := ParseSlotErrorType(parts[0])
+		if errorType == SlotErrorTypeUnknown && strings.TrimSpace(parts[0]) != string(SlotErrorTypeUnknown) {
+			return nil, fmt.Errorf("unknown slot error type %q", parts[0])
+		}
+
+		remediation := SlotErrorRemediation(strings.TrimSpace(parts[1]))
+		if !remediation.IsValid() {
+			return nil, fmt.Errorf("unknown slot error remediation %q", parts[1])
+		}
+
+		remediations[errorType] = remediation
+	}
+	return remediations, nil
+}
@@ -31,6 +31,16 @@ func (Slot) TableName() string {
 	return "slots"
 }
 
+// IsStaleEvent reports whether a physical event describes the slot as it was before its last committed change.
+// Events that carry the slot version they refer to are compared by version, other events by timestamp,
+// allowing for clock skew between the shelf and the service.
+func (s *Slot) IsStaleEvent(eventTime time.Time, eventSlotVersion int64, clockSkew time.Duration) bool {
+	if eventSlotVersion > 0 {
+		return eventSlotVersion < s.Version
+	}
+	return eventTime.Add(clockSkew).Before(s.UpdatedAt)
+}
+
 func (Slot) IsSuitableForMaterialType(materialType string) bool {
 	// add logic to determine if the slot is suitable for the given material type
 	return true
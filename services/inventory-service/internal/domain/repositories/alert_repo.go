@@ -13,4 +13,6 @@ type AlertRepository interface {
     UpdateStatus(ctx context.Context, id string, status string) error
     MarkAsResolved(ctx context.Context, id string) error
     List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entities.Alert, error)
+    // GetActiveByShelfID returns the active and acknowledged alerts of the given type on a shelf
+    GetActiveByShelfID(ctx context.Context, shelfID string, alertType entities.AlertType) ([]*entities.Alert, error)
 }
\ No newline at end of file
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"WMS/services/inventory-service/internal/domain/entities"
+	"context"
+	"gorm.io/gorm"
+)
+
+type MaintenanceTicketFilter struct {
+	SlotID  string
+	ShelfID string
+	Status  entities.MaintenanceTicketStatus
+	Limit   int
+	Offset  int
+}
+
+type MaintenanceTicketRepository interface {
+	Create(ctx context.Context, ticket *entities.MaintenanceTicket) error
+	CreateWithTx(ctx context.Context, tx *gorm.DB, ticket *entities.MaintenanceTicket) error
+	GetByID(ctx context.Context, id string) (*entities.MaintenanceTicket, error)
+	// GetActiveBySlotID returns the open or in progress ticket of a slot
+	GetActiveBySlotID(ctx context.Context, slotID string) (*entities.MaintenanceTicket, error)
+	Update(ctx context.Context, ticket *entities.MaintenanceTicket) error
+	UpdateWithTx(ctx context.Context, tx *gorm.DB, ticket *entities.MaintenanceTicket) error
+	List(ctx context.Context, filter MaintenanceTicketFilter) ([]*entities.MaintenanceTicket, error)
+}
@@ -6,6 +6,29 @@ import (
 	"gorm.io/gorm"
 )
 
+// MaterialSearchFilter narrows a material search. Query is matched against the barcode, name and type,
+// the other fields must match exactly. Empty fields are ignored.
+type MaterialSearchFilter struct {
+	Query   string
+	Type    string
+	Status  entities.MaterialStatus
+	ShelfID string // materials currently in a slot of this shelf
+}
+
+// MaterialFacetCount is the number of matching materials sharing one value of a facet
+type MaterialFacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// MaterialFacets counts the matching materials per type, status and shelf. Each facet ignores its own
+// filter, so the alternatives to a selected value stay visible.
+type MaterialFacets struct {
+	Types    []MaterialFacetCount `json:"types"`
+	Statuses []MaterialFacetCount `json:"statuses"`
+	Shelves  []MaterialFacetCount `json:"shelves"`
+}
+
 type MaterialRepository interface {
 	Create(ctx context.Context, material *entities.Material) error
 	GetByID(ctx context.Context, id string) (*entities.Material, error)
@@ -13,5 +36,8 @@ type MaterialRepository interface {
 	Update(ctx context.Context, material *entities.Material) error
 	UpdateWithTx(ctx context.Context, tx *gorm.DB, material *entities.Material) error
 	List(ctx context.Context, limit, offset int) ([]*entities.Material, error)
-	Search(ctx context.Context, query string, limit, offset int) ([]*entities.Material, error)
+	// Search returns the best matches first, ties and searches without a query newest first
+	Search(ctx context.Context, filter MaterialSearchFilter, limit, offset int) ([]*entities.Material, error)
+	CountSearch(ctx context.Context, filter MaterialSearchFilter) (int64, error)
+	SearchFacets(ctx context.Context, filter MaterialSearchFilter) (*MaterialFacets, error)
 }
\ No newline at end of file
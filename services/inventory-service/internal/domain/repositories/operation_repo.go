@@ -8,6 +8,78 @@ import (
 	"gorm.io/gorm"
 )
 
+// OperationFilter narrows an operation listing, zero values match everything. From is inclusive, To exclusive.
+type OperationFilter struct {
+	ShelfID    string
+	SlotID     string
+	OperatorID string
+	MaterialID string
+	Type       entities.OperationType
+	Status     entities.OperationStatus
+	From       time.Time
+	To         time.Time
+}
+
+// OperationSortField is a column operations can be ordered by. The operation ID always breaks ties,
+// which keeps the order total so it can be paged through with a keyset cursor.
+type OperationSortField string
+
+const (
+	OperationSortByTimestamp  OperationSortField = "timestamp"
+	OperationSortByShelfID    OperationSortField = "shelf_id"
+	OperationSortBySlotID     OperationSortField = "slot_id"
+	OperationSortByOperatorID OperationSortField = "operator_id"
+	OperationSortByMaterialID OperationSortField = "material_id"
+	OperationSortByType       OperationSortField = "type"
+	OperationSortByStatus     OperationSortField = "status"
+)
+
+func (f OperationSortField) IsValid() bool {
+	switch f {
+	case OperationSortByTimestamp, OperationSortByShelfID, OperationSortBySlotID, OperationSortByOperatorID,
+		OperationSortByMaterialID, OperationSortByType, OperationSortByStatus:
+		return true
+	}
+	return false
+}
+
+// ValueOf returns the sort key of an operation, timestamps in RFC 3339 with nanoseconds
+func (f OperationSortField) ValueOf(operation *entities.Operation) string {
+	switch f {
+	case OperationSortByShelfID:
+		return operation.ShelfID
+	case OperationSortBySlotID:
+		return operation.SlotID
+	case OperationSortByOperatorID:
+		return operation.OperatorID
+	case OperationSortByMaterialID:
+		return operation.MaterialID
+	case OperationSortByType:
+		return string(operation.Type)
+	case OperationSortByStatus:
+		return string(operation.Status)
+	default:
+		return operation.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// OperationKey is the position of an operation in a sorted listing, as returned by OperationSortField.ValueOf
+type OperationKey struct {
+	SortValue string
+	ID        string
+}
+
+// OperationListOptions selects a page of operations. When After is set the page starts right behind that
+// key and Offset must be zero; keyset pages stay fast however deep the history goes.
+type OperationListOptions struct {
+	Filter     OperationFilter
+	SortBy     OperationSortField
+	Descending bool
+	After      *OperationKey
+	Limit      int
+	Offset     int
+}
+
 type OperationRepository interface {
 	Create(ctx context.Context, operation *entities.Operation) error
 	CreateWithTx(ctx context.Context, tx *gorm.DB, operation *entities.Operation) error
@@ -15,9 +87,13 @@ type OperationRepository interface {
 	GetByShelfID(ctx context.Context, shelfID string, limit, offset int) ([]*entities.Operation, error)
 	GetByOperatorID(ctx context.Context, operatorID string, limit, offset int) ([]*entities.Operation, error)
 	List(ctx context.Context, limit int, offset int) ([]*entities.Operation, error)
+	Find(ctx context.Context, options OperationListOptions) ([]*entities.Operation, error)
+	Count(ctx context.Context, filter OperationFilter) (int64, error)
 	GetTimedOutPendingPhysicalConfirmations(ctx context.Context, timeout time.Duration) ([]*entities.Operation, error)
 	GetPendingPhysicalConfirmationsBySlotID(ctx context.Context, slotID string) ([]*entities.Operation, error)
 	GetPendingRemovalConfirmationsBySlotID(ctx context.Context, slotID string) ([]*entities.Operation, error)
 	BeginTx(ctx context.Context) (*gorm.DB, error)
 	UpdateWithTx(ctx context.Context, tx *gorm.DB, operation *entities.Operation) error
+	// TransitionStatusWithTx moves an operation from one status to another and reports false if it was no longer in the from status
+	TransitionStatusWithTx(ctx context.Context, tx *gorm.DB, id string, from, to entities.OperationStatus) (bool, error)
 }
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"gorm.io/gorm"
+)
+
+// OperationTransitionRepository is append-only, transitions are written in the transaction that changes the operation
+type OperationTransitionRepository interface {
+	CreateWithTx(ctx context.Context, tx *gorm.DB, transition *entities.OperationTransition) error
+	// GetByOperationID returns the timeline of an operation, oldest first
+	GetByOperationID(ctx context.Context, operationID string) ([]*entities.OperationTransition, error)
+	// GetByMaterialID returns the transitions of every operation on a material, oldest first
+	GetByMaterialID(ctx context.Context, materialID string) ([]*entities.OperationTransition, error)
+	// ListAfter returns up to limit transitions with an ID greater than afterID in log order
+	ListAfter(ctx context.Context, afterID int64, limit int) ([]*entities.OperationTransition, error)
+}
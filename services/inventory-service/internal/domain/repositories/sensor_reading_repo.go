@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+)
+
+// SensorReadingFilter selects telemetry for a shelf (and optionally a single slot) over a time range.
+type SensorReadingFilter struct {
+	ShelfID    string
+	SlotID     string
+	From       time.Time
+	To         time.Time
+	Resolution entities.SensorResolution
+	Limit      int
+}
+
+type SensorReadingRepository interface {
+	Create(ctx context.Context, reading *entities.SensorReading) error
+	ListReadings(ctx context.Context, filter SensorReadingFilter) ([]*entities.SensorReading, error)
+	GetLatestWeightBySlotID(ctx context.Context, slotID string, before time.Time) (*entities.SensorReading, error)
+	ListRollups(ctx context.Context, filter SensorReadingFilter) ([]*entities.SensorReadingRollup, error)
+	Rollup(ctx context.Context, resolution entities.SensorResolution, from, to time.Time) error
+	// CreateMonthlyPartition creates the sensor_readings partition for the month containing the given time, if missing
+	CreateMonthlyPartition(ctx context.Context, month time.Time) error
+}
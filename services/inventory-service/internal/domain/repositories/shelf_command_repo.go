@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+)
+
+type ShelfCommandRepository interface {
+	Create(ctx context.Context, command *entities.ShelfCommand) error
+	GetByID(ctx context.Context, id string) (*entities.ShelfCommand, error)
+	Update(ctx context.Context, command *entities.ShelfCommand) error
+	// MarkSent moves a pending command to sent and reports false if it had already moved on, e.g. was acknowledged
+	MarkSent(ctx context.Context, id string, sentAt time.Time) (bool, error)
+	// ListAwaitingAck returns commands sent before the given time that the shelf has not acknowledged yet
+	ListAwaitingAck(ctx context.Context, sentBefore time.Time) ([]*entities.ShelfCommand, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+)
+
+type ShelfStateRepository interface {
+	// GetByShelfID returns nil without an error if the shelf has never reported its status
+	GetByShelfID(ctx context.Context, shelfID string) (*entities.ShelfState, error)
+	// Save inserts the state or overwrites the existing state of the shelf
+	Save(ctx context.Context, state *entities.ShelfState) error
+	TouchHeartbeat(ctx context.Context, shelfID string, at time.Time) error
+	// ListStale returns shelves that are expected to send heartbeats but have not done so since the given time
+	ListStale(ctx context.Context, lastHeartbeatBefore time.Time) ([]*entities.ShelfState, error)
+}
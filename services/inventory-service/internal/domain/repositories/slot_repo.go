@@ -15,4 +15,8 @@ type SlotRepository interface {
 	BeginTx(ctx context.Context) (*gorm.DB, error)
 	List(ctx context.Context, limit, offset int) ([]*entities.Slot, error)
 	GetEmptySlotsByShelf(ctx context.Context, shelfID string) ([]*entities.Slot, error)
+	// GetByMaterialID returns the slot holding a material, or nil when it is not on a shelf
+	GetByMaterialID(ctx context.Context, materialID string) (*entities.Slot, error)
+	// ListShelfIDs returns every shelf that has slots, ordered by ID
+	ListShelfIDs(ctx context.Context) ([]string, error)
 }
\ No newline at end of file
@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 
+	"WMS/shared/events"
 	"WMS/services/inventory-service/internal/domain/entities"
 	"WMS/services/inventory-service/pkg/utils/logger"
 )
@@ -22,16 +23,16 @@ func NewAlertService(eventService *EventService) *AlertService {
 }
 
 func (s *AlertService) SendShelfHealthAlert(ctx context.Context, health *entities.ShelfHealth) {
-	alert := map[string]interface{}{
-		"type":         entities.AlertTypeShelfHealth,
-		"shelf_id":     health.ShelfID,
-		"health_score": health.HealthScore,
-		"message":      fmt.Sprintf("Shelf %s health score is %.2f%%", health.ShelfID, health.HealthScore),
-		"severity":     s.determineSeverity(health.HealthScore),
-		"timestamp":    health.LastCheckTime,
+	alert := &events.ShelfHealthAlertEvent{
+		BaseEvent:   events.BaseEvent{EventType: EventTypeShelfHealthAlert, AggregateID: health.ShelfID, Timestamp: health.LastCheckTime},
+		AlertType:   string(entities.AlertTypeShelfHealth),
+		ShelfID:     health.ShelfID,
+		HealthScore: health.HealthScore,
+		Message:     fmt.Sprintf("Shelf %s health score is %.2f%%", health.ShelfID, health.HealthScore),
+		Severity:    string(s.determineSeverity(health.HealthScore)),
 	}
 
-	if err := s.eventService.PublishEvent(ctx, EventTypeShelfHealthAlert, alert); err != nil {
+	if err := s.eventService.PublishEvent(ctx, alert); err != nil {
 		logger.Error("Failed to send shelf health alert", err)
 	}
 }
@@ -0,0 +1,74 @@
+/*
+ * CommandGuardService keeps commands from being applied twice. Message buses deliver at least once,
+ * so a command is redelivered when a consumer fails or the group rebalances before its offset is
+ * committed. Handled command IDs are remembered in Redis and their redeliveries skipped.
+ */
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type CommandGuardVerdict string
+
+const (
+	CommandGuardAccepted   CommandGuardVerdict = "accepted"
+	CommandGuardHandled    CommandGuardVerdict = "handled"
+	CommandGuardInProgress CommandGuardVerdict = "in_progress"
+)
+
+// commandClaimTTL bounds how long a consumer that died while handling a command keeps others from taking it over
+const commandClaimTTL = 5 * time.Minute
+
+type CommandGuardService struct {
+	redisClient *redis.Client
+	dedupTTL    time.Duration
+}
+
+func NewCommandGuardService(redisClient *redis.Client, dedupTTL time.Duration) *CommandGuardService {
+	return &CommandGuardService{
+		redisClient: redisClient,
+		dedupTTL:    dedupTTL,
+	}
+}
+
+// Admit claims a command for handling until Complete is called. A command that was handled before,
+// or is being handled by another consumer, is not admitted.
+func (s *CommandGuardService) Admit(ctx context.Context, commandID string) (CommandGuardVerdict, error) {
+	claimed, err := s.redisClient.SetNX(ctx, s.dedupKey(commandID), "processing", commandClaimTTL).Result()
+	if err != nil {
+		return "", err
+	}
+	if claimed {
+		return CommandGuardAccepted, nil
+	}
+
+	state, err := s.redisClient.Get(ctx, s.dedupKey(commandID)).Result()
+	if err == redis.Nil {
+		// the claim expired in between, the next delivery takes it
+		return CommandGuardInProgress, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if state == "done" {
+		return CommandGuardHandled, nil
+	}
+	return CommandGuardInProgress, nil
+}
+
+// Complete finishes an admitted command. A command that is to be redelivered is released so that the redelivery is handled.
+func (s *CommandGuardService) Complete(ctx context.Context, commandID string, redeliver bool) error {
+	if redeliver {
+		return s.redisClient.Del(ctx, s.dedupKey(commandID)).Err()
+	}
+	return s.redisClient.Set(ctx, s.dedupKey(commandID), "done", s.dedupTTL).Err()
+}
+
+func (s *CommandGuardService) dedupKey(commandID string) string {
+	return fmt.Sprintf("command:%s", commandID)
+}
@@ -0,0 +1,128 @@
+/*
+ * EventGuardService protects the processing of physical shelf events against MQTT QoS 1
+ * redeliveries and out of order delivery. It deduplicates events by ID in Redis, tracks the
+ * last sequence number seen per slot and rejects events that are older than the slot state.
+ */
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"WMS/services/inventory-service/internal/domain/repositories"
+)
+
+type EventGuardVerdict string
+
+const (
+	EventGuardAccepted   EventGuardVerdict = "accepted"
+	EventGuardDuplicate  EventGuardVerdict = "duplicate"
+	EventGuardOutOfOrder EventGuardVerdict = "out_of_order"
+	EventGuardStale      EventGuardVerdict = "stale"
+)
+
+// ShelfEventMeta identifies a shelf event for deduplication and ordering
+type ShelfEventMeta struct {
+	ShelfID     string
+	SlotID      string
+	EventID     string // unique per event, stays the same across redeliveries
+	Sequence    int64  // per shelf, increasing; 0 if the shelf does not number its events
+	Timestamp   time.Time
+	SlotVersion int64 // slot version the shelf last received in a command; 0 if unknown
+	// ChangesSlotState marks events (detections, removals) that must not be applied on top of a newer slot state
+	ChangesSlotState bool
+}
+
+// advanceSequenceScript stores the highest sequence and timestamp seen for a slot and returns 0 if the
+// event is older on both counts. A lower sequence with a newer timestamp means the shelf restarted
+// its numbering and is accepted. The sequence is kept for ARGV[3] milliseconds after the last event.
+var advanceSequenceScript = redis.NewScript(`
+local last_seq = tonumber(redis.call("hget", KEYS[1], "seq"))
+local last_ts = tonumber(redis.call("hget", KEYS[1], "ts"))
+local seq = tonumber(ARGV[1])
+local ts = tonumber(ARGV[2])
+if last_seq and seq < last_seq and ts <= last_ts then
+	return 0
+end
+if not last_seq or seq > last_seq or ts > last_ts then
+	redis.call("hset", KEYS[1], "seq", seq, "ts", ts)
+end
+redis.call("pexpire", KEYS[1], ARGV[3])
+return 1
+`)
+
+// eventClaimTTL bounds how long a replica that died while processing an event keeps its redeliveries from being processed
+const eventClaimTTL = 5 * time.Minute
+
+type EventGuardService struct {
+	redisClient *redis.Client
+	slotRepo    repositories.SlotRepository
+	dedupTTL    time.Duration
+	clockSkew   time.Duration
+}
+
+func NewEventGuardService(redisClient *redis.Client, slotRepo repositories.SlotRepository, dedupTTL, clockSkew time.Duration) *EventGuardService {
+	return &EventGuardService{
+		redisClient: redisClient,
+		slotRepo:    slotRepo,
+		dedupTTL:    dedupTTL,
+		clockSkew:   clockSkew,
+	}
+}
+
+// Admit decides whether an event should be processed. An accepted event is claimed until Complete is called,
+// so a concurrent redelivery is reported as a duplicate.
+func (s *EventGuardService) Admit(ctx context.Context, meta ShelfEventMeta) (EventGuardVerdict, error) {
+	claimed, err := s.redisClient.SetNX(ctx, s.dedupKey(meta), "processing", eventClaimTTL).Result()
+	if err != nil {
+		return "", err
+	}
+	if !claimed {
+		return EventGuardDuplicate, nil
+	}
+
+	// Events of different slots may be delivered out of order, e.g. to different replicas through a shared
+	// subscription, and do not affect each other. Only events of the same slot are ordered.
+	if meta.Sequence > 0 {
+		inOrder, err := advanceSequenceScript.Run(ctx, s.redisClient, []string{s.sequenceKey(meta)},
+			meta.Sequence, meta.Timestamp.UnixMilli(), s.dedupTTL.Milliseconds()).Int()
+		if err != nil {
+			s.release(ctx, meta)
+			return "", err
+		}
+		if inOrder == 0 {
+			return EventGuardOutOfOrder, nil
+		}
+	}
+
+	if meta.ChangesSlotState && meta.SlotID != "" {
+		// unknown slots are left to the event handler to reject
+		if slot, err := s.slotRepo.GetByID(ctx, meta.SlotID); err == nil && slot.IsStaleEvent(meta.Timestamp, meta.SlotVersion, s.clockSkew) {
+			return EventGuardStale, nil
+		}
+	}
+
+	return EventGuardAccepted, nil
+}
+
+// Complete finishes an accepted event. A failed event is released so that its redelivery is processed again.
+func (s *EventGuardService) Complete(ctx context.Context, meta ShelfEventMeta, processingErr error) error {
+	if processingErr != nil {
+		return s.release(ctx, meta)
+	}
+	return s.redisClient.Set(ctx, s.dedupKey(meta), "done", s.dedupTTL).Err()
+}
+
+func (s *EventGuardService) release(ctx context.Context, meta ShelfEventMeta) error {
+	return s.redisClient.Del(ctx, s.dedupKey(meta)).Err()
+}
+
+func (s *EventGuardService) sequenceKey(meta ShelfEventMeta) string {
+	return fmt.Sprintf("shelf_event_seq:%s:%s", meta.ShelfID, meta.SlotID)
+}
+
+func (s *EventGuardService) dedupKey(meta ShelfEventMeta) string {
+	return fmt.Sprintf("shelf_event:%s:%s", meta.ShelfID, meta.EventID)
+}
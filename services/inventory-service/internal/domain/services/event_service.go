@@ -3,46 +3,60 @@ package services
 import (
     "context"
     "encoding/json"
+    "time"
     
-    "github.com/IBM/sarama"
+    "WMS/shared/events"
+    "WMS/services/inventory-service/pkg/errors"
     "WMS/services/inventory-service/pkg/utils/logger"
 )
 
+// eventSource identifies this service in the envelope of the events it publishes
+const eventSource = "inventory-service"
+
 type EventService struct {
-    producer sarama.SyncProducer
-    topic    string
+    bus     MessageBus
+    topic   string
+    schemas *events.SchemaRegistry // nil disables schema validation
 }
 
-func NewEventService(brokers []string, topic string) (*EventService, error) {
-    config := sarama.NewConfig()
-    config.Producer.RequiredAcks = sarama.WaitForAll
-    config.Producer.Retry.Max = 5
-    config.Producer.Return.Successes = true
-    
-    producer, err := sarama.NewSyncProducer(brokers, config)
-    if err != nil {
-        return nil, err
-    }
-    
+func NewEventService(bus MessageBus, topic string, schemas *events.SchemaRegistry) *EventService {
     return &EventService{
-        producer: producer,
-        topic:    topic,
-    }, nil
+        bus:     bus,
+        topic:   topic,
+        schemas: schemas,
+    }
 }
 
-func (s *EventService) PublishEvent(ctx context.Context, eventType string, event interface{}) error {
+// PublishEvent completes the event envelope and publishes it keyed by its aggregate, so events of one shelf stay in order.
+// Events that do not match the schema of their type are rejected before they reach the consumers.
+func (s *EventService) PublishEvent(ctx context.Context, event events.Event) error {
+    envelope := event.Envelope()
+    s.completeEnvelope(ctx, envelope)
+
     data, err := json.Marshal(event)
     if err != nil {
         return err
     }
+
+    if s.schemas != nil {
+        if err := s.schemas.Validate(envelope.EventType, data); err != nil {
+            logger.Error("Event failed schema validation", err)
+            return errors.NewValidationError("event does not match its schema", err)
+        }
+    }
     
-    msg := &sarama.ProducerMessage{
+    msg := BusMessage{
         Topic: s.topic,
-        Key:   sarama.StringEncoder(eventType),
-        Value: sarama.ByteEncoder(data),
+        Key:   envelope.PartitionKey(),
+        Headers: map[string]string{
+            events.HeaderEventType:     envelope.EventType,
+            events.HeaderEventVersion:  envelope.Version,
+            events.HeaderCorrelationID: envelope.CorrelationID,
+        },
+        Payload: data,
     }
     
-    _, _, err = s.producer.SendMessage(msg)
+    err = s.bus.Publish(ctx, msg)
     if err != nil {
         logger.Error("Failed to publish event", err)
         return err
@@ -51,6 +65,39 @@ func (s *EventService) PublishEvent(ctx context.Context, eventType string, event
     return nil
 }
 
+// completeEnvelope fills in the envelope fields publishers usually leave empty.
+// Correlation and causation IDs come from the context, an event without them starts a new correlation.
+func (s *EventService) completeEnvelope(ctx context.Context, envelope *events.BaseEvent) {
+    if envelope.EventID == "" {
+        envelope.EventID = generateUUID()
+    }
+    if envelope.Version == "" {
+        envelope.Version = events.EnvelopeVersion
+    }
+    if envelope.Timestamp.IsZero() {
+        envelope.Timestamp = time.Now()
+    }
+    if envelope.Source == "" {
+        envelope.Source = eventSource
+    }
+
+    correlationID, causationID := events.TraceFromContext(ctx)
+    if envelope.CorrelationID == "" {
+        envelope.CorrelationID = correlationID
+    }
+    if envelope.CausationID == "" {
+        envelope.CausationID = causationID
+    }
+    if envelope.CorrelationID == "" {
+        envelope.CorrelationID = envelope.EventID
+    }
+}
+
+// Subscribe delivers the events published on the service's topic to the handler until the context is cancelled
+func (s *EventService) Subscribe(ctx context.Context, group string, handler BusHandler) error {
+    return s.bus.Subscribe(ctx, s.topic, group, handler)
+}
+
 func (s *EventService) Close() error {
-    return s.producer.Close()
-}
\ No newline at end of file
+    return s.bus.Close()
+}
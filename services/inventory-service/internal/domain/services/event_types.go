@@ -8,6 +8,11 @@ const (
 
 	// Slot Events
 	EventTypeMaterialReserved = "material.reserved"
+	EventTypeSlotsReserved = "slots.reserved"
+	EventTypeSlotError = "slot.error" // Raw slot fault from a physical shelf, republished with the applied remediation
+	EventTypeSlotTakenOutOfService = "slot.taken_out_of_service" // Event for a slot put under maintenance
+	EventTypeSlotReturnedToService = "slot.returned_to_service" // Event for a slot leaving maintenance
+	EventTypeRelocationSuggested = "material.relocation_suggested" // Event for material stuck in a slot under maintenance
 
 	// Physical Placement Events
 	EventTypeMaterialDetected = "material.detected" // Raw event from physical sensor
@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"math"
 	"time"
 
 	
@@ -12,8 +14,16 @@ import (
 	"WMS/services/inventory-service/pkg/utils/logger"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// defaultWeightToleranceRatio is the share of a material's unit weight accepted as
+// measurement noise when the material has no explicit tolerance configured.
+const defaultWeightToleranceRatio = 0.05
+
+// maxPlacementSuggestions is the number of slots asked from location-service, the best ones may already be taken
+const maxPlacementSuggestions = 5
+
 type PlaceMaterialParams struct {
 	MaterialBarcode string
 	SlotID          string
@@ -33,6 +43,16 @@ type MoveMaterialParams struct {
 	Reason     string
 }
 
+// FindOptimalSlotParams describes the material a slot is looked for, StorageClass and Role are passed on
+// to the storage policies of location-service
+type FindOptimalSlotParams struct {
+	MaterialType string
+	ShelfID      string
+	ZoneID       string
+	StorageClass string
+	Role         string
+}
+
 type ReserveSlotsParams struct {
 	SlotIDs    []string
 	OperatorID string
@@ -47,6 +67,7 @@ type InventoryService struct {
 	materialRepo    repositories.MaterialRepository
 	slotRepo        repositories.SlotRepository
 	operationRepo   repositories.OperationRepository
+	operationTransitionRepo repositories.OperationTransitionRepository
 	alertRepo       repositories.AlertRepository
 	lockService     *LockService
 	eventService    *EventService
@@ -55,13 +76,22 @@ type InventoryService struct {
 	alertService    *AlertService
 	retryService 	*RetryService
 	failedEventRepo repositories.FailedEventRepository
+	telemetryService *TelemetryService
+	maintenanceTicketRepo repositories.MaintenanceTicketRepository
+	shelfStateRepo  repositories.ShelfStateRepository
+	shelfCommandService *ShelfCommandService
+	locationClient  LocationClient
+	slotErrorRemediations map[entities.SlotErrorType]entities.SlotErrorRemediation
 }
 
 // NewInventoryService creates a new instance of the InventoryService.
+// locationClient may be nil, zones are then left out of material traces.
+// slotErrorRemediations overrides the default remediation per slot error type and may be nil.
 func NewInventoryService(
 	materialRepo repositories.MaterialRepository,
 	slotRepo repositories.SlotRepository,
 	operationRepo repositories.OperationRepository,
+	operationTransitionRepo repositories.OperationTransitionRepository,
 	alertRepo repositories.AlertRepository,
 	lockService *LockService,
 	eventService *EventService,
@@ -70,11 +100,23 @@ func NewInventoryService(
 	alertService *AlertService,
 	retryService *RetryService,
 	failedEventRepo repositories.FailedEventRepository,
+	telemetryService *TelemetryService,
+	maintenanceTicketRepo repositories.MaintenanceTicketRepository,
+	shelfStateRepo repositories.ShelfStateRepository,
+	shelfCommandService *ShelfCommandService,
+	locationClient LocationClient,
+	slotErrorRemediations map[entities.SlotErrorType]entities.SlotErrorRemediation,
 ) *InventoryService {
+	remediations := entities.DefaultSlotErrorRemediations()
+	for errorType, remediation := range slotErrorRemediations {
+		remediations[errorType] = remediation
+	}
+
 	return &InventoryService{
 		materialRepo:    materialRepo,
 		slotRepo:        slotRepo,
 		operationRepo:   operationRepo,
+		operationTransitionRepo: operationTransitionRepo,
 		alertRepo:       alertRepo,
 		lockService:     lockService,
 		eventService:    eventService,
@@ -83,6 +125,12 @@ func NewInventoryService(
 		alertService:    alertService,
 		retryService: 	 retryService,
 		failedEventRepo: failedEventRepo,
+		telemetryService: telemetryService,
+		maintenanceTicketRepo: maintenanceTicketRepo,
+		shelfStateRepo:  shelfStateRepo,
+		shelfCommandService: shelfCommandService,
+		locationClient:  locationClient,
+		slotErrorRemediations: remediations,
 	}
 }
 
@@ -173,6 +221,9 @@ func (s *InventoryService) MoveMaterial(ctx context.Context, params MoveMaterial
 	if toSlot.Status != entities.SlotStatusEmpty {
 		return errors.NewConflictError("target slot is not empty", nil)
 	}
+	if err := s.ensureShelfAcceptsPlacements(ctx, toSlot.ShelfID); err != nil {
+		return err
+	}
 
 	// acquire locks on both source and target shelves
 	locks := s.acquireMultipleShelfLocks(ctx, []string{fromSlot.ShelfID, toSlot.ShelfID})
@@ -222,11 +273,32 @@ func (s *InventoryService) ReserveSlots(ctx context.Context, param ReserveSlotsP
 		return err
 	}
 
+	s.publishSlotsReservedEvents(ctx, param, shelfIDs, slotShelfMap)
+	if s.locationClient != nil {
+		for _, slotID := range param.SlotIDs {
+			if slot, err := s.slotRepo.GetByID(ctx, slotID); err == nil {
+				s.syncSlotWithLocation(ctx, slot)
+			}
+		}
+	}
 	return nil
 }
 
-func (s *InventoryService) FindOptimalSlot(ctx context.Context, materialType string, shelfID string) (*entities.Slot, error) {
-	slots, err := s.slotRepo.GetEmptySlotsByShelf(ctx, shelfID)
+// FindOptimalSlot prefers the zone-aware suggestions of location-service and falls back to picking an
+// empty slot of the shelf locally when location-service is unavailable or all of its suggestions are stale.
+// A placement the storage policies of location-service reject is not placed locally either.
+// ZoneID is optional; without a ShelfID there is nothing to fall back to.
+func (s *InventoryService) FindOptimalSlot(ctx context.Context, params FindOptimalSlotParams) (*entities.Slot, error) {
+	slot, err := s.suggestedSlot(ctx, params)
+	if err != nil || slot != nil {
+		return slot, err
+	}
+
+	if params.ShelfID == "" {
+		return nil, errors.NewNotFoundError("location-service has no available suggestion and no shelf was given to search", nil)
+	}
+
+	slots, err := s.slotRepo.GetEmptySlotsByShelf(ctx, params.ShelfID)
 	if err != nil {
 		return nil, err
 	}
@@ -235,7 +307,45 @@ func (s *InventoryService) FindOptimalSlot(ctx context.Context, materialType str
 		return nil, errors.NewNotFoundError("no empty slots available", nil)
 	}
 
-	return s.selectBestSlot(slots, materialType)
+	return s.selectBestSlot(slots, params.MaterialType)
+}
+
+// suggestedSlot returns the best slot location-service suggests that inventory agrees is empty and on the
+// requested shelf, nil otherwise. Several candidates are asked for, so a suggestion location-service has
+// not caught up on does not end the search. It only fails when location-service rejects the placement.
+func (s *InventoryService) suggestedSlot(ctx context.Context, params FindOptimalSlotParams) (*entities.Slot, error) {
+	if s.locationClient == nil {
+		return nil, nil
+	}
+
+	suggestions, err := s.locationClient.SuggestPlacement(ctx, PlacementRequest{
+		MaterialType: params.MaterialType,
+		ZoneID:       params.ZoneID,
+		ShelfID:      params.ShelfID,
+		StorageClass: params.StorageClass,
+		Role:         params.Role,
+		Limit:        maxPlacementSuggestions,
+	})
+	if stderrors.Is(err, ErrNoSuitableSlot) {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("no slot for material type %s is allowed by the storage policies", params.MaterialType), err)
+	}
+	if err != nil {
+		logger.Error("Failed to get a placement suggestion from location-service, selecting locally", err)
+		return nil, nil
+	}
+
+	for _, suggestion := range suggestions {
+		if params.ShelfID != "" && suggestion.ShelfID != params.ShelfID {
+			continue
+		}
+		slot, err := s.slotRepo.GetByID(ctx, suggestion.SlotID)
+		if err != nil || slot.Status != entities.SlotStatusEmpty || !slot.IsSuitableForMaterialType(params.MaterialType) {
+			logger.Info(fmt.Sprintf("Ignoring placement suggestion %s from location-service, the slot is not available", suggestion.SlotID))
+			continue
+		}
+		return slot, nil
+	}
+	return nil, nil
 }
 
 func (s *InventoryService) BatchPlaceMaterials(ctx context.Context, params []PlaceMaterialParams) error {
@@ -245,6 +355,12 @@ func (s *InventoryService) BatchPlaceMaterials(ctx context.Context, params []Pla
 		return errors.NewInternalError("failed to group commands by shelf", err)
 	}
 	
+	for shelfID := range shelfGroups {
+		if err := s.ensureShelfAcceptsPlacements(ctx, shelfID); err != nil {
+			return err
+		}
+	}
+
 	for shelfID, shelfParams := range shelfGroups {
 		lockKey := fmt.Sprintf("shelf:%s", shelfID)
 		unlock, err := s.lockService.AcquireLock(ctx, lockKey, 60*time.Second)
@@ -298,49 +414,70 @@ func (s *InventoryService) HealthCheckShelf(ctx context.Context, shelfID string)
 	return health, nil
 }
 
-func (s *InventoryService) HandleSlotError(ctx context.Context, slotID string, errorType string) error {
+// HandleSlotError records a slot error reported by a shelf and applies the remediation configured
+// for its type. Unrecognized error types are handled as SlotErrorTypeUnknown.
+func (s *InventoryService) HandleSlotError(ctx context.Context, slotID string, errorType string, details map[string]interface{}) error {
+	return s.handleSlotError(ctx, entities.ParseSlotErrorType(errorType), slotID, details)
+}
+
+// handleSlotError records the error as an alert, keeping any details (e.g. measured vs expected weight)
+// in the alert metadata, and applies the remediation for the error type.
+func (s *InventoryService) handleSlotError(ctx context.Context, errorType entities.SlotErrorType, slotID string, details entities.JSON) error {
 	slot, err := s.slotRepo.GetByID(ctx, slotID)
 	if err != nil {
-		return err
+		return errors.NewNotFoundError(fmt.Sprintf("slot %s not found", slotID), err)
+	}
+
+	remediation, ok := s.slotErrorRemediations[errorType]
+	if !ok {
+		remediation = entities.SlotErrorRemediationInvestigation
 	}
 
 	// log the error
 	alert := &entities.Alert{
 		ID:        generateUUID(),
-		Type:      "slot_error",
+		Type:      entities.AlertTypeSlotError,
 		ShelfID:   slot.ShelfID,
 		SlotID:    slotID,
 		Message:   fmt.Sprintf("Slot error: %s", errorType),
-		Severity:  "high",
+		Severity:  entities.AlertSeverityHigh,
 		CreatedAt: time.Now(),
-		Status:    "active",
+		Status:    entities.AlertStatusActive,
+		Metadata:  details,
+	}
+	if remediation == entities.SlotErrorRemediationAutoClear {
+		alert.Severity = entities.AlertSeverityLow
 	}
 	if err := s.alertRepo.Create(ctx, alert); err != nil {
 		logger.Error("Failed to create alert", err)
 	}
 
-	// handle the error based on its type
-	switch errorType {
-	case "sensor_error":
-		return s.markSlotForMaintenance(ctx, slotID, "sensor malfunction")
-	case "weight_mismatch":
-		return s.triggerManualVerification(ctx, slotID)
+	s.publishSlotErrorEvent(ctx, slot, errorType, remediation, details)
+	if remediation != entities.SlotErrorRemediationAutoClear {
+		s.sendShelfCommand(ctx, SendShelfCommandParams{
+			ShelfID: slot.ShelfID,
+			SlotID:  slot.ID,
+			Type:    entities.ShelfCommandBlinkError,
+			Params:  map[string]interface{}{"error_type": string(errorType)},
+		})
+	}
+
+	// handle the error based on the configured remediation
+	switch remediation {
+	case entities.SlotErrorRemediationMaintenance:
+		return s.markSlotForMaintenance(ctx, slotID, string(errorType))
+	case entities.SlotErrorRemediationVerification:
+		return s.triggerManualVerification(ctx, slotID, details)
+	case entities.SlotErrorRemediationAutoClear:
+		if err := s.alertRepo.MarkAsResolved(ctx, alert.ID); err != nil {
+			logger.Error(fmt.Sprintf("Failed to auto-clear alert %s", alert.ID), err)
+		}
+		return nil
 	default:
-		return s.markSlotForInvestigation(ctx, slotID, errorType)
+		return s.markSlotForInvestigation(ctx, slotID, string(errorType))
 	}
 }
 
-func (s *InventoryService) UpdateShelfStatus(ctx context.Context, shelfID string, status string) error {
-	// update the shelf status in the cache
-	cacheKey := fmt.Sprintf("shelf_status:%s", shelfID)
-	statusData := map[string]interface{}{
-		"status":     status,
-		"updated_at": time.Now(),
-	}
-
-	return s.cacheService.Set(ctx, cacheKey, statusData, time.Hour)
-}
-
 func (s *InventoryService) GetShelfStatus(ctx context.Context, shelfID string) (*entities.ShelfStatus, error) {
 	// attempt to get the shelf status from the cache
 	if status, err := s.cacheService.GetShelfStatus(ctx, shelfID); err == nil && status != nil {
@@ -394,6 +531,9 @@ func (s *InventoryService) validatePlacementPreconditions(ctx context.Context, p
 	if slot.Status != entities.SlotStatusEmpty {
 		return errors.NewConflictError("slot is not available", nil)
 	}
+	if err := s.ensureShelfAcceptsPlacements(ctx, slot.ShelfID); err != nil {
+		return err
+	}
 
 	material, err := s.materialRepo.GetByBarcode(ctx, params.MaterialBarcode)
 	if err != nil {
@@ -423,13 +563,13 @@ func (s *InventoryService) executePlaceMaterial(ctx context.Context, params Plac
 	slot.MaterialID = &material.ID
 	slot.UpdatedAt = time.Now()
 	slot.Version++
-	if err := s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
+	if err = s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
 		return errors.NewConflictError("failed to update slot", err)
 	}
 
 	material.Status = entities.MaterialStatusInUse
 	material.UpdatedAt = time.Now()
-	if err := s.materialRepo.UpdateWithTx(ctx, tx, material); err != nil {
+	if err = s.materialRepo.UpdateWithTx(ctx, tx, material); err != nil {
 		return errors.NewInternalError("failed to update material", err)
 	}
 
@@ -443,16 +583,28 @@ func (s *InventoryService) executePlaceMaterial(ctx context.Context, params Plac
 		Timestamp:  time.Now(),
 		Status:     entities.OperationStatusPendingPhysicalConfirmation,
 	}
-	if err := s.operationRepo.CreateWithTx(ctx, tx, operation); err != nil {
+	if err = s.operationRepo.CreateWithTx(ctx, tx, operation); err != nil {
 		return errors.NewInternalError("failed to record operation", err)
 	}
+	if err = s.recordCreationWithTx(ctx, tx, operation, "", ""); err != nil {
+		return err
+	}
 
-	if err := tx.Commit(); err != nil {
-		return errors.NewInternalError("failed to commit transaction", err.Error)
+	if err = tx.Commit().Error; err != nil {
+		return errors.NewInternalError("failed to commit transaction", err)
 	}
 
-	// Publish event to request physical placement
-	s.publishPhysicalPlacementRequestedEvent(ctx, operation)
+	s.syncSlotWithLocation(ctx, slot)
+
+	// Publish event to request physical placement and guide the worker to the slot
+	s.publishPhysicalPlacementRequestedEvent(ctx, operation, material.Barcode)
+	s.sendShelfCommand(ctx, SendShelfCommandParams{
+		ShelfID:     slot.ShelfID,
+		SlotID:      slot.ID,
+		Type:        entities.ShelfCommandLightUpSlot,
+		Params:      map[string]interface{}{"purpose": string(entities.OperationTypePlacement), "slot_version": slot.Version},
+		OperationID: operation.ID,
+	})
 	return nil
 }
 
@@ -480,7 +632,7 @@ func (s *InventoryService) executeRemoveMaterial(ctx context.Context, param Remo
 	slot.Status = entities.SlotStatusRemovalPending
 	slot.UpdatedAt = time.Now()
 	slot.Version++
-	if err := s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
+	if err = s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
 		return errors.NewConflictError("failed to update slot", err)
 	}
 
@@ -495,15 +647,26 @@ func (s *InventoryService) executeRemoveMaterial(ctx context.Context, param Remo
 		// Status:     entities.OperationStatusCompleted,
 		Status: entities.OperationStatusPendingRemovalConfirmation,
 	}
-	if err := s.operationRepo.CreateWithTx(ctx, tx, operation); err != nil {
+	if err = s.operationRepo.CreateWithTx(ctx, tx, operation); err != nil {
 		return errors.NewInternalError("failed to record operation", err)
 	}
+	if err = s.recordCreationWithTx(ctx, tx, operation, "", param.Reason); err != nil {
+		return err
+	}
 
-	if err := tx.Commit(); err != nil {
-		return errors.NewInternalError("failed to commit transaction", err.Error)
+	if err = tx.Commit().Error; err != nil {
+		return errors.NewInternalError("failed to commit transaction", err)
 	}
 
+	s.syncSlotWithLocation(ctx, slot)
 	s.publishMaterialRemovedEvent(ctx, operation)
+	s.sendShelfCommand(ctx, SendShelfCommandParams{
+		ShelfID:     slot.ShelfID,
+		SlotID:      slot.ID,
+		Type:        entities.ShelfCommandLightUpSlot,
+		Params:      map[string]interface{}{"purpose": string(entities.OperationTypeRemoval), "slot_version": slot.Version},
+		OperationID: operation.ID,
+	})
 
 	return nil
 }
@@ -535,11 +698,8 @@ func (s *InventoryService) executeMoveMaterial(ctx context.Context, param MoveMa
 	}
 
 	// Update fromSlot
-	fromSlot.Status = entities.SlotStatusEmpty
-	fromSlot.MaterialID = nil
-	fromSlot.UpdatedAt = time.Now()
-	fromSlot.Version++
-	if err := s.slotRepo.UpdateWithTx(ctx, tx, fromSlot); err != nil {
+	vacateSlot(fromSlot)
+	if err = s.slotRepo.UpdateWithTx(ctx, tx, fromSlot); err != nil {
 		return errors.NewConflictError("failed to update from_slot", err)
 	}
 
@@ -548,7 +708,7 @@ func (s *InventoryService) executeMoveMaterial(ctx context.Context, param MoveMa
 	toSlot.MaterialID = &material.ID
 	toSlot.UpdatedAt = time.Now()
 	toSlot.Version++
-	if err := s.slotRepo.UpdateWithTx(ctx, tx, toSlot); err != nil {
+	if err = s.slotRepo.UpdateWithTx(ctx, tx, toSlot); err != nil {
 		return errors.NewConflictError("failed to update to_slot", err)
 	}
 
@@ -562,14 +722,18 @@ func (s *InventoryService) executeMoveMaterial(ctx context.Context, param MoveMa
 		Timestamp:  time.Now(),
 		Status:     entities.OperationStatusCompleted,
 	}
-	if err := s.operationRepo.CreateWithTx(ctx, tx, operation); err != nil {
+	if err = s.operationRepo.CreateWithTx(ctx, tx, operation); err != nil {
 		return errors.NewInternalError("failed to record operation", err)
 	}
+	if err = s.recordCreationWithTx(ctx, tx, operation, param.FromSlotID, param.Reason); err != nil {
+		return err
+	}
 
-	if err := tx.Commit(); err != nil {
-		return errors.NewInternalError("failed to commit transaction", err.Error)
+	if err = tx.Commit().Error; err != nil {
+		return errors.NewInternalError("failed to commit transaction", err)
 	}
 
+	s.syncSlotWithLocation(ctx, fromSlot, toSlot)
 	s.publishMaterialMovedEvent(ctx, operation, param.FromSlotID)
 
 	return nil
@@ -600,8 +764,8 @@ func (s *InventoryService) executeReserveSlots(ctx context.Context, params Reser
 			return errors.NewConflictError(fmt.Sprintf("failed to reserve slot %s", slotID), err)
 		}
 	}
-	if err := tx.Commit(); err != nil {
-		return errors.NewInternalError("failed to commit transaction", err.Error)
+	if err = tx.Commit().Error; err != nil {
+		return errors.NewInternalError("failed to commit transaction", err)
 	}
 	return nil
 }
@@ -653,31 +817,15 @@ func (s *InventoryService) executeBatchPlacement(ctx context.Context, params []P
 	return tx.Commit().Error
 }
 
-func (s *InventoryService) markSlotForMaintenance(ctx context.Context, slotID, reason string) error {
-	slot, err := s.slotRepo.GetByID(ctx, slotID)
-	if err != nil {
-		return err
+func (s *InventoryService) triggerManualVerification(ctx context.Context, slotID string, details entities.JSON) error {
+	eventDetails := map[string]interface{}{
+		"slot_id": slotID,
 	}
-
-	slot.Status = entities.SlotStatusMaintenance
-	slot.UpdatedAt = time.Now()
-	slot.Version++
-	if err := s.slotRepo.Update(ctx, slot); err != nil {
-		return err
+	for key, value := range details {
+		eventDetails[key] = value
 	}
 
-	s.publishSystemAlertEvent(ctx, "slot_maintenance", "warning", fmt.Sprintf("Slot %s marked for maintenance: %s", slotID, reason), map[string]interface{}{
-		"slot_id": slotID,
-		"reason":  reason,
-	})
-
-	return nil
-}
-
-func (s *InventoryService) triggerManualVerification(ctx context.Context, slotID string) error {
-	s.publishSystemAlertEvent(ctx, "manual_verification_required", "high", fmt.Sprintf("Manual verification required for slot %s", slotID), map[string]interface{}{
-		"slot_id": slotID,
-	})
+	s.publishSystemAlertEvent(ctx, "manual_verification_required", "high", fmt.Sprintf("Manual verification required for slot %s", slotID), eventDetails)
 
 	return nil
 }
@@ -691,20 +839,6 @@ func (s *InventoryService) markSlotForInvestigation(ctx context.Context, slotID,
 	return nil
 }
 
-func (s *InventoryService) publishSystemAlertEvent(ctx context.Context, alertType entities.AlertType, severity entities.AlertSeverity, message string, details map[string]interface{}) {
-	event := &entities.SystemAlertEvent{
-		Type:      alertType,
-		Severity:  severity,
-		Message:   message,
-		Timestamp: time.Now(),
-		Details:   details,
-	}
-
-	if err := s.eventService.PublishEvent(ctx, "system_alert", event); err != nil {
-		logger.Error("Failed to publish system alert event", err)
-	}
-}
-
 func (s *InventoryService) acquireMultipleShelfLocks(ctx context.Context, shelfIDs []string) []func() {
 	unlockFuncs := make([]func(), 0)
 	for _, shelfID := range shelfIDs {
@@ -724,24 +858,161 @@ func (s *InventoryService) releaseMultipleLocks(unlockFuncs []func()) {
 	}
 }
 
-func (s *InventoryService) SearchMaterials(ctx context.Context, query string, limit, offset int) ([]*entities.Material, error) {
-	// Search materials by barcode or name
-	materials, err := s.materialRepo.Search(ctx, query, limit, offset)
-	if err != nil {
-		return nil, errors.NewInternalError("failed to search materials", err)
+// sendShelfCommand sends a command to the shelf hardware on a best effort basis; the inventory
+// operation that triggered it does not fail if the shelf cannot be reached.
+func (s *InventoryService) sendShelfCommand(ctx context.Context, params SendShelfCommandParams) {
+	if s.shelfCommandService == nil {
+		return
+	}
+	if _, err := s.shelfCommandService.SendCommand(ctx, params); err != nil {
+		logger.Error(fmt.Sprintf("Failed to send %s command to shelf %s", params.Type, params.ShelfID), err)
+	}
+}
+
+// syncSlotWithLocation mirrors a committed slot change to location-service on a best effort basis, so its
+// placement suggestions skip occupied slots. Inventory stays the source of truth for slot state.
+// vacateSlot clears the material from a slot. A slot under maintenance stays out of service,
+// its maintenance ticket returns it to service empty.
+func vacateSlot(slot *entities.Slot) {
+	if slot.Status != entities.SlotStatusMaintenance {
+		slot.Status = entities.SlotStatusEmpty
+	}
+	slot.MaterialID = nil
+	slot.UpdatedAt = time.Now()
+	slot.Version++
+}
+
+func (s *InventoryService) syncSlotWithLocation(ctx context.Context, slots ...*entities.Slot) {
+	if s.locationClient == nil {
+		return
+	}
+	for _, slot := range slots {
+		if err := s.locationClient.UpdateSlotStatus(ctx, slot); err != nil {
+			logger.Error(fmt.Sprintf("Failed to sync slot %s with location-service", slot.ID), err)
+		}
 	}
+}
 
-	if len(materials) == 0 {
-		return nil, errors.NewNotFoundError("no materials found", nil)
+// syncUnplannedChangeWithLocation tells location-service what a sensor found in a slot without an operation
+// behind it, so no placement is suggested into a slot that is physically taken. Inventory keeps its own slot
+// state until the unplanned change has been investigated, the alert raised for it keeps reconciliation from
+// reverting location-service in the meantime.
+func (s *InventoryService) syncUnplannedChangeWithLocation(ctx context.Context, slotID string, status entities.SlotStatus, materialID *string) {
+	if s.locationClient == nil {
+		return
 	}
+	slot, err := s.slotRepo.GetByID(ctx, slotID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load slot %s to sync with location-service", slotID), err)
+		return
+	}
+	s.raiseUnplannedChangeAlert(ctx, slot, status, materialID)
+	physical := *slot
+	physical.Status = status
+	physical.MaterialID = materialID
+	s.syncSlotWithLocation(ctx, &physical)
+}
 
-	return materials, nil
+// raiseUnplannedChangeAlert records what a sensor found in a slot that inventory has in another state
+func (s *InventoryService) raiseUnplannedChangeAlert(ctx context.Context, slot *entities.Slot, status entities.SlotStatus, materialID *string) {
+	metadata := entities.JSON{
+		"inventory_status": string(slot.Status),
+		"detected_status":  string(status),
+	}
+	if materialID != nil {
+		metadata["material_id"] = *materialID
+	}
+	alert := &entities.Alert{
+		ID:        generateUUID(),
+		Type:      entities.AlertTypeUnplannedChange,
+		ShelfID:   slot.ShelfID,
+		SlotID:    slot.ID,
+		Message:   fmt.Sprintf("Unplanned change: slot found %s", status),
+		Severity:  entities.AlertSeverityMedium,
+		CreatedAt: time.Now(),
+		Status:    entities.AlertStatusActive,
+		Metadata:  metadata,
+	}
+	if err := s.alertRepo.Create(ctx, alert); err != nil {
+		logger.Error("Failed to create alert", err)
+	}
 }
 
 func generateUUID() string {
 	return uuid.New().String()
 }
 
+// recordCreationWithTx logs how a new operation got to its initial status: the operator's request,
+// followed by the initial status itself. fromSlotID is only set for moves.
+func (s *InventoryService) recordCreationWithTx(ctx context.Context, tx *gorm.DB, operation *entities.Operation, fromSlotID, reason string) error {
+	for _, transition := range []struct{ from, to entities.OperationStatus }{
+		{"", entities.OperationStatusRequested},
+		{entities.OperationStatusRequested, operation.Status},
+	} {
+		err := s.operationTransitionRepo.CreateWithTx(ctx, tx, &entities.OperationTransition{
+			OperationID:   operation.ID,
+			OperationType: operation.Type,
+			FromStatus:    transition.from,
+			ToStatus:      transition.to,
+			Actor:         operation.OperatorID,
+			Reason:        reason,
+			MaterialID:    operation.MaterialID,
+			SlotID:        operation.SlotID,
+			FromSlotID:    fromSlotID,
+			ShelfID:       operation.ShelfID,
+			OccurredAt:    operation.Timestamp,
+		})
+		if err != nil {
+			return errors.NewInternalError("failed to record operation transition", err)
+		}
+	}
+	return nil
+}
+
+// transitionOperationWithTx moves an operation to a new status and appends the change to its timeline.
+// The operation must still be in the status it was read with, otherwise a concurrent confirmation
+// or timeout got there first and a ConflictError is returned.
+func (s *InventoryService) transitionOperationWithTx(ctx context.Context, tx *gorm.DB, operation *entities.Operation, status entities.OperationStatus, actor, reason string) error {
+	transition := &entities.OperationTransition{
+		OperationID:   operation.ID,
+		OperationType: operation.Type,
+		FromStatus:    operation.Status,
+		ToStatus:      status,
+		Actor:         actor,
+		Reason:        reason,
+		MaterialID:    operation.MaterialID,
+		SlotID:        operation.SlotID,
+		ShelfID:       operation.ShelfID,
+		OccurredAt:    time.Now(),
+	}
+
+	transitioned, err := s.operationRepo.TransitionStatusWithTx(ctx, tx, operation.ID, operation.Status, status)
+	if err != nil {
+		return errors.NewInternalError("failed to update operation status", err)
+	}
+	if !transitioned {
+		return errors.NewConflictError(fmt.Sprintf("operation %s is no longer %s", operation.ID, operation.Status), nil)
+	}
+	operation.Status = status
+	if err := s.operationTransitionRepo.CreateWithTx(ctx, tx, transition); err != nil {
+		return errors.NewInternalError("failed to record operation transition", err)
+	}
+	return nil
+}
+
+// GetOperationTimeline returns the status transitions of an operation, oldest first
+func (s *InventoryService) GetOperationTimeline(ctx context.Context, operationID string) ([]*entities.OperationTransition, error) {
+	if _, err := s.operationRepo.GetByID(ctx, operationID); err != nil {
+		return nil, errors.NewNotFoundError("operation not found", err)
+	}
+
+	transitions, err := s.operationTransitionRepo.GetByOperationID(ctx, operationID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to get operation timeline", err)
+	}
+	return transitions, nil
+}
+
 // ConfirmPhysicalPlacement confirms that a physical placement operation has been completed.
 func (s *InventoryService) ConfirmPhysicalPlacement(ctx context.Context, operationID string) error {
 	operation, err := s.operationRepo.GetByID(ctx, operationID)
@@ -763,14 +1034,12 @@ func (s *InventoryService) ConfirmPhysicalPlacement(ctx context.Context, operati
 		}
 	}()
 
-	operation.Status = entities.OperationStatusCompleted
-	operation.Timestamp = time.Now()
-	if err := s.operationRepo.UpdateWithTx(ctx, tx, operation); err != nil {
-		return errors.NewInternalError("failed to update operation status", err)
+	if err = s.transitionOperationWithTx(ctx, tx, operation, entities.OperationStatusCompleted, entities.ShelfActor(operation.ShelfID), ""); err != nil {
+		return err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return errors.NewInternalError("failed to commit transaction", err.Error)
+	if err = tx.Commit().Error; err != nil {
+		return errors.NewInternalError("failed to commit transaction", err)
 	}
 
 	// Publish material placed confirmed event (now that physical placement is confirmed)
@@ -803,11 +1072,8 @@ func (s *InventoryService) ConfirmPhysicalRemoval(ctx context.Context, operation
 	if err != nil {
 		return errors.NewNotFoundError("slot not found for confirmation", err)
 	}
-	slot.Status = entities.SlotStatusEmpty
-	slot.MaterialID = nil
-	slot.UpdatedAt = time.Now()
-	slot.Version++
-	if err := s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
+	vacateSlot(slot)
+	if err = s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
 		return errors.NewInternalError("failed to update slot status", err)
 	}
 
@@ -817,20 +1083,19 @@ func (s *InventoryService) ConfirmPhysicalRemoval(ctx context.Context, operation
 	}
 	material.Status = entities.MaterialStatusAvailable
 	material.UpdatedAt = time.Now()
-	if err := s.materialRepo.UpdateWithTx(ctx, tx, material); err != nil {
+	if err = s.materialRepo.UpdateWithTx(ctx, tx, material); err != nil {
 		return errors.NewInternalError("failed to update material", err)
 	}
 
-	operation.Status = entities.OperationStatusCompleted
-	operation.Timestamp = time.Now()
-	if err := s.operationRepo.UpdateWithTx(ctx, tx, operation); err != nil {
-		return errors.NewInternalError("failed to update operation status", err)
+	if err = s.transitionOperationWithTx(ctx, tx, operation, entities.OperationStatusCompleted, entities.ShelfActor(operation.ShelfID), ""); err != nil {
+		return err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return errors.NewInternalError("failed to commit transaction", err.Error)
+	if err = tx.Commit().Error; err != nil {
+		return errors.NewInternalError("failed to commit transaction", err)
 	}
 
+	s.syncSlotWithLocation(ctx, slot)
 	s.publishPhysicalRemovalConfirmedEvent(ctx, operation)
 	
 	return nil
@@ -862,11 +1127,8 @@ func (s *InventoryService) HandlePhysicalPlacementTimeout(ctx context.Context, o
 	if err != nil {
 		return errors.NewNotFoundError("slot not found for rollback", err)
 	}
-	slot.Status = entities.SlotStatusEmpty
-	slot.MaterialID = nil
-	slot.UpdatedAt = time.Now()
-	slot.Version++
-	if err := s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
+	vacateSlot(slot)
+	if err = s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
 		return errors.NewInternalError("failed to rollback slot status", err)
 	}
 
@@ -877,24 +1139,24 @@ func (s *InventoryService) HandlePhysicalPlacementTimeout(ctx context.Context, o
 	}
 	material.Status = entities.MaterialStatusAvailable
 	material.UpdatedAt = time.Now()
-	if err := s.materialRepo.UpdateWithTx(ctx, tx, material); err != nil {
+	if err = s.materialRepo.UpdateWithTx(ctx, tx, material); err != nil {
 		return errors.NewInternalError("failed to rollback material status", err)
 	}
 
 	// Update operation status to failed
-	operation.Status = entities.OperationStatusFailed
-	operation.Timestamp = time.Now()
-	if err := s.operationRepo.UpdateWithTx(ctx, tx, operation); err != nil {
-		return errors.NewInternalError("failed to update operation status to failed", err)
+	if err = s.transitionOperationWithTx(ctx, tx, operation, entities.OperationStatusFailed, entities.SystemActor, "physical confirmation timed out"); err != nil {
+		return err
 	}
 
 	// Publish physical placement failed event
 	s.publishPhysicalPlacementFailedEvent(ctx, operation)
 
-	if err := tx.Commit(); err != nil {
-		return errors.NewInternalError("failed to commit transaction", err.Error)
+	if err = tx.Commit().Error; err != nil {
+		return errors.NewInternalError("failed to commit transaction", err)
 	}
 
+	s.syncSlotWithLocation(ctx, slot)
+
 	return nil
 }
 
@@ -922,20 +1184,26 @@ func (s *InventoryService) HandlePhysicalRemovalTimeout(ctx context.Context, ope
 	if err != nil {
 		return errors.NewNotFoundError("slot not found for rollback", err)
 	}
-	slot.Status = entities.SlotStatusOccupied // Rollback to occupied status
+	if slot.Status != entities.SlotStatusMaintenance {
+		slot.Status = entities.SlotStatusOccupied // Rollback to occupied status
+	}
 	slot.UpdatedAt = time.Now()
 	slot.Version++
-	if err := s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
+	if err = s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
 		return errors.NewInternalError("failed to rollback slot status", err)
 	}
 
 	// Update operation status to failed
-	operation.Status = entities.OperationStatusFailed
-	operation.Timestamp = time.Now()
-	if err := s.operationRepo.UpdateWithTx(ctx, tx, operation); err != nil {
-		return errors.NewInternalError("failed to update operation status to failed", err)
+	if err = s.transitionOperationWithTx(ctx, tx, operation, entities.OperationStatusFailed, entities.SystemActor, "physical confirmation timed out"); err != nil {
+		return err
 	}
 
+	if err = tx.Commit().Error; err != nil {
+		return errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	s.syncSlotWithLocation(ctx, slot)
+
 	// Publish physical removal failed event
 	s.publishPhysicalRemovalFailedEvent(ctx, operation)
 
@@ -944,7 +1212,9 @@ func (s *InventoryService) HandlePhysicalRemovalTimeout(ctx context.Context, ope
 
 // HandleMaterialDetectedEvent handles a material detected event from a physical sensor.
 // It checks if this detection confirms a pending placement operation or is an unplanned placement.
-func (s *InventoryService) HandleMaterialDetectedEvent(ctx context.Context, slotID, materialBarcode string) error {
+// When the material has a known unit weight, the weight change reported by the slot sensor must match it
+// before the placement is confirmed; otherwise the slot is flagged with a weight_mismatch error.
+func (s *InventoryService) HandleMaterialDetectedEvent(ctx context.Context, slotID, materialBarcode string, measuredWeight *float64, detectedAt time.Time) error {
 	// First, try to find a pending physical confirmation operation for this slot
 	operations, err := s.operationRepo.GetPendingPhysicalConfirmationsBySlotID(ctx, slotID)
 	if err != nil {
@@ -952,21 +1222,87 @@ func (s *InventoryService) HandleMaterialDetectedEvent(ctx context.Context, slot
 		return err
 	}
 
+	// operations reference materials by ID while the sensor reports the barcode
+	// an unknown barcode is an unplanned placement, any other failure is left to the caller to retry
+	material, err := s.materialRepo.GetByBarcode(ctx, materialBarcode)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		material = nil
+	} else if err != nil {
+		logger.Error(fmt.Sprintf("Failed to look up material %s detected in slot %s", materialBarcode, slotID), err)
+		return err
+	}
+
 	for _, op := range operations {
 		// Check if the detected material matches the expected material for this operation
-		if op.MaterialID == materialBarcode { // Assuming materialBarcode from sensor matches MaterialID in operation
-			logger.Info(fmt.Sprintf("Confirming physical placement for operation %s in slot %s", op.ID, slotID))
-			return s.ConfirmPhysicalPlacement(ctx, op.ID)
+		if material == nil || op.MaterialID != material.ID {
+			continue
+		}
+
+		details, ok, err := s.verifyPlacementWeight(ctx, slotID, material, measuredWeight, detectedAt)
+		if err != nil {
+			// without the baseline the weight cannot be judged, the event is retried rather than flagging the slot
+			logger.Error(fmt.Sprintf("Failed to get the weight baseline of slot %s", slotID), err)
+			return err
 		}
+		if !ok {
+			details["operation_id"] = op.ID
+			logger.Info(fmt.Sprintf("Weight mismatch for operation %s in slot %s: measured %.2fg, expected %.2fg", op.ID, slotID, details["measured_weight"], details["expected_weight"]))
+			return s.handleSlotError(ctx, entities.SlotErrorTypeWeightMismatch, slotID, details)
+		}
+
+		logger.Info(fmt.Sprintf("Confirming physical placement for operation %s in slot %s", op.ID, slotID))
+		return s.ConfirmPhysicalPlacement(ctx, op.ID)
 	}
 
 	// If no matching pending operation is found, it's an unplanned placement
 	logger.Info(fmt.Sprintf("Unplanned material detected in slot %s with barcode %s. Triggering alert.", slotID, materialBarcode))
 	s.publishUnplannedPlacementEvent(ctx, slotID, materialBarcode)
+	var materialID *string
+	if material != nil {
+		materialID = &material.ID
+	}
+	s.syncUnplannedChangeWithLocation(ctx, slotID, entities.SlotStatusOccupied, materialID)
 
 	return nil
 }
 
+// verifyPlacementWeight compares the weight added to the slot with the material's unit weight.
+// The added weight is the measured weight minus the last reading before the detection, so
+// the scale does not need to be tared. Materials without a unit weight and events without a
+// weight reading are not verified. On mismatch the returned details carry the measured and
+// expected values.
+func (s *InventoryService) verifyPlacementWeight(ctx context.Context, slotID string, material *entities.Material, measuredWeight *float64, detectedAt time.Time) (entities.JSON, bool, error) {
+	if material.UnitWeight <= 0 || measuredWeight == nil {
+		return nil, true, nil
+	}
+
+	// an empty slot without any previous reading is assumed to weigh nothing
+	baseline, _, err := s.telemetryService.LatestSlotWeight(ctx, slotID, detectedAt)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tolerance := material.WeightTolerance
+	if tolerance <= 0 {
+		tolerance = material.UnitWeight * defaultWeightToleranceRatio
+	}
+
+	delta := *measuredWeight - baseline
+	if math.Abs(delta-material.UnitWeight) <= tolerance {
+		return nil, true, nil
+	}
+
+	return entities.JSON{
+		"material_id":      material.ID,
+		"material_barcode": material.Barcode,
+		"measured_weight":  delta,
+		"sensor_weight":    *measuredWeight,
+		"baseline_weight":  baseline,
+		"expected_weight":  material.UnitWeight,
+		"tolerance":        tolerance,
+	}, false, nil
+}
+
 func (s *InventoryService) HandleMaterialRemovedEvent(ctx context.Context, slotID string, materialBarcode string) error {
 	operations, err := s.operationRepo.GetPendingRemovalConfirmationsBySlotID(ctx, slotID)
 	if err != nil {
@@ -983,6 +1319,7 @@ func (s *InventoryService) HandleMaterialRemovedEvent(ctx context.Context, slotI
 
 	logger.Info(fmt.Sprintf("Unplanned removal detected in slot %s with barcode %s. Triggering alert.", slotID, materialBarcode))
 	s.publishUnplannedRemovalEvent(ctx, slotID, materialBarcode)
+	s.syncUnplannedChangeWithLocation(ctx, slotID, entities.SlotStatusEmpty, nil)
 
-	return s.ConfirmPhysicalRemoval(ctx, slotID, true)
+	return nil
 }
\ No newline at end of file
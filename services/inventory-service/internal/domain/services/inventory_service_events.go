@@ -2,236 +2,223 @@ package services
 
 import (
 	"context"
-	"time"
 
+	"WMS/shared/events"
 	"WMS/services/inventory-service/internal/domain/entities"
 	"WMS/services/inventory-service/pkg/utils/logger"
 )
 
-func (s *InventoryService) SaveFailedEventToDLQ(ctx context.Context, topic, eventType string, event any, originalErr error) {
-	// save the failed event to the dead-letter queue (DLQ)
+// SaveFailedEventToDLQ saves a failed event to the dead-letter queue (DLQ). Callers that still hold
+// the event elsewhere, like a consumer that can have it redelivered, act on the returned error.
+func (s *InventoryService) SaveFailedEventToDLQ(ctx context.Context, topic, eventType string, event any, originalErr error) error {
 	failedEvent, err := entities.NewFailedEvent(generateUUID(), topic, eventType, event, originalErr)
 	if err != nil {
 		logger.Error("Failed to create failed event", err)
-		return
+		return err
 	}
 
 	if err := s.failedEventRepo.Create(ctx, failedEvent); err != nil {
 		logger.Error("Failed to save failed event to DLQ", err)
+		return err
 	}
+	return nil
 }
 
-func (s *InventoryService) publishShelfStatusChangedEvent(ctx context.Context, shelfID string, oldStatus, newStatus string) {
-	event := struct {
-		EventID   string    `json:"event_id"`
-		ShelfID   string    `json:"shelf_id"`
-		OldStatus string    `json:"old_status"`
-		NewStatus string    `json:"new_status"`
-		Timestamp time.Time `json:"timestamp"`
-	}{
-		EventID:   generateUUID(),
-		ShelfID:   shelfID,
-		OldStatus: oldStatus,
-		NewStatus: newStatus,
-		Timestamp: time.Now(),
+// publishEvent publishes an event, parking it in the DLQ if the bus rejects it
+func (s *InventoryService) publishEvent(ctx context.Context, event events.Event, description string) {
+	eventType := event.Envelope().EventType
+	if err := s.eventService.PublishEvent(ctx, event); err != nil {
+		logger.Error("Failed to publish "+description+" event", err)
+		s.SaveFailedEventToDLQ(ctx, eventType, eventType, event, err)
 	}
+}
 
-	if err := s.eventService.PublishEvent(ctx, EventTypeShelfStatusChanged, event); err != nil {
-		logger.Error("Failed to publish shelf status changed event", err)
-		s.SaveFailedEventToDLQ(ctx, EventTypeShelfStatusChanged, EventTypeShelfStatusChanged, event, err)
-	}
+// newBaseEvent starts the envelope of an event about the given shelf, the rest is completed by EventService
+func newBaseEvent(eventType, shelfID string) events.BaseEvent {
+	return events.BaseEvent{EventType: eventType, AggregateID: shelfID}
 }
 
-func (s *InventoryService) publishPhysicalPlacementRequestedEvent(ctx context.Context, operation *entities.Operation) {
-	event := struct {
-		OperationID string    `json:"operation_id"`
-		MaterialID  string    `json:"material_id"`
-		SlotID      string    `json:"slot_id"`
-		ShelfID     string    `json:"shelf_id"`
-		OperatorID  string    `json:"operator_id"`
-		Timestamp   time.Time `json:"timestamp"`
-		EventType   string    `json:"event_type"`
-	}{
-		OperationID: operation.ID,
-		MaterialID:  operation.MaterialID,
-		SlotID:      operation.SlotID,
-		ShelfID:     operation.ShelfID,
-		OperatorID:  operation.OperatorID,
-		Timestamp:   time.Now(),
-		EventType:   EventTypePhysicalPlacementRequested,
+// shelfIDOfSlot returns the shelf of a slot for events that only know the slot, or "" if it cannot be found
+func (s *InventoryService) shelfIDOfSlot(ctx context.Context, slotID string) string {
+	slot, err := s.slotRepo.GetByID(ctx, slotID)
+	if err != nil {
+		return ""
 	}
+	return slot.ShelfID
+}
 
-	if err := s.eventService.PublishEvent(ctx, EventTypePhysicalPlacementRequested, event); err != nil {
-		logger.Error("Failed to publish physical placement requested event", err)
-		s.SaveFailedEventToDLQ(ctx, EventTypePhysicalPlacementRequested, EventTypePhysicalPlacementRequested, event, err)
-	}
+func (s *InventoryService) publishShelfStatusChangedEvent(ctx context.Context, shelfID string, oldStatus, newStatus string) {
+	s.publishEvent(ctx, &events.ShelfStatusChangedEvent{
+		BaseEvent: newBaseEvent(EventTypeShelfStatusChanged, shelfID),
+		ShelfID:   shelfID,
+		OldStatus: oldStatus, // empty for the first status reported by a shelf
+		NewStatus: newStatus,
+	}, "shelf status changed")
 }
 
-func (s *InventoryService) publishPhysicalPlacementConfirmedEvent(ctx context.Context, operation *entities.Operation) {
-	event := struct {
-		OperationID string    `json:"operation_id"`
-		MaterialID  string    `json:"material_id"`
-		SlotID      string    `json:"slot_id"`
-		ShelfID     string    `json:"shelf_id"`
-		OperatorID  string    `json:"operator_id"`
-		Timestamp   time.Time `json:"timestamp"`
-		EventType   string    `json:"event_type"`
-	}{
+func (s *InventoryService) newPhysicalPlacementEvent(eventType string, operation *entities.Operation) *events.PhysicalPlacementEvent {
+	return &events.PhysicalPlacementEvent{
+		BaseEvent:   newBaseEvent(eventType, operation.ShelfID),
 		OperationID: operation.ID,
 		MaterialID:  operation.MaterialID,
 		SlotID:      operation.SlotID,
 		ShelfID:     operation.ShelfID,
 		OperatorID:  operation.OperatorID,
-		Timestamp:   time.Now(),
-		EventType:   EventTypePhysicalPlacementConfirmed,
 	}
+}
 
-	if err := s.eventService.PublishEvent(ctx, EventTypePhysicalPlacementConfirmed, event); err != nil {
-		logger.Error("Failed to publish physical placement confirmed event", err)
-		s.SaveFailedEventToDLQ(ctx, EventTypePhysicalPlacementConfirmed, EventTypePhysicalPlacementConfirmed, event, err)
-	}
+func (s *InventoryService) publishPhysicalPlacementRequestedEvent(ctx context.Context, operation *entities.Operation, materialBarcode string) {
+	event := s.newPhysicalPlacementEvent(EventTypePhysicalPlacementRequested, operation)
+	event.MaterialBarcode = materialBarcode
+	s.publishEvent(ctx, event, "physical placement requested")
 }
 
-func (s *InventoryService) publishPhysicalPlacementFailedEvent(ctx context.Context, operation *entities.Operation) {
-	event := struct {
-		OperationID string    `json:"operation_id"`
-		MaterialID  string    `json:"material_id"`
-		SlotID      string    `json:"slot_id"`
-		ShelfID     string    `json:"shelf_id"`
-		OperatorID  string    `json:"operator_id"`
-		Timestamp   time.Time `json:"timestamp"`
-		EventType   string    `json:"event_type"`
-	}{
-		OperationID: operation.ID,
-		MaterialID:  operation.MaterialID,
-		SlotID:      operation.SlotID,
-		ShelfID:     operation.ShelfID,
-		OperatorID:  operation.OperatorID,
-		Timestamp:   time.Now(),
-		EventType:   EventTypePhysicalPlacementFailed,
-	}
+func (s *InventoryService) publishPhysicalPlacementConfirmedEvent(ctx context.Context, operation *entities.Operation) {
+	s.publishEvent(ctx, s.newPhysicalPlacementEvent(EventTypePhysicalPlacementConfirmed, operation), "physical placement confirmed")
+}
 
-	if err := s.eventService.PublishEvent(ctx, EventTypePhysicalPlacementFailed, event); err != nil {
-		logger.Error("Failed to publish physical placement failed event", err)
-		s.SaveFailedEventToDLQ(ctx, EventTypePhysicalPlacementFailed, EventTypePhysicalPlacementFailed, event, err)
-	}
+func (s *InventoryService) publishPhysicalPlacementFailedEvent(ctx context.Context, operation *entities.Operation) {
+	s.publishEvent(ctx, s.newPhysicalPlacementEvent(EventTypePhysicalPlacementFailed, operation), "physical placement failed")
 }
 
-func (s *InventoryService) publishPhysicalRemovalConfirmedEvent(ctx context.Context, operation *entities.Operation) {}
+// Removal events carry the same fields as placement events
+func (s *InventoryService) publishPhysicalRemovalConfirmedEvent(ctx context.Context, operation *entities.Operation) {
+	s.publishEvent(ctx, s.newPhysicalPlacementEvent(EventTypePhysicalRemovalConfirmed, operation), "physical removal confirmed")
+}
 
-func (s *InventoryService) publishPhysicalRemovalFailedEvent(ctx context.Context, operation *entities.Operation) {}
+func (s *InventoryService) publishPhysicalRemovalFailedEvent(ctx context.Context, operation *entities.Operation) {
+	s.publishEvent(ctx, s.newPhysicalPlacementEvent(EventTypePhysicalRemovalFailed, operation), "physical removal failed")
+}
 
 func (s *InventoryService) publishUnplannedPlacementEvent(ctx context.Context, slotID, materialBarcode string) {
-	event := struct {
-		SlotID          string    `json:"slot_id"`
-		MaterialBarcode string    `json:"material_barcode"`
-		Timestamp       time.Time `json:"timestamp"`
-		EventType       string    `json:"event_type"`
-	}{
+	shelfID := s.shelfIDOfSlot(ctx, slotID)
+	s.publishEvent(ctx, &events.UnplannedSlotEvent{
+		BaseEvent:       newBaseEvent(EventTypeUnplannedPlacement, shelfID),
 		SlotID:          slotID,
+		ShelfID:         shelfID,
 		MaterialBarcode: materialBarcode,
-		Timestamp:       time.Now(),
-		EventType:       EventTypeUnplannedPlacement,
-	}
+	}, "unplanned placement")
+}
+
+func (s *InventoryService) publishSlotErrorEvent(ctx context.Context, slot *entities.Slot, errorType entities.SlotErrorType, remediation entities.SlotErrorRemediation, details map[string]interface{}) {
+	s.publishEvent(ctx, &events.SlotErrorEvent{
+		BaseEvent:   newBaseEvent(EventTypeSlotError, slot.ShelfID),
+		SlotID:      slot.ID,
+		ShelfID:     slot.ShelfID,
+		ErrorType:   string(errorType),
+		Remediation: string(remediation),
+		Details:     details,
+	}, "slot error")
+}
 
-	if err := s.eventService.PublishEvent(ctx, EventTypeUnplannedPlacement, event); err != nil {
-		logger.Error("Failed to publish unplanned placement event", err)
-		s.SaveFailedEventToDLQ(ctx, EventTypeUnplannedPlacement, EventTypeUnplannedPlacement, event, err)
+func (s *InventoryService) publishRelocationSuggestedEvent(ctx context.Context, ticket *entities.MaintenanceTicket) {
+	s.publishEvent(ctx, &events.RelocationSuggestedEvent{
+		BaseEvent:        newBaseEvent(EventTypeRelocationSuggested, ticket.ShelfID),
+		TicketID:         ticket.ID,
+		SlotID:           ticket.SlotID,
+		ShelfID:          ticket.ShelfID,
+		MaterialID:       *ticket.MaterialID,
+		SuggestedSlotIDs: ticket.SuggestedSlotIDs,
+	}, "relocation suggested")
+}
+
+func (s *InventoryService) publishSlotTakenOutOfServiceEvent(ctx context.Context, ticket *entities.MaintenanceTicket) {
+	event := &events.SlotTakenOutOfServiceEvent{
+		BaseEvent: newBaseEvent(EventTypeSlotTakenOutOfService, ticket.ShelfID),
+		TicketID:  ticket.ID,
+		SlotID:    ticket.SlotID,
+		ShelfID:   ticket.ShelfID,
+		Reason:    ticket.Reason,
+	}
+	if ticket.MaterialID != nil {
+		event.MaterialID = *ticket.MaterialID
 	}
+	s.publishEvent(ctx, event, "slot taken out of service")
+}
+
+func (s *InventoryService) publishSlotReturnedToServiceEvent(ctx context.Context, slot *entities.Slot, ticket *entities.MaintenanceTicket) {
+	s.publishEvent(ctx, &events.SlotReturnedToServiceEvent{
+		BaseEvent: newBaseEvent(EventTypeSlotReturnedToService, slot.ShelfID),
+		TicketID:  ticket.ID,
+		SlotID:    slot.ID,
+		ShelfID:   slot.ShelfID,
+		Status:    string(slot.Status),
+	}, "slot returned to service")
 }
 
 func (s *InventoryService) publishUnplannedRemovalEvent(ctx context.Context, slotID string, materialBarcode string) {
-	event := struct {
-		SlotID          string    `json:"slot_id"`
-		MaterialBarcode string    `json:"material_barcode"`
-		Timestamp       time.Time `json:"timestamp"`
-		EventType       string    `json:"event_type"`
-	}{
+	shelfID := s.shelfIDOfSlot(ctx, slotID)
+	s.publishEvent(ctx, &events.UnplannedSlotEvent{
+		BaseEvent:       newBaseEvent(EventTypeUnplannedRemoval, shelfID),
 		SlotID:          slotID,
+		ShelfID:         shelfID,
 		MaterialBarcode: materialBarcode,
-		Timestamp:       time.Now(),
-		EventType:       EventTypeUnplannedRemoval,
-	}
-
-	if err := s.eventService.PublishEvent(ctx, EventTypeUnplannedRemoval, event); err != nil {
-		logger.Error("Failed to publish unplanned removal event", err)
-		s.SaveFailedEventToDLQ(ctx, EventTypeUnplannedRemoval, EventTypeUnplannedRemoval, event, err)
-	}
+	}, "unplanned removal")
 }
 
 func (s *InventoryService) publishMaterialPlacedEvent(ctx context.Context, operation *entities.Operation) {
-	event := struct {
-		EventID    string    `json:"event_id"`
-		MaterialID string    `json:"material_id"`
-		SlotID     string    `json:"slot_id"`
-		ShelfID    string    `json:"shelf_id"`
-		OperatorID string    `json:"operator_id"`
-		Timestamp  time.Time `json:"timestamp"`
-		EventType  string    `json:"event_type"`
-	}{
-		EventID:    generateUUID(),
-		MaterialID: operation.MaterialID,
-		SlotID:     operation.SlotID,
-		ShelfID:    operation.ShelfID,
-		OperatorID: operation.OperatorID,
-		Timestamp:  time.Now(),
-		EventType:  EventTypeMaterialPlaced,
-	}
-
-	if err := s.eventService.PublishEvent(ctx, EventTypeMaterialPlaced, event); err != nil {
-		logger.Error("Failed to publish material placed event", err)
-		s.SaveFailedEventToDLQ(ctx, EventTypeMaterialPlaced, EventTypeMaterialPlaced, event, err)
-	}
+	s.publishEvent(ctx, &events.MaterialPlacedEvent{
+		BaseEvent:   newBaseEvent(EventTypeMaterialPlaced, operation.ShelfID),
+		MaterialID:  operation.MaterialID,
+		SlotID:      operation.SlotID,
+		ShelfID:     operation.ShelfID,
+		OperatorID:  operation.OperatorID,
+		OperationID: operation.ID,
+	}, "material placed")
 }
 
 func (s *InventoryService) publishMaterialRemovedEvent(ctx context.Context, operation *entities.Operation) {
-	event := struct {
-		EventID    string    `json:"event_id"`
-		MaterialID string    `json:"material_id"`
-		SlotID     string    `json:"slot_id"`
-		ShelfID    string    `json:"shelf_id"`
-		OperatorID string    `json:"operator_id"`
-		Timestamp  time.Time `json:"timestamp"`
-		EventType  string    `json:"event_type"`
-	}{
-		EventID:    generateUUID(),
-		MaterialID: operation.MaterialID,
-		SlotID:     operation.SlotID,
-		ShelfID:    operation.ShelfID,
-		OperatorID: operation.OperatorID,
-		Timestamp:  time.Now(),
-		EventType:  EventTypeMaterialRemoved,
-	}
-
-	if err := s.eventService.PublishEvent(ctx, EventTypeMaterialRemoved, event); err != nil {
-		logger.Error("Failed to publish material removed event", err)
-		s.SaveFailedEventToDLQ(ctx, EventTypeMaterialRemoved, EventTypeMaterialRemoved, event, err)
-	}
+	s.publishEvent(ctx, &events.MaterialRemovedEvent{
+		BaseEvent:   newBaseEvent(EventTypeMaterialRemoved, operation.ShelfID),
+		MaterialID:  operation.MaterialID,
+		SlotID:      operation.SlotID,
+		ShelfID:     operation.ShelfID,
+		OperatorID:  operation.OperatorID,
+		OperationID: operation.ID,
+	}, "material removed")
 }
 
 func (s *InventoryService) publishMaterialMovedEvent(ctx context.Context, operation *entities.Operation, fromSlotID string) {
-	event := struct {
-		EventID    string    `json:"event_id"`
-		MaterialID string    `json:"material_id"`
-		FromSlotID string    `json:"from_slot_id"`
-		ToSlotID   string    `json:"to_slot_id"`
-		ShelfID    string    `json:"shelf_id"`
-		OperatorID string    `json:"operator_id"`
-		Timestamp  time.Time `json:"timestamp"`
-		EventType  string    `json:"event_type"`
-	}{
-		EventID:    generateUUID(),
+	s.publishEvent(ctx, &events.MaterialMovedEvent{
+		BaseEvent:  newBaseEvent(EventTypeMaterialMoved, operation.ShelfID),
 		MaterialID: operation.MaterialID,
 		FromSlotID: fromSlotID,
 		ToSlotID:   operation.SlotID,
 		ShelfID:    operation.ShelfID,
 		OperatorID: operation.OperatorID,
-		Timestamp:  time.Now(),
-		EventType:  EventTypeMaterialMoved,
+	}, "material moved")
+}
+
+func (s *InventoryService) publishSystemAlertEvent(ctx context.Context, alertType entities.AlertType, severity entities.AlertSeverity, message string, details map[string]interface{}) {
+	shelfID, _ := details["shelf_id"].(string)
+	event := &events.SystemAlertEvent{
+		BaseEvent: newBaseEvent(EventTypeSystemAlert, shelfID),
+		AlertType: string(alertType),
+		Severity:  string(severity),
+		Message:   message,
+		Details:   details,
+	}
+
+	if err := s.eventService.PublishEvent(ctx, event); err != nil {
+		logger.Error("Failed to publish system alert event", err)
 	}
+}
 
-	if err := s.eventService.PublishEvent(ctx, EventTypeMaterialMoved, event); err != nil {
-		logger.Error("Failed to publish material moved event", err)
-		s.SaveFailedEventToDLQ(ctx, EventTypeMaterialMoved, EventTypeMaterialMoved, event, err)
+// publishSlotsReservedEvents publishes one event per shelf, so the event is ordered with the other events of that shelf
+func (s *InventoryService) publishSlotsReservedEvents(ctx context.Context, param ReserveSlotsParams, shelfIDs []string, slotShelfMap map[string]string) {
+	for _, shelfID := range shelfIDs {
+		slotIDs := make([]string, 0)
+		for _, slotID := range param.SlotIDs {
+			if slotShelfMap[slotID] == shelfID {
+				slotIDs = append(slotIDs, slotID)
+			}
+		}
+		s.publishEvent(ctx, &events.SlotsReservedEvent{
+			BaseEvent:  newBaseEvent(EventTypeSlotsReserved, shelfID),
+			SlotIDs:    slotIDs,
+			OperatorID: param.OperatorID,
+			Duration:   param.Duration,
+			Purpose:    param.Purpose,
+		}, "slots reserved")
 	}
 }
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/pkg/errors"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// maxRelocationSuggestions caps the number of empty slots suggested for material stuck in a slot under maintenance
+const maxRelocationSuggestions = 3
+
+type StartSlotMaintenanceParams struct {
+	SlotID   string
+	Reason   string
+	Assignee string
+	Notes    string
+}
+
+type CompleteSlotMaintenanceParams struct {
+	SlotID     string
+	OperatorID string
+	Notes      string
+}
+
+// markSlotForMaintenance takes the slot out of service and opens a maintenance ticket for it.
+// Calling it for a slot that already has an active ticket keeps the existing ticket.
+func (s *InventoryService) markSlotForMaintenance(ctx context.Context, slotID, reason string) error {
+	_, err := s.openMaintenanceTicket(ctx, slotID, reason)
+	return err
+}
+
+func (s *InventoryService) openMaintenanceTicket(ctx context.Context, slotID, reason string) (*entities.MaintenanceTicket, error) {
+	if ticket, err := s.maintenanceTicketRepo.GetActiveBySlotID(ctx, slotID); err == nil && ticket != nil {
+		return ticket, nil
+	}
+
+	slot, err := s.slotRepo.GetByID(ctx, slotID)
+	if err != nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("slot %s not found", slotID), err)
+	}
+
+	unlock, err := s.lockService.AcquireLock(ctx, fmt.Sprintf("shelf:%s", slot.ShelfID), 30*time.Second)
+	if err != nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("failed to lock shelf %s", slot.ShelfID), err)
+	}
+	defer unlock()
+
+	// another ticket or operation may have been committed before the lock was taken
+	if ticket, err := s.maintenanceTicketRepo.GetActiveBySlotID(ctx, slotID); err == nil && ticket != nil {
+		return ticket, nil
+	}
+	if slot, err = s.slotRepo.GetByID(ctx, slotID); err != nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("slot %s not found", slotID), err)
+	}
+
+	ticket := &entities.MaintenanceTicket{
+		ID:         generateUUID(),
+		SlotID:     slot.ID,
+		ShelfID:    slot.ShelfID,
+		Reason:     reason,
+		Status:     entities.MaintenanceTicketStatusOpen,
+		MaterialID: slot.MaterialID,
+		// pending operations and reservations of the slot carry on once it is back in service
+		PreviousSlotStatus: slot.Status,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+	}
+	if slot.MaterialID != nil {
+		ticket.SuggestedSlotIDs = s.suggestRelocationSlots(ctx, slot)
+	}
+
+	tx, err := s.slotRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to start transaction", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	slot.Status = entities.SlotStatusMaintenance
+	slot.UpdatedAt = time.Now()
+	slot.Version++
+	if err = s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
+		return nil, errors.NewConflictError("failed to update slot", err)
+	}
+
+	if err = s.maintenanceTicketRepo.CreateWithTx(ctx, tx, ticket); err != nil {
+		return nil, errors.NewInternalError("failed to create maintenance ticket", err)
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	s.syncSlotWithLocation(ctx, slot)
+	s.publishSlotTakenOutOfServiceEvent(ctx, ticket)
+	s.publishSystemAlertEvent(ctx, "slot_maintenance", entities.AlertSeverityMedium, fmt.Sprintf("Slot %s marked for maintenance: %s", slotID, reason), map[string]interface{}{
+		"slot_id":   slotID,
+		"reason":    reason,
+		"ticket_id": ticket.ID,
+	})
+
+	if ticket.MaterialID != nil {
+		s.publishRelocationSuggestedEvent(ctx, ticket)
+	}
+
+	// keep workers from using the slot while it is out of service
+	s.sendShelfCommand(ctx, SendShelfCommandParams{
+		ShelfID: ticket.ShelfID,
+		SlotID:  ticket.SlotID,
+		Type:    entities.ShelfCommandLockSlot,
+		Params:  map[string]interface{}{"maintenance_ticket_id": ticket.ID},
+	})
+
+	return ticket, nil
+}
+
+// StartSlotMaintenance assigns the slot's maintenance ticket and marks the work as started.
+// If the slot is still in service, it is taken out of service first.
+func (s *InventoryService) StartSlotMaintenance(ctx context.Context, params StartSlotMaintenanceParams) (*entities.MaintenanceTicket, error) {
+	if params.SlotID == "" {
+		return nil, errors.NewValidationError("slot ID is required", nil)
+	}
+	if params.Assignee == "" {
+		return nil, errors.NewValidationError("assignee is required", nil)
+	}
+
+	reason := params.Reason
+	if reason == "" {
+		reason = "manual maintenance"
+	}
+
+	ticket, err := s.openMaintenanceTicket(ctx, params.SlotID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.Status == entities.MaintenanceTicketStatusInProgress && ticket.Assignee != params.Assignee {
+		return nil, errors.NewConflictError(fmt.Sprintf("maintenance of slot %s is already in progress by %s", params.SlotID, ticket.Assignee), nil)
+	}
+
+	now := time.Now()
+	if ticket.StartedAt == nil {
+		ticket.StartedAt = &now
+	}
+	ticket.Status = entities.MaintenanceTicketStatusInProgress
+	ticket.Assignee = params.Assignee
+	ticket.AddNote(params.Assignee, params.Notes, now)
+	ticket.UpdatedAt = now
+	if err := s.maintenanceTicketRepo.Update(ctx, ticket); err != nil {
+		return nil, errors.NewInternalError("failed to update maintenance ticket", err)
+	}
+
+	return ticket, nil
+}
+
+// CompleteSlotMaintenance closes the slot's maintenance ticket and returns the slot to service
+// with the status it had before, see MaintenanceTicket.ReturnStatus.
+func (s *InventoryService) CompleteSlotMaintenance(ctx context.Context, params CompleteSlotMaintenanceParams) (*entities.MaintenanceTicket, error) {
+	if params.SlotID == "" {
+		return nil, errors.NewValidationError("slot ID is required", nil)
+	}
+
+	slot, err := s.slotRepo.GetByID(ctx, params.SlotID)
+	if err != nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("slot %s not found", params.SlotID), err)
+	}
+
+	ticket, err := s.maintenanceTicketRepo.GetActiveBySlotID(ctx, params.SlotID)
+	if err != nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("no active maintenance ticket for slot %s", params.SlotID), err)
+	}
+
+	unlock, err := s.lockService.AcquireLock(ctx, fmt.Sprintf("shelf:%s", slot.ShelfID), 30*time.Second)
+	if err != nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("failed to lock shelf %s", slot.ShelfID), err)
+	}
+	defer unlock()
+
+	tx, err := s.slotRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to start transaction", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+	if slot.Status == entities.SlotStatusMaintenance {
+		slot.Status = ticket.ReturnStatus(slot.MaterialID)
+		slot.UpdatedAt = now
+		slot.Version++
+		if err = s.slotRepo.UpdateWithTx(ctx, tx, slot); err != nil {
+			return nil, errors.NewConflictError("failed to update slot", err)
+		}
+	}
+
+	ticket.Status = entities.MaintenanceTicketStatusCompleted
+	ticket.CompletedAt = &now
+	ticket.AddNote(params.OperatorID, params.Notes, now)
+	ticket.UpdatedAt = now
+	if err = s.maintenanceTicketRepo.UpdateWithTx(ctx, tx, ticket); err != nil {
+		return nil, errors.NewInternalError("failed to update maintenance ticket", err)
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	logger.Info(fmt.Sprintf("Slot %s returned to service as %s", slot.ID, slot.Status))
+	s.syncSlotWithLocation(ctx, slot)
+	s.publishSlotReturnedToServiceEvent(ctx, slot, ticket)
+	s.sendShelfCommand(ctx, SendShelfCommandParams{
+		ShelfID: slot.ShelfID,
+		SlotID:  slot.ID,
+		Type:    entities.ShelfCommandUnlockSlot,
+		Params:  map[string]interface{}{"maintenance_ticket_id": ticket.ID},
+	})
+
+	return ticket, nil
+}
+
+// suggestRelocationSlots returns the empty slots on the same shelf closest to the given slot
+func (s *InventoryService) suggestRelocationSlots(ctx context.Context, slot *entities.Slot) []string {
+	candidates, err := s.slotRepo.GetEmptySlotsByShelf(ctx, slot.ShelfID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to find relocation slots for slot %s", slot.ID), err)
+		return nil
+	}
+
+	distance := func(other *entities.Slot) int {
+		return abs(other.Row-slot.Row) + abs(other.Column-slot.Column)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return distance(candidates[i]) < distance(candidates[j])
+	})
+
+	suggestions := make([]string, 0, maxRelocationSuggestions)
+	for _, candidate := range candidates {
+		if candidate.ID == slot.ID || candidate.Status != entities.SlotStatusEmpty {
+			continue
+		}
+		suggestions = append(suggestions, candidate.ID)
+		if len(suggestions) == maxRelocationSuggestions {
+			break
+		}
+	}
+	return suggestions
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/pkg/errors"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// UpdateShelfStatus applies a status reported by a shelf. Reporting the current status again only
+// refreshes the heartbeat; a change is validated against the shelf state machine, persisted and published.
+func (s *InventoryService) UpdateShelfStatus(ctx context.Context, shelfID string, status string) error {
+	if shelfID == "" {
+		return errors.NewValidationError("shelf ID is required", nil)
+	}
+
+	next := entities.ShelfStateStatus(status)
+	if !next.IsValid() {
+		return errors.NewValidationError(fmt.Sprintf("unsupported shelf status: %s", status), nil)
+	}
+
+	return s.transitionShelfState(ctx, shelfID, next, time.Now())
+}
+
+// RecordShelfHeartbeat marks the shelf as alive. Any message from a shelf counts as a heartbeat, and a
+// shelf that was considered offline is brought back online by it.
+func (s *InventoryService) RecordShelfHeartbeat(ctx context.Context, shelfID string, at time.Time) error {
+	state, err := s.shelfStateRepo.GetByShelfID(ctx, shelfID)
+	if err != nil {
+		return errors.NewInternalError("failed to get shelf state", err)
+	}
+
+	if state == nil || state.Status == entities.ShelfStateOffline {
+		return s.transitionShelfState(ctx, shelfID, entities.ShelfStateOnline, at)
+	}
+
+	if err := s.shelfStateRepo.TouchHeartbeat(ctx, shelfID, at); err != nil {
+		return errors.NewInternalError("failed to record shelf heartbeat", err)
+	}
+	return nil
+}
+
+// MarkStaleShelvesOffline moves shelves that have not sent a heartbeat within the timeout to offline.
+// Shelves in maintenance are expected to be silent and are left alone.
+func (s *InventoryService) MarkStaleShelvesOffline(ctx context.Context, timeout time.Duration) error {
+	now := time.Now()
+	states, err := s.shelfStateRepo.ListStale(ctx, now.Add(-timeout))
+	if err != nil {
+		return errors.NewInternalError("failed to list stale shelves", err)
+	}
+
+	for _, state := range states {
+		logger.Info(fmt.Sprintf("No heartbeat from shelf %s since %s, marking it offline", state.ShelfID, state.LastHeartbeatAt.Format(time.RFC3339)))
+		if err := s.changeShelfState(ctx, state, entities.ShelfStateOffline, now); err != nil {
+			logger.Error(fmt.Sprintf("Failed to mark shelf %s offline", state.ShelfID), err)
+		}
+	}
+	return nil
+}
+
+// ensureShelfAcceptsPlacements rejects placements to shelves that are offline or in maintenance.
+// Shelves that never reported a status are not blocked.
+func (s *InventoryService) ensureShelfAcceptsPlacements(ctx context.Context, shelfID string) error {
+	state, err := s.shelfStateRepo.GetByShelfID(ctx, shelfID)
+	if err != nil {
+		return errors.NewInternalError("failed to get shelf state", err)
+	}
+	if state != nil && !state.Status.AcceptsPlacements() {
+		return errors.NewConflictError(fmt.Sprintf("shelf %s is %s", shelfID, state.Status), nil)
+	}
+	return nil
+}
+
+func (s *InventoryService) transitionShelfState(ctx context.Context, shelfID string, next entities.ShelfStateStatus, at time.Time) error {
+	state, err := s.shelfStateRepo.GetByShelfID(ctx, shelfID)
+	if err != nil {
+		return errors.NewInternalError("failed to get shelf state", err)
+	}
+
+	if state == nil {
+		state = &entities.ShelfState{ShelfID: shelfID}
+	} else if state.Status == next {
+		if err := s.shelfStateRepo.TouchHeartbeat(ctx, shelfID, at); err != nil {
+			return errors.NewInternalError("failed to record shelf heartbeat", err)
+		}
+		return nil
+	} else if !state.Status.CanTransitionTo(next) {
+		return errors.NewConflictError(fmt.Sprintf("shelf %s cannot change from %s to %s", shelfID, state.Status, next), nil)
+	}
+
+	if at.After(state.LastHeartbeatAt) {
+		state.LastHeartbeatAt = at
+	}
+	return s.changeShelfState(ctx, state, next, at)
+}
+
+func (s *InventoryService) changeShelfState(ctx context.Context, state *entities.ShelfState, next entities.ShelfStateStatus, at time.Time) error {
+	previous := state.Status
+	state.Status = next
+	state.StatusChangedAt = at
+	state.UpdatedAt = time.Now()
+	if err := s.shelfStateRepo.Save(ctx, state); err != nil {
+		return errors.NewInternalError("failed to save shelf state", err)
+	}
+
+	s.publishShelfStatusChangedEvent(ctx, state.ShelfID, string(previous), string(next))
+	return nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/pkg/errors"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// traceBatchSize is the number of operations read per query while collecting a material's history
+const traceBatchSize = 500
+
+// MaterialTrace answers where a material is and where it has been, for quality investigations and recalls
+type MaterialTrace struct {
+	Material *entities.Material  `json:"material"`
+	Current  *MaterialLocation   `json:"current_location"` // nil when the material is not on a shelf
+	History  []*MaterialMovement `json:"history"`          // oldest first
+}
+
+type MaterialLocation struct {
+	ShelfID    string              `json:"shelf_id"`
+	SlotID     string              `json:"slot_id"`
+	Row        int                 `json:"row"`
+	Column     int                 `json:"column"`
+	SlotStatus entities.SlotStatus `json:"slot_status"`
+	ZoneID     string              `json:"zone_id,omitempty"` // empty when location-service could not be asked
+	Since      *time.Time          `json:"since,omitempty"`   // when the material was put into the slot, if recorded
+}
+
+// MaterialMovement is one operation on the material. Placements only have a target slot and removals
+// only a source slot, moves have both.
+type MaterialMovement struct {
+	OperationID string                   `json:"operation_id"`
+	Type        entities.OperationType   `json:"type"`
+	Status      entities.OperationStatus `json:"status"`
+	FromSlotID  string                   `json:"from_slot_id,omitempty"`
+	ToSlotID    string                   `json:"to_slot_id,omitempty"`
+	ShelfID     string                   `json:"shelf_id"`
+	OperatorID  string                   `json:"operator_id"`
+	RequestedAt *time.Time               `json:"requested_at,omitempty"` // only known for operations with a recorded timeline
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+// TraceMaterial returns the current slot of a material and every operation that moved it.
+// The zone comes from location-service; when it is unavailable the trace is returned without it.
+func (s *InventoryService) TraceMaterial(ctx context.Context, barcode string) (*MaterialTrace, error) {
+	if barcode == "" {
+		return nil, errors.NewValidationError("barcode is required", nil)
+	}
+
+	material, err := s.materialRepo.GetByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("material %s not found", barcode), err)
+	}
+
+	history, err := s.materialHistory(ctx, material.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := &MaterialTrace{Material: material, History: history}
+
+	slot, err := s.slotRepo.GetByMaterialID(ctx, material.ID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to look up the slot of the material", err)
+	}
+	if slot != nil {
+		trace.Current = &MaterialLocation{
+			ShelfID:    slot.ShelfID,
+			SlotID:     slot.ID,
+			Row:        slot.Row,
+			Column:     slot.Column,
+			SlotStatus: slot.Status,
+		}
+		for i := len(history) - 1; i >= 0; i-- {
+			if history[i].ToSlotID == slot.ID {
+				since := history[i].UpdatedAt
+				if history[i].RequestedAt != nil {
+					since = *history[i].RequestedAt
+				}
+				trace.Current.Since = &since
+				break
+			}
+		}
+		if s.locationClient != nil {
+			zoneID, err := s.locationClient.GetShelfZone(ctx, slot.ShelfID)
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to get zone of shelf %s from location-service", slot.ShelfID), err)
+			}
+			trace.Current.ZoneID = zoneID
+		}
+	}
+
+	return trace, nil
+}
+
+func (s *InventoryService) materialHistory(ctx context.Context, materialID string) ([]*MaterialMovement, error) {
+	transitions, err := s.operationTransitionRepo.GetByMaterialID(ctx, materialID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to get operation transitions of the material", err)
+	}
+	// the first transition of an operation is its request, moves also record their source slot there
+	requests := make(map[string]*entities.OperationTransition)
+	for _, transition := range transitions {
+		if _, ok := requests[transition.OperationID]; !ok {
+			requests[transition.OperationID] = transition
+		}
+	}
+
+	options := repositories.OperationListOptions{
+		Filter: repositories.OperationFilter{MaterialID: materialID},
+		SortBy: repositories.OperationSortByTimestamp,
+		Limit:  traceBatchSize,
+	}
+	history := make([]*MaterialMovement, 0)
+	for {
+		operations, err := s.operationRepo.Find(ctx, options)
+		if err != nil {
+			return nil, errors.NewInternalError("failed to get operations of the material", err)
+		}
+		for _, operation := range operations {
+			history = append(history, newMaterialMovement(operation, requests[operation.ID]))
+		}
+		if len(operations) < traceBatchSize {
+			return history, nil
+		}
+		last := operations[len(operations)-1]
+		options.After = &repositories.OperationKey{SortValue: options.SortBy.ValueOf(last), ID: last.ID}
+	}
+}
+
+func newMaterialMovement(operation *entities.Operation, request *entities.OperationTransition) *MaterialMovement {
+	movement := &MaterialMovement{
+		OperationID: operation.ID,
+		Type:        operation.Type,
+		Status:      operation.Status,
+		ShelfID:     operation.ShelfID,
+		OperatorID:  operation.OperatorID,
+		UpdatedAt:   operation.Timestamp,
+	}
+	if request != nil {
+		requestedAt := request.OccurredAt
+		movement.RequestedAt = &requestedAt
+	}
+
+	switch operation.Type {
+	case entities.OperationTypeRemoval:
+		movement.FromSlotID = operation.SlotID
+	case entities.OperationTypeMove:
+		if request != nil {
+			movement.FromSlotID = request.FromSlotID
+		}
+		movement.ToSlotID = operation.SlotID
+	default:
+		movement.ToSlotID = operation.SlotID
+	}
+	return movement
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+)
+
+// ErrNoSuitableSlot is returned by SuggestPlacement when the storage policies of the zones leave no slot for the material
+var ErrNoSuitableSlot = stderrors.New("location-service has no suitable slot for the material")
+
+// LocationClient is the part of location-service the inventory service relies on. location-service owns the
+// warehouse layout, so zones are only known there.
+type LocationClient interface {
+	// GetShelfZone returns the ID of the zone a shelf stands in
+	GetShelfZone(ctx context.Context, shelfID string) (string, error)
+	// SuggestPlacement proposes slots for a material type that the storage policies of the zones allow, best first.
+	// It returns ErrNoSuitableSlot when location-service rejects the placement.
+	SuggestPlacement(ctx context.Context, req PlacementRequest) ([]*PlacementSuggestion, error)
+	// UpdateSlotStatus mirrors the status and material of a slot to location-service
+	UpdateSlotStatus(ctx context.Context, slot *entities.Slot) error
+	// GetShelfSlots returns location-service's copy of the slots of a shelf, nil when it does not know the shelf
+	GetShelfSlots(ctx context.Context, shelfID string) ([]*LocationSlot, error)
+}
+
+// PlacementRequest describes a material to place for location-service, which applies the storage policies
+type PlacementRequest struct {
+	MaterialType string
+	ZoneID       string // optional
+	ShelfID      string // optional
+	StorageClass string // optional, zones of other classes are not considered, e.g. QUARANTINE
+	Role         string // role of the operator, zones with access roles only take materials from those roles
+	Limit        int    // optional, number of slots to suggest, 1 when unset
+}
+
+// PlacementSuggestion is a slot location-service picked based on the warehouse layout
+type PlacementSuggestion struct {
+	ShelfID string
+	SlotID  string
+}
+
+// LocationSlot is location-service's copy of a slot, Status is in location-service's vocabulary
+type LocationSlot struct {
+	ID            string
+	Status        string
+	MaterialID    string
+	ReservedUntil time.Time // set while location-service holds the slot for an AllocateSlot caller
+}
+
+// IsAllocated reports whether location-service still holds the slot for an AllocateSlot caller
+func (s *LocationSlot) IsAllocated(now time.Time) bool {
+	return s.Status == locationSlotStatuses[entities.SlotStatusReserved] && s.ReservedUntil.After(now)
+}
+
+// location-service only knows whether a slot is usable, maintenance disables the slot and a pending
+// removal still holds the material
+var locationSlotStatuses = map[entities.SlotStatus]string{
+	entities.SlotStatusEmpty:          "EMPTY",
+	entities.SlotStatusOccupied:       "OCCUPIED",
+	entities.SlotStatusReserved:       "RESERVED",
+	entities.SlotStatusMaintenance:    "DISABLED",
+	entities.SlotStatusRemovalPending: "OCCUPIED",
+}
+
+// LocationSlotStatus returns the status location-service should have for a slot in the given status
+func LocationSlotStatus(status entities.SlotStatus) (string, bool) {
+	locationStatus, ok := locationSlotStatuses[status]
+	return locationStatus, ok
+}
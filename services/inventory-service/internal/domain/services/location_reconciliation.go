@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/pkg/errors"
+)
+
+type LocationDriftKind string
+
+const (
+	LocationDriftStatus             LocationDriftKind = "status"               // status or material differ
+	LocationDriftMissingInLocation  LocationDriftKind = "missing_in_location"  // location-service does not know the slot
+	LocationDriftUnknownToInventory LocationDriftKind = "unknown_to_inventory" // location-service has a slot inventory does not
+)
+
+// LocationSlotDrift is a slot whose copy in location-service disagrees with inventory, the source of truth
+type LocationSlotDrift struct {
+	SlotID              string              `json:"slot_id"`
+	Kind                LocationDriftKind   `json:"kind"`
+	InventoryStatus     entities.SlotStatus `json:"inventory_status,omitempty"`
+	InventoryMaterialID string              `json:"inventory_material_id,omitempty"`
+	ExpectedStatus      string              `json:"expected_status,omitempty"` // inventory status in location-service's vocabulary
+	LocationStatus      string              `json:"location_status,omitempty"`
+	LocationMaterialID  string              `json:"location_material_id,omitempty"`
+	Repaired            bool                `json:"repaired"`
+	RepairError         string              `json:"repair_error,omitempty"`
+}
+
+type ShelfReconciliation struct {
+	ShelfID      string              `json:"shelf_id"`
+	CheckedSlots int                 `json:"checked_slots"`
+	Drift        []LocationSlotDrift `json:"drift"`
+	// Unplanned are slots that differ because location-service was told about an unplanned change
+	// that is still being investigated. They are not drift and are never repaired.
+	Unplanned []LocationSlotDrift `json:"unplanned"`
+	Error     string              `json:"error,omitempty"` // the shelf could not be compared
+}
+
+// LocationReconciliationReport is the outcome of comparing slot state between inventory and location-service
+type LocationReconciliationReport struct {
+	Repair        bool                  `json:"repair"`
+	StartedAt     time.Time             `json:"started_at"`
+	FinishedAt    time.Time             `json:"finished_at"`
+	Shelves       []ShelfReconciliation `json:"shelves"`
+	DriftCount    int                   `json:"drift_count"`
+	RepairedCount int                   `json:"repaired_count"`
+	FailedShelves int                   `json:"failed_shelves"`
+}
+
+// ReconcileLocationSlots compares the slots of the given shelves, or of every shelf when none are given,
+// with location-service. With repair set, slots that differ are overwritten in location-service; slots it
+// does not know at all need the shelf layout fixed there and are only reported. An empty slot location-service
+// holds for an AllocateSlot caller is not drift until the allocation runs out, nor is a slot with an unplanned
+// change whose alert has not been resolved yet.
+func (s *InventoryService) ReconcileLocationSlots(ctx context.Context, shelfIDs []string, repair bool) (*LocationReconciliationReport, error) {
+	if s.locationClient == nil {
+		return nil, errors.NewInternalError("location-service client is not configured", nil)
+	}
+
+	if len(shelfIDs) == 0 {
+		var err error
+		shelfIDs, err = s.slotRepo.ListShelfIDs(ctx)
+		if err != nil {
+			return nil, errors.NewInternalError("failed to list shelves", err)
+		}
+	}
+
+	report := &LocationReconciliationReport{Repair: repair, StartedAt: time.Now(), Shelves: make([]ShelfReconciliation, 0, len(shelfIDs))}
+	for _, shelfID := range shelfIDs {
+		shelf := s.reconcileShelf(ctx, shelfID, repair)
+		if shelf.Error != "" {
+			report.FailedShelves++
+		}
+		report.DriftCount += len(shelf.Drift)
+		for _, drift := range shelf.Drift {
+			if drift.Repaired {
+				report.RepairedCount++
+			}
+		}
+		report.Shelves = append(report.Shelves, shelf)
+	}
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func (s *InventoryService) reconcileShelf(ctx context.Context, shelfID string, repair bool) ShelfReconciliation {
+	result := ShelfReconciliation{ShelfID: shelfID, Drift: []LocationSlotDrift{}, Unplanned: []LocationSlotDrift{}}
+
+	slots, err := s.slotRepo.GetByShelfID(ctx, shelfID)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to get slots: %v", err)
+		return result
+	}
+	locationSlots, err := s.locationClient.GetShelfSlots(ctx, shelfID)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if locationSlots == nil {
+		result.Error = "shelf is not known to location-service"
+		return result
+	}
+	alerts, err := s.alertRepo.GetActiveByShelfID(ctx, shelfID, entities.AlertTypeUnplannedChange)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to get unplanned change alerts: %v", err)
+		return result
+	}
+	unplanned := make(map[string]bool, len(alerts))
+	for _, alert := range alerts {
+		unplanned[alert.SlotID] = true
+	}
+
+	now := time.Now()
+	remote := make(map[string]*LocationSlot, len(locationSlots))
+	for _, slot := range locationSlots {
+		remote[slot.ID] = slot
+	}
+
+	for _, slot := range slots {
+		result.CheckedSlots++
+		expected, ok := LocationSlotStatus(slot.Status)
+		if !ok {
+			continue
+		}
+		materialID := ""
+		if slot.MaterialID != nil {
+			materialID = *slot.MaterialID
+		}
+
+		drift := LocationSlotDrift{
+			SlotID:              slot.ID,
+			InventoryStatus:     slot.Status,
+			InventoryMaterialID: materialID,
+			ExpectedStatus:      expected,
+		}
+
+		locationSlot, ok := remote[slot.ID]
+		delete(remote, slot.ID)
+		if !ok {
+			drift.Kind = LocationDriftMissingInLocation
+			result.Drift = append(result.Drift, drift)
+			continue
+		}
+		if locationSlot.Status == expected && locationSlot.MaterialID == materialID {
+			continue
+		}
+		// a placement is underway, overwriting the allocation would hand the slot to the next caller
+		if slot.Status == entities.SlotStatusEmpty && locationSlot.IsAllocated(now) {
+			continue
+		}
+
+		drift.Kind = LocationDriftStatus
+		drift.LocationStatus = locationSlot.Status
+		drift.LocationMaterialID = locationSlot.MaterialID
+		// location-service holds what the sensors found, see syncUnplannedChangeWithLocation
+		if unplanned[slot.ID] {
+			result.Unplanned = append(result.Unplanned, drift)
+			continue
+		}
+		if repair {
+			if err := s.locationClient.UpdateSlotStatus(ctx, slot); err != nil {
+				drift.RepairError = err.Error()
+			} else {
+				drift.Repaired = true
+			}
+		}
+		result.Drift = append(result.Drift, drift)
+	}
+
+	// whatever is left exists only in location-service
+	for _, locationSlot := range locationSlots {
+		if _, ok := remote[locationSlot.ID]; !ok {
+			continue
+		}
+		result.Drift = append(result.Drift, LocationSlotDrift{
+			SlotID:             locationSlot.ID,
+			Kind:               LocationDriftUnknownToInventory,
+			LocationStatus:     locationSlot.Status,
+			LocationMaterialID: locationSlot.MaterialID,
+		})
+	}
+	return result
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+)
+
+// BusMessage is a serialized event travelling over the message bus
+type BusMessage struct {
+	Topic   string
+	Key     string            // partitioning key, the aggregate (shelf) ID for events published by EventService
+	Headers map[string]string // e.g. the event type, see shared/events
+	Payload []byte
+}
+
+// BusHandler processes a delivered message. Returning an error asks the bus to redeliver it, where the backend supports it.
+type BusHandler func(ctx context.Context, msg BusMessage) error
+
+// MessageBus is the publish/subscribe backend behind EventService, e.g. Kafka, NATS JetStream or in-memory
+type MessageBus interface {
+	Publish(ctx context.Context, msg BusMessage) error
+	// Subscribe delivers the messages of a topic to the handler until the context is cancelled.
+	// Each message is delivered to one subscriber of every group.
+	Subscribe(ctx context.Context, topic, group string, handler BusHandler) error
+	Close() error
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/pkg/errors"
+)
+
+// SlotOccupancy is the state of a slot as far as the operation transition log can tell
+type SlotOccupancy struct {
+	SlotID           string              `json:"slot_id"`
+	Status           entities.SlotStatus `json:"status"`
+	MaterialID       string              `json:"material_id,omitempty"`
+	LastTransitionID int64               `json:"last_transition_id"`
+}
+
+// OccupancyProjection replays operation transitions into slot occupancy. It only knows the slots
+// that appear in the log, slots that never had an operation are empty.
+type OccupancyProjection struct {
+	slots map[string]*SlotOccupancy
+}
+
+func NewOccupancyProjection() *OccupancyProjection {
+	return &OccupancyProjection{slots: make(map[string]*SlotOccupancy)}
+}
+
+// Apply applies one transition. Transitions must be applied in log order.
+func (p *OccupancyProjection) Apply(transition *entities.OperationTransition) {
+	switch transition.OperationType {
+	case entities.OperationTypePlacement:
+		switch transition.ToStatus {
+		case entities.OperationStatusPendingPhysicalConfirmation:
+			p.set(transition.SlotID, entities.SlotStatusOccupied, transition.MaterialID, transition.ID)
+		case entities.OperationStatusFailed:
+			p.set(transition.SlotID, entities.SlotStatusEmpty, "", transition.ID)
+		}
+	case entities.OperationTypeRemoval:
+		switch transition.ToStatus {
+		case entities.OperationStatusPendingRemovalConfirmation:
+			p.set(transition.SlotID, entities.SlotStatusRemovalPending, transition.MaterialID, transition.ID)
+		case entities.OperationStatusCompleted:
+			p.set(transition.SlotID, entities.SlotStatusEmpty, "", transition.ID)
+		case entities.OperationStatusFailed:
+			p.set(transition.SlotID, entities.SlotStatusOccupied, transition.MaterialID, transition.ID)
+		}
+	case entities.OperationTypeMove:
+		if transition.ToStatus == entities.OperationStatusCompleted {
+			p.set(transition.FromSlotID, entities.SlotStatusEmpty, "", transition.ID)
+			p.set(transition.SlotID, entities.SlotStatusOccupied, transition.MaterialID, transition.ID)
+		}
+	}
+}
+
+func (p *OccupancyProjection) set(slotID string, status entities.SlotStatus, materialID string, transitionID int64) {
+	if slotID == "" {
+		return
+	}
+	p.slots[slotID] = &SlotOccupancy{
+		SlotID:           slotID,
+		Status:           status,
+		MaterialID:       materialID,
+		LastTransitionID: transitionID,
+	}
+}
+
+// Slot returns the rebuilt occupancy of a slot, slots missing from the log are empty
+func (p *OccupancyProjection) Slot(slotID string) *SlotOccupancy {
+	if occupancy, ok := p.slots[slotID]; ok {
+		return occupancy
+	}
+	return &SlotOccupancy{SlotID: slotID, Status: entities.SlotStatusEmpty}
+}
+
+// Slots returns every slot that appears in the log, ordered by slot ID
+func (p *OccupancyProjection) Slots() []*SlotOccupancy {
+	slots := make([]*SlotOccupancy, 0, len(p.slots))
+	for _, occupancy := range p.slots {
+		slots = append(slots, occupancy)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i].SlotID < slots[j].SlotID })
+	return slots
+}
+
+// RebuildSlotOccupancy replays the whole transition log, reading it in batches of batchSize
+func RebuildSlotOccupancy(ctx context.Context, transitionRepo repositories.OperationTransitionRepository, batchSize int) (*OccupancyProjection, error) {
+	if batchSize <= 0 {
+		return nil, errors.NewValidationError("batch size must be positive", nil)
+	}
+
+	projection := NewOccupancyProjection()
+	var afterID int64
+	for {
+		transitions, err := transitionRepo.ListAfter(ctx, afterID, batchSize)
+		if err != nil {
+			return nil, errors.NewInternalError(fmt.Sprintf("failed to read transitions after %d", afterID), err)
+		}
+		for _, transition := range transitions {
+			projection.Apply(transition)
+			afterID = transition.ID
+		}
+		if len(transitions) < batchSize {
+			return projection, nil
+		}
+	}
+}
+
+// OccupancyDrift is a slot whose stored state disagrees with the state rebuilt from the log
+type OccupancyDrift struct {
+	SlotID            string              `json:"slot_id"`
+	ShelfID           string              `json:"shelf_id"`
+	StoredStatus      entities.SlotStatus `json:"stored_status"`
+	StoredMaterialID  string              `json:"stored_material_id,omitempty"`
+	RebuiltStatus     entities.SlotStatus `json:"rebuilt_status"`
+	RebuiltMaterialID string              `json:"rebuilt_material_id,omitempty"`
+}
+
+// CompareSlotOccupancy returns the stored slots that disagree with the projection. The material is always
+// compared, the status only when the slot is in a status operations produce; reserved and maintenance
+// slots are set outside of operations and keep whatever material they hold.
+func CompareSlotOccupancy(projection *OccupancyProjection, slots []*entities.Slot) []OccupancyDrift {
+	var drift []OccupancyDrift
+	for _, slot := range slots {
+		rebuilt := projection.Slot(slot.ID)
+
+		storedMaterialID := ""
+		if slot.MaterialID != nil {
+			storedMaterialID = *slot.MaterialID
+		}
+
+		matches := storedMaterialID == rebuilt.MaterialID
+		switch slot.Status {
+		case entities.SlotStatusEmpty, entities.SlotStatusOccupied, entities.SlotStatusRemovalPending:
+			matches = matches && slot.Status == rebuilt.Status
+		}
+		if matches {
+			continue
+		}
+
+		drift = append(drift, OccupancyDrift{
+			SlotID:            slot.ID,
+			ShelfID:           slot.ShelfID,
+			StoredStatus:      slot.Status,
+			StoredMaterialID:  storedMaterialID,
+			RebuiltStatus:     rebuilt.Status,
+			RebuiltMaterialID: rebuilt.MaterialID,
+		})
+	}
+	return drift
+}
@@ -59,4 +59,8 @@ func (s *RetryService) ExecuteWithRetry(ctx context.Context, operation interface
     }
     
     return fmt.Errorf("operation failed after %d attempts: %w", s.maxRetries, lastErr)
-}
\ No newline at end of file
+}
+// Execute runs a closure with the same retry policy as ExecuteWithRetry
+func (s *RetryService) Execute(operation func() error) error {
+    return s.ExecuteWithRetry(context.Background(), operation)
+}
@@ -0,0 +1,171 @@
+/*
+ * ShelfCommandService sends commands down to the smart shelves (pick-to-light, error blink,
+ * slot locks, re-scans) and tracks their acknowledgements using the command ID as correlation ID.
+ */
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/pkg/errors"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// ShelfCommandPublisher delivers a command to the shelf hardware
+type ShelfCommandPublisher interface {
+	PublishCommand(ctx context.Context, command *entities.ShelfCommand) error
+}
+
+type SendShelfCommandParams struct {
+	ShelfID     string
+	SlotID      string
+	Type        entities.ShelfCommandType
+	Params      map[string]interface{}
+	OperationID string
+}
+
+// ShelfCommandAck is the acknowledgement a shelf returns for a command
+type ShelfCommandAck struct {
+	CommandID string
+	ShelfID   string
+	Success   bool
+	Error     string
+	Timestamp time.Time
+}
+
+type ShelfCommandService struct {
+	shelfCommandRepo repositories.ShelfCommandRepository
+	publisher        ShelfCommandPublisher
+}
+
+func NewShelfCommandService(shelfCommandRepo repositories.ShelfCommandRepository, publisher ShelfCommandPublisher) *ShelfCommandService {
+	return &ShelfCommandService{
+		shelfCommandRepo: shelfCommandRepo,
+		publisher:        publisher,
+	}
+}
+
+// SendCommand records the command and publishes it to the shelf. A command that could not be published
+// is kept with status failed and returned together with the error.
+func (s *ShelfCommandService) SendCommand(ctx context.Context, params SendShelfCommandParams) (*entities.ShelfCommand, error) {
+	if params.ShelfID == "" {
+		return nil, errors.NewValidationError("shelf ID is required", nil)
+	}
+	if !params.Type.IsValid() {
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported shelf command: %s", params.Type), nil)
+	}
+	if params.Type.RequiresSlot() && params.SlotID == "" {
+		return nil, errors.NewValidationError(fmt.Sprintf("slot ID is required for %s", params.Type), nil)
+	}
+
+	now := time.Now()
+	command := &entities.ShelfCommand{
+		ID:          generateUUID(),
+		ShelfID:     params.ShelfID,
+		SlotID:      params.SlotID,
+		Type:        params.Type,
+		Params:      params.Params,
+		OperationID: params.OperationID,
+		Status:      entities.ShelfCommandStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.shelfCommandRepo.Create(ctx, command); err != nil {
+		return nil, errors.NewInternalError("failed to record shelf command", err)
+	}
+
+	if err := s.publisher.PublishCommand(ctx, command); err != nil {
+		command.Status = entities.ShelfCommandStatusFailed
+		command.Error = err.Error()
+		command.UpdatedAt = time.Now()
+		if updateErr := s.shelfCommandRepo.Update(ctx, command); updateErr != nil {
+			logger.Error(fmt.Sprintf("Failed to update shelf command %s", command.ID), updateErr)
+		}
+		return command, errors.NewInternalError(fmt.Sprintf("failed to publish %s command to shelf %s", command.Type, command.ShelfID), err)
+	}
+
+	// the shelf may acknowledge before the command is marked sent, its acknowledgement must not be overwritten
+	sentAt := time.Now()
+	marked, err := s.shelfCommandRepo.MarkSent(ctx, command.ID, sentAt)
+	if err != nil {
+		return command, errors.NewInternalError("failed to update shelf command", err)
+	}
+	if !marked {
+		if current, err := s.shelfCommandRepo.GetByID(ctx, command.ID); err == nil {
+			return current, nil
+		}
+		return command, nil
+	}
+	command.Status = entities.ShelfCommandStatusSent
+	command.SentAt = &sentAt
+	command.UpdatedAt = sentAt
+
+	return command, nil
+}
+
+// HandleAcknowledgement records the shelf's answer to a command. Late acknowledgements of commands that
+// already timed out are still recorded, since the shelf did execute them.
+func (s *ShelfCommandService) HandleAcknowledgement(ctx context.Context, ack ShelfCommandAck) error {
+	command, err := s.shelfCommandRepo.GetByID(ctx, ack.CommandID)
+	if err != nil {
+		return errors.NewNotFoundError(fmt.Sprintf("shelf command %s not found", ack.CommandID), err)
+	}
+
+	if ack.ShelfID != command.ShelfID {
+		return errors.NewValidationError(fmt.Sprintf("shelf command %s was sent to shelf %s, not %s", command.ID, command.ShelfID, ack.ShelfID), nil)
+	}
+
+	if command.Status == entities.ShelfCommandStatusAcknowledged || command.Status == entities.ShelfCommandStatusFailed {
+		return nil // duplicate acknowledgement
+	}
+
+	at := ack.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if ack.Success {
+		command.Status = entities.ShelfCommandStatusAcknowledged
+		command.AcknowledgedAt = &at
+	} else {
+		command.Status = entities.ShelfCommandStatusFailed
+		command.Error = ack.Error
+		logger.Info(fmt.Sprintf("Shelf %s rejected %s command %s: %s", command.ShelfID, command.Type, command.ID, ack.Error))
+	}
+	command.UpdatedAt = time.Now()
+
+	if err := s.shelfCommandRepo.Update(ctx, command); err != nil {
+		return errors.NewInternalError("failed to update shelf command", err)
+	}
+	return nil
+}
+
+// MarkUnacknowledgedTimedOut flags commands the shelf did not acknowledge within the timeout
+func (s *ShelfCommandService) MarkUnacknowledgedTimedOut(ctx context.Context, timeout time.Duration) error {
+	commands, err := s.shelfCommandRepo.ListAwaitingAck(ctx, time.Now().Add(-timeout))
+	if err != nil {
+		return errors.NewInternalError("failed to list unacknowledged shelf commands", err)
+	}
+
+	for _, command := range commands {
+		logger.Info(fmt.Sprintf("Shelf %s did not acknowledge %s command %s", command.ShelfID, command.Type, command.ID))
+		command.Status = entities.ShelfCommandStatusTimedOut
+		command.UpdatedAt = time.Now()
+		if err := s.shelfCommandRepo.Update(ctx, command); err != nil {
+			logger.Error(fmt.Sprintf("Failed to update shelf command %s", command.ID), err)
+		}
+	}
+	return nil
+}
+
+func (s *ShelfCommandService) GetCommand(ctx context.Context, commandID string) (*entities.ShelfCommand, error) {
+	command, err := s.shelfCommandRepo.GetByID(ctx, commandID)
+	if err != nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("shelf command %s not found", commandID), err)
+	}
+	return command, nil
+}
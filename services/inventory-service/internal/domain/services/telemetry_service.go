@@ -0,0 +1,83 @@
+/*
+ * TelemetryService persists sensor readings reported by the smart shelves and
+ * maintains the downsampled rollups used for long range history queries, as well
+ * as the monthly partitions the readings are stored in.
+ */
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// sensorPartitionMonthsAhead is the number of monthly sensor_readings partitions kept ready after the
+// current one, so readings never land in the default partition while the service is running
+const sensorPartitionMonthsAhead = 2
+
+type TelemetryService struct {
+	sensorReadingRepo repositories.SensorReadingRepository
+}
+
+func NewTelemetryService(sensorReadingRepo repositories.SensorReadingRepository) *TelemetryService {
+	return &TelemetryService{sensorReadingRepo: sensorReadingRepo}
+}
+
+func (s *TelemetryService) RecordSensorReading(ctx context.Context, reading *entities.SensorReading) error {
+	if reading.ID == "" {
+		reading.ID = generateUUID()
+	}
+	if reading.RecordedAt.IsZero() {
+		reading.RecordedAt = time.Now()
+	}
+	reading.CreatedAt = time.Now()
+
+	return s.sensorReadingRepo.Create(ctx, reading)
+}
+
+// LatestSlotWeight returns the last weight reported for the slot before the given time.
+// The second return value is false when the slot has no weight reading yet.
+func (s *TelemetryService) LatestSlotWeight(ctx context.Context, slotID string, before time.Time) (float64, bool, error) {
+	reading, err := s.sensorReadingRepo.GetLatestWeightBySlotID(ctx, slotID, before)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if reading == nil || reading.Weight == nil {
+		return 0, false, nil
+	}
+	return *reading.Weight, true, nil
+}
+
+// RollupSensorReadings (re)aggregates the current and previous hour and day buckets.
+// Rollups are upserts, so running this more often than the bucket size is safe.
+func (s *TelemetryService) RollupSensorReadings(ctx context.Context, now time.Time) error {
+	hourStart := now.Truncate(time.Hour).Add(-time.Hour)
+	if err := s.sensorReadingRepo.Rollup(ctx, entities.SensorResolutionHour, hourStart, now); err != nil {
+		return err
+	}
+
+	year, month, day := now.Date()
+	dayStart := time.Date(year, month, day, 0, 0, 0, 0, now.Location()).AddDate(0, 0, -1)
+	return s.sensorReadingRepo.Rollup(ctx, entities.SensorResolutionDay, dayStart, now)
+}
+
+// EnsureSensorReadingPartitions creates the sensor_readings partitions for the current month and the
+// months ahead. Existing partitions are left alone, so this can run as often as needed.
+func (s *TelemetryService) EnsureSensorReadingPartitions(ctx context.Context, now time.Time) error {
+	year, month, _ := now.Date()
+	for i := 0; i <= sensorPartitionMonthsAhead; i++ {
+		start := time.Date(year, month+time.Month(i), 1, 0, 0, 0, 0, now.Location())
+		if err := s.sensorReadingRepo.CreateMonthlyPartition(ctx, start); err != nil {
+			return fmt.Errorf("failed to create sensor readings partition for %s: %w", start.Format("2006-01"), err)
+		}
+	}
+	return nil
+}
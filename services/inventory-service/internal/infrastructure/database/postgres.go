@@ -31,5 +31,6 @@ func RunMigrations(db *gorm.DB) error {
 		&entities.Material{},
 		&entities.Slot{},
 		&entities.Operation{},
+		&entities.OperationTransition{},
 	)
 }
\ No newline at end of file
@@ -52,6 +52,15 @@ func (r *alertRepository) GetByShelfID(ctx context.Context, shelfID string, limi
     return alerts, err
 }
 
+func (r *alertRepository) GetActiveByShelfID(ctx context.Context, shelfID string, alertType entities.AlertType) ([]*entities.Alert, error) {
+    var alerts []*entities.Alert
+    err := r.db.WithContext(ctx).
+        Where("shelf_id = ? AND type = ? AND status IN ?", shelfID, alertType, []string{"active", "acknowledged"}).
+        Order("created_at DESC").
+        Find(&alerts).Error
+    return alerts, err
+}
+
 func (r *alertRepository) UpdateStatus(ctx context.Context, id string, status string) error {
     return r.db.WithContext(ctx).
         Model(&entities.Alert{}).
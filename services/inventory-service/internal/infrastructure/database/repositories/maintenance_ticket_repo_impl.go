@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type maintenanceTicketRepository struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceTicketRepository(db *gorm.DB) repositories.MaintenanceTicketRepository {
+	return &maintenanceTicketRepository{db: db}
+}
+
+func (r *maintenanceTicketRepository) Create(ctx context.Context, ticket *entities.MaintenanceTicket) error {
+	return r.db.WithContext(ctx).Create(ticket).Error
+}
+
+func (r *maintenanceTicketRepository) CreateWithTx(ctx context.Context, tx *gorm.DB, ticket *entities.MaintenanceTicket) error {
+	return tx.WithContext(ctx).Create(ticket).Error
+}
+
+func (r *maintenanceTicketRepository) GetByID(ctx context.Context, id string) (*entities.MaintenanceTicket, error) {
+	var ticket entities.MaintenanceTicket
+	err := r.db.WithContext(ctx).First(&ticket, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *maintenanceTicketRepository) GetActiveBySlotID(ctx context.Context, slotID string) (*entities.MaintenanceTicket, error) {
+	var ticket entities.MaintenanceTicket
+	err := r.db.WithContext(ctx).
+		Where("slot_id = ? AND status <> ?", slotID, entities.MaintenanceTicketStatusCompleted).
+		Order("created_at DESC").
+		First(&ticket).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *maintenanceTicketRepository) Update(ctx context.Context, ticket *entities.MaintenanceTicket) error {
+	return r.db.WithContext(ctx).Save(ticket).Error
+}
+
+func (r *maintenanceTicketRepository) UpdateWithTx(ctx context.Context, tx *gorm.DB, ticket *entities.MaintenanceTicket) error {
+	return tx.WithContext(ctx).Save(ticket).Error
+}
+
+func (r *maintenanceTicketRepository) List(ctx context.Context, filter repositories.MaintenanceTicketFilter) ([]*entities.MaintenanceTicket, error) {
+	var tickets []*entities.MaintenanceTicket
+	query := r.db.WithContext(ctx)
+	if filter.SlotID != "" {
+		query = query.Where("slot_id = ?", filter.SlotID)
+	}
+	if filter.ShelfID != "" {
+		query = query.Where("shelf_id = ?", filter.ShelfID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&tickets).Error
+	return tickets, err
+}
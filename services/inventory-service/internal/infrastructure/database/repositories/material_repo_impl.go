@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"strings"
 	"WMS/services/inventory-service/internal/domain/entities"
 	"WMS/services/inventory-service/internal/domain/repositories"
 	
@@ -56,14 +57,104 @@ func (r *materialRepository) List(ctx context.Context, limit, offset int) ([]*en
 	return materials, err
 }
 
-func (r *materialRepository) Search(ctx context.Context, query string, limit, offset int) ([]*entities.Material, error) {
+// materialSearchDocument must stay the expression of idx_materials_search, otherwise Postgres cannot
+// use the index for the full-text match
+const materialSearchDocument = "to_tsvector('simple', coalesce(materials.barcode, '') || ' ' || coalesce(materials.name, '') || ' ' || coalesce(materials.type, ''))"
+
+// materialSearchRank weighs barcode over name over type, and puts exact and prefix barcode hits first
+const materialSearchRank = `ts_rank(
+	setweight(to_tsvector('simple', coalesce(materials.barcode, '')), 'A') ||
+	setweight(to_tsvector('simple', coalesce(materials.name, '')), 'B') ||
+	setweight(to_tsvector('simple', coalesce(materials.type, '')), 'C'),
+	plainto_tsquery('simple', ?))
+	+ CASE WHEN lower(materials.barcode) = lower(?) THEN 10 WHEN materials.barcode ILIKE ? THEN 5 ELSE 0 END
+	+ CASE WHEN materials.name ILIKE ? THEN 1 ELSE 0 END`
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *materialRepository) Search(ctx context.Context, filter repositories.MaterialSearchFilter, limit, offset int) ([]*entities.Material, error) {
+	query := r.applySearchFilter(r.db.WithContext(ctx).Model(&entities.Material{}), filter)
+
+	text := strings.TrimSpace(filter.Query)
+	if text != "" {
+		prefix := likeEscaper.Replace(text) + "%"
+		query = query.
+			Select("materials.*, ("+materialSearchRank+") AS search_rank", text, text, prefix, prefix).
+			Order("search_rank DESC")
+	}
+
 	var materials []*entities.Material
-	err := r.db.WithContext(ctx).
-		Where("name ILIKE ? OR barcode ILIKE ? OR type ILIKE ?", 
-			"%"+query+"%", "%"+query+"%", "%"+query+"%").
+	err := query.
+		Order("materials.created_at DESC, materials.id").
 		Limit(limit).
 		Offset(offset).
-		Order("created_at DESC").
 		Find(&materials).Error
 	return materials, err
-}
\ No newline at end of file
+}
+
+func (r *materialRepository) CountSearch(ctx context.Context, filter repositories.MaterialSearchFilter) (int64, error) {
+	var count int64
+	err := r.applySearchFilter(r.db.WithContext(ctx).Model(&entities.Material{}), filter).Count(&count).Error
+	return count, err
+}
+
+func (r *materialRepository) SearchFacets(ctx context.Context, filter repositories.MaterialSearchFilter) (*repositories.MaterialFacets, error) {
+	facets := &repositories.MaterialFacets{}
+
+	withoutType := filter
+	withoutType.Type = ""
+	if err := r.applySearchFilter(r.db.WithContext(ctx).Model(&entities.Material{}), withoutType).
+		Select("coalesce(materials.type, '') AS value, COUNT(*) AS count").
+		Group("coalesce(materials.type, '')").
+		Order("count DESC, value").
+		Scan(&facets.Types).Error; err != nil {
+		return nil, err
+	}
+
+	withoutStatus := filter
+	withoutStatus.Status = ""
+	if err := r.applySearchFilter(r.db.WithContext(ctx).Model(&entities.Material{}), withoutStatus).
+		Select("materials.status AS value, COUNT(*) AS count").
+		Group("materials.status").
+		Order("count DESC, value").
+		Scan(&facets.Statuses).Error; err != nil {
+		return nil, err
+	}
+
+	// materials that are not on a shelf have no shelf value and are left out of this facet
+	withoutShelf := filter
+	withoutShelf.ShelfID = ""
+	if err := r.applySearchFilter(r.db.WithContext(ctx).Model(&entities.Material{}), withoutShelf).
+		Joins("JOIN slots ON slots.material_id = materials.id").
+		Select("slots.shelf_id AS value, COUNT(DISTINCT materials.id) AS count").
+		Group("slots.shelf_id").
+		Order("count DESC, value").
+		Scan(&facets.Shelves).Error; err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// applySearchFilter qualifies every column, the shelf facet joins slots which has a status column too
+func (r *materialRepository) applySearchFilter(query *gorm.DB, filter repositories.MaterialSearchFilter) *gorm.DB {
+	if text := strings.TrimSpace(filter.Query); text != "" {
+		// the full-text match finds whole words in any order, the trigram indexed ILIKE finds fragments
+		// such as a partial barcode
+		pattern := "%" + likeEscaper.Replace(text) + "%"
+		query = query.Where(
+			"("+materialSearchDocument+" @@ plainto_tsquery('simple', ?) OR materials.barcode ILIKE ? OR materials.name ILIKE ?)",
+			text, pattern, pattern,
+		)
+	}
+	if filter.Type != "" {
+		query = query.Where("materials.type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("materials.status = ?", filter.Status)
+	}
+	if filter.ShelfID != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM slots shelf_slots WHERE shelf_slots.material_id = materials.id AND shelf_slots.shelf_id = ?)", filter.ShelfID)
+	}
+	return query
+}
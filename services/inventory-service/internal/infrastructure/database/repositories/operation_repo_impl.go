@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"WMS/services/inventory-service/internal/domain/entities"
 	"WMS/services/inventory-service/internal/domain/repositories"
 
@@ -67,6 +68,72 @@ func (r *operationRepository) List(ctx context.Context, limit, offset int) ([]*e
 	return operations, err
 }
 
+func (r *operationRepository) Find(ctx context.Context, options repositories.OperationListOptions) ([]*entities.Operation, error) {
+	// the sort field is whitelisted by the caller, it is the only part of the query that is not a parameter
+	column := string(options.SortBy)
+	direction, comparison := "ASC", ">"
+	if options.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	query := r.applyFilter(r.db.WithContext(ctx), options.Filter)
+	if options.After != nil {
+		var sortValue interface{} = options.After.SortValue
+		if options.SortBy == repositories.OperationSortByTimestamp {
+			timestamp, err := time.Parse(time.RFC3339Nano, options.After.SortValue)
+			if err != nil {
+				return nil, err
+			}
+			sortValue = timestamp
+		}
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparison), sortValue, options.After.ID)
+	}
+
+	var operations []*entities.Operation
+	err := query.
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(options.Limit).
+		Offset(options.Offset).
+		Preload("Material").
+		Preload("Slot").
+		Find(&operations).Error
+	return operations, err
+}
+
+func (r *operationRepository) Count(ctx context.Context, filter repositories.OperationFilter) (int64, error) {
+	var count int64
+	err := r.applyFilter(r.db.WithContext(ctx).Model(&entities.Operation{}), filter).Count(&count).Error
+	return count, err
+}
+
+func (r *operationRepository) applyFilter(query *gorm.DB, filter repositories.OperationFilter) *gorm.DB {
+	if filter.ShelfID != "" {
+		query = query.Where("shelf_id = ?", filter.ShelfID)
+	}
+	if filter.SlotID != "" {
+		query = query.Where("slot_id = ?", filter.SlotID)
+	}
+	if filter.OperatorID != "" {
+		query = query.Where("operator_id = ?", filter.OperatorID)
+	}
+	if filter.MaterialID != "" {
+		query = query.Where("material_id = ?", filter.MaterialID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("timestamp >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("timestamp < ?", filter.To)
+	}
+	return query
+}
+
 func (r *operationRepository) GetTimedOutPendingPhysicalConfirmations(ctx context.Context, timeout time.Duration) ([]*entities.Operation, error) {
 	var operations []*entities.Operation
 	err := r.db.WithContext(ctx).
@@ -83,6 +150,13 @@ func (r *operationRepository) UpdateWithTx(ctx context.Context, tx *gorm.DB, ope
 	return tx.WithContext(ctx).Save(operation).Error
 }
 
+func (r *operationRepository) TransitionStatusWithTx(ctx context.Context, tx *gorm.DB, id string, from, to entities.OperationStatus) (bool, error) {
+	result := tx.WithContext(ctx).Model(&entities.Operation{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	return result.RowsAffected == 1, result.Error
+}
+
 func (r *operationRepository) GetPendingPhysicalConfirmationsBySlotID(ctx context.Context, slotID string) ([]*entities.Operation, error) {
 	var operations []*entities.Operation
 	err := r.db.WithContext(ctx).
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type operationTransitionRepository struct {
+	db *gorm.DB
+}
+
+func NewOperationTransitionRepository(db *gorm.DB) repositories.OperationTransitionRepository {
+	return &operationTransitionRepository{db: db}
+}
+
+func (r *operationTransitionRepository) CreateWithTx(ctx context.Context, tx *gorm.DB, transition *entities.OperationTransition) error {
+	return tx.WithContext(ctx).Create(transition).Error
+}
+
+func (r *operationTransitionRepository) GetByOperationID(ctx context.Context, operationID string) ([]*entities.OperationTransition, error) {
+	var transitions []*entities.OperationTransition
+	err := r.db.WithContext(ctx).
+		Where("operation_id = ?", operationID).
+		Order("id ASC").
+		Find(&transitions).Error
+	return transitions, err
+}
+
+func (r *operationTransitionRepository) GetByMaterialID(ctx context.Context, materialID string) ([]*entities.OperationTransition, error) {
+	var transitions []*entities.OperationTransition
+	err := r.db.WithContext(ctx).
+		Where("material_id = ?", materialID).
+		Order("id ASC").
+		Find(&transitions).Error
+	return transitions, err
+}
+
+func (r *operationTransitionRepository) ListAfter(ctx context.Context, afterID int64, limit int) ([]*entities.OperationTransition, error) {
+	var transitions []*entities.OperationTransition
+	err := r.db.WithContext(ctx).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&transitions).Error
+	return transitions, err
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+const aggregateColumns = `
+	COUNT(*) AS sample_count,
+	AVG(weight) AS avg_weight,
+	MIN(weight) AS min_weight,
+	MAX(weight) AS max_weight,
+	AVG(temperature) AS avg_temperature,
+	MIN(temperature) AS min_temperature,
+	MAX(temperature) AS max_temperature,
+	AVG(humidity) AS avg_humidity,
+	MIN(humidity) AS min_humidity,
+	MAX(humidity) AS max_humidity,
+	AVG(light_level) AS avg_light_level`
+
+type sensorReadingRepository struct {
+	db *gorm.DB
+}
+
+func NewSensorReadingRepository(db *gorm.DB) repositories.SensorReadingRepository {
+	return &sensorReadingRepository{db: db}
+}
+
+func (r *sensorReadingRepository) Create(ctx context.Context, reading *entities.SensorReading) error {
+	return r.db.WithContext(ctx).Create(reading).Error
+}
+
+func (r *sensorReadingRepository) ListReadings(ctx context.Context, filter repositories.SensorReadingFilter) ([]*entities.SensorReading, error) {
+	var readings []*entities.SensorReading
+	err := r.applyFilter(r.db.WithContext(ctx), filter).
+		Order("recorded_at ASC").
+		Limit(filter.Limit).
+		Find(&readings).Error
+	return readings, err
+}
+
+func (r *sensorReadingRepository) GetLatestWeightBySlotID(ctx context.Context, slotID string, before time.Time) (*entities.SensorReading, error) {
+	var reading entities.SensorReading
+	err := r.db.WithContext(ctx).
+		Where("slot_id = ? AND recorded_at < ? AND weight IS NOT NULL", slotID, before).
+		Order("recorded_at DESC").
+		First(&reading).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reading, nil
+}
+
+func (r *sensorReadingRepository) ListRollups(ctx context.Context, filter repositories.SensorReadingFilter) ([]*entities.SensorReadingRollup, error) {
+	var rollups []*entities.SensorReadingRollup
+
+	// minute buckets are cheap enough to compute from the raw partitions on the fly,
+	// coarser resolutions are served from the pre-aggregated rollup table
+	if filter.Resolution == entities.SensorResolutionMinute {
+		err := r.applyFilter(r.db.WithContext(ctx).Model(&entities.SensorReading{}), filter).
+			Select(fmt.Sprintf("shelf_id, slot_id, ? AS resolution, date_trunc('minute', recorded_at) AS bucket_start, %s", aggregateColumns), filter.Resolution).
+			Group("shelf_id, slot_id, bucket_start").
+			Order("bucket_start ASC").
+			Limit(filter.Limit).
+			Scan(&rollups).Error
+		return rollups, err
+	}
+
+	query := r.db.WithContext(ctx).
+		Where("shelf_id = ? AND resolution = ?", filter.ShelfID, filter.Resolution).
+		Where("bucket_start >= ? AND bucket_start < ?", filter.From, filter.To)
+	if filter.SlotID != "" {
+		query = query.Where("slot_id = ?", filter.SlotID)
+	}
+
+	err := query.
+		Order("bucket_start ASC").
+		Limit(filter.Limit).
+		Find(&rollups).Error
+	return rollups, err
+}
+
+func (r *sensorReadingRepository) Rollup(ctx context.Context, resolution entities.SensorResolution, from, to time.Time) error {
+	if resolution != entities.SensorResolutionHour && resolution != entities.SensorResolutionDay {
+		return fmt.Errorf("unsupported rollup resolution: %s", resolution)
+	}
+
+	sql := fmt.Sprintf(`
+		INSERT INTO sensor_reading_rollups (
+			shelf_id, slot_id, resolution, bucket_start, sample_count,
+			avg_weight, min_weight, max_weight,
+			avg_temperature, min_temperature, max_temperature,
+			avg_humidity, min_humidity, max_humidity,
+			avg_light_level
+		)
+		SELECT shelf_id, slot_id, @resolution, date_trunc(@resolution, recorded_at) AS bucket_start, %s
+		FROM sensor_readings
+		WHERE recorded_at >= @from AND recorded_at < @to
+		GROUP BY shelf_id, slot_id, bucket_start
+		ON CONFLICT (shelf_id, slot_id, resolution, bucket_start) DO UPDATE SET
+			sample_count = EXCLUDED.sample_count,
+			avg_weight = EXCLUDED.avg_weight,
+			min_weight = EXCLUDED.min_weight,
+			max_weight = EXCLUDED.max_weight,
+			avg_temperature = EXCLUDED.avg_temperature,
+			min_temperature = EXCLUDED.min_temperature,
+			max_temperature = EXCLUDED.max_temperature,
+			avg_humidity = EXCLUDED.avg_humidity,
+			min_humidity = EXCLUDED.min_humidity,
+			max_humidity = EXCLUDED.max_humidity,
+			avg_light_level = EXCLUDED.avg_light_level`, aggregateColumns)
+
+	return r.db.WithContext(ctx).Exec(sql, map[string]interface{}{
+		"resolution": string(resolution),
+		"from":       from,
+		"to":         to,
+	}).Error
+}
+
+func (r *sensorReadingRepository) CreateMonthlyPartition(ctx context.Context, month time.Time) error {
+	// create_sensor_readings_partition is defined by postgres_setup.sql
+	return r.db.WithContext(ctx).Exec("SELECT create_sensor_readings_partition(?)", month).Error
+}
+
+func (r *sensorReadingRepository) applyFilter(query *gorm.DB, filter repositories.SensorReadingFilter) *gorm.DB {
+	query = query.
+		Where("shelf_id = ?", filter.ShelfID).
+		Where("recorded_at >= ? AND recorded_at < ?", filter.From, filter.To)
+	if filter.SlotID != "" {
+		query = query.Where("slot_id = ?", filter.SlotID)
+	}
+	return query
+}
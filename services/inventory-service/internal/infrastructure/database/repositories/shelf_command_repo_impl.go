@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type shelfCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewShelfCommandRepository(db *gorm.DB) repositories.ShelfCommandRepository {
+	return &shelfCommandRepository{db: db}
+}
+
+func (r *shelfCommandRepository) Create(ctx context.Context, command *entities.ShelfCommand) error {
+	return r.db.WithContext(ctx).Create(command).Error
+}
+
+func (r *shelfCommandRepository) GetByID(ctx context.Context, id string) (*entities.ShelfCommand, error) {
+	var command entities.ShelfCommand
+	err := r.db.WithContext(ctx).First(&command, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &command, nil
+}
+
+func (r *shelfCommandRepository) Update(ctx context.Context, command *entities.ShelfCommand) error {
+	return r.db.WithContext(ctx).Save(command).Error
+}
+
+func (r *shelfCommandRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entities.ShelfCommand{}).
+		Where("id = ? AND status = ?", id, entities.ShelfCommandStatusPending).
+		Updates(map[string]interface{}{
+			"status":     entities.ShelfCommandStatusSent,
+			"sent_at":    sentAt,
+			"updated_at": sentAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *shelfCommandRepository) ListAwaitingAck(ctx context.Context, sentBefore time.Time) ([]*entities.ShelfCommand, error) {
+	var commands []*entities.ShelfCommand
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND sent_at < ?", entities.ShelfCommandStatusSent, sentBefore).
+		Order("sent_at ASC").
+		Find(&commands).Error
+	return commands, err
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type shelfStateRepository struct {
+	db *gorm.DB
+}
+
+func NewShelfStateRepository(db *gorm.DB) repositories.ShelfStateRepository {
+	return &shelfStateRepository{db: db}
+}
+
+func (r *shelfStateRepository) GetByShelfID(ctx context.Context, shelfID string) (*entities.ShelfState, error) {
+	var state entities.ShelfState
+	err := r.db.WithContext(ctx).First(&state, "shelf_id = ?", shelfID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *shelfStateRepository) Save(ctx context.Context, state *entities.ShelfState) error {
+	return r.db.WithContext(ctx).Save(state).Error
+}
+
+func (r *shelfStateRepository) TouchHeartbeat(ctx context.Context, shelfID string, at time.Time) error {
+	// never move the heartbeat backwards when messages arrive out of order
+	return r.db.WithContext(ctx).Model(&entities.ShelfState{}).
+		Where("shelf_id = ? AND last_heartbeat_at < ?", shelfID, at).
+		Updates(map[string]interface{}{
+			"last_heartbeat_at": at,
+			"updated_at":        time.Now(),
+		}).Error
+}
+
+func (r *shelfStateRepository) ListStale(ctx context.Context, lastHeartbeatBefore time.Time) ([]*entities.ShelfState, error) {
+	var states []*entities.ShelfState
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND last_heartbeat_at < ?", []entities.ShelfStateStatus{entities.ShelfStateOnline, entities.ShelfStateDegraded}, lastHeartbeatBefore).
+		Order("last_heartbeat_at ASC").
+		Find(&states).Error
+	return states, err
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"WMS/services/inventory-service/internal/domain/entities"
 	"WMS/services/inventory-service/internal/domain/repositories"
 	
@@ -65,6 +66,28 @@ func (r *slotRepository) GetEmptySlotsByShelf(ctx context.Context, shelfID strin
 	return slots, err
 }
 
+func (r *slotRepository) GetByMaterialID(ctx context.Context, materialID string) (*entities.Slot, error) {
+	var slot entities.Slot
+	err := r.db.WithContext(ctx).First(&slot, "material_id = ?", materialID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+func (r *slotRepository) ListShelfIDs(ctx context.Context) ([]string, error) {
+	var shelfIDs []string
+	err := r.db.WithContext(ctx).
+		Model(&entities.Slot{}).
+		Distinct("shelf_id").
+		Order("shelf_id").
+		Pluck("shelf_id", &shelfIDs).Error
+	return shelfIDs, err
+}
+
 func (r *slotRepository) List(ctx context.Context, limit, offset int) ([]*entities.Slot, error) {
 	var slots []*entities.Slot
 	err := r.db.WithContext(ctx).
@@ -9,6 +9,22 @@ import (
 	"gorm.io/gorm"
 )
 
+// Shelf layout of the seed data, also emulated by the shelf simulator
+const (
+	SeedShelfRows    = 7
+	SeedShelfColumns = 100
+)
+
+// SeedShelfID returns the ID of the n-th seeded shelf, starting at 1
+func SeedShelfID(shelfNum int) string {
+	return fmt.Sprintf("SHELF%03d", shelfNum)
+}
+
+// SeedSlotID returns the ID of a seeded slot
+func SeedSlotID(shelfNum, row, col int) string {
+	return fmt.Sprintf("%s-R%02d-C%02d", SeedShelfID(shelfNum), row, col)
+}
+
 func SeedData(db *gorm.DB) error {
 	// check if the database already has data
 	var count int64
@@ -40,6 +56,17 @@ func SeedData(db *gorm.DB) error {
 func generateMockMaterials(count int) []*entities.Material {
 	materials := make([]*entities.Material, count)
 	materialTypes := []string{"IC", "Resistor", "Capacitor", "Inductor", "Connector", "CPU", "Memory", "PCB"}
+	// typical reel / tray weights in grams per material type
+	unitWeights := map[string]float64{
+		"IC":        120,
+		"Resistor":  250,
+		"Capacitor": 300,
+		"Inductor":  350,
+		"Connector": 180,
+		"CPU":       90,
+		"Memory":    60,
+		"PCB":       450,
+	}
 	
 	for i := 0; i < count; i++ {
 		materials[i] = &entities.Material{
@@ -48,6 +75,7 @@ func generateMockMaterials(count int) []*entities.Material {
 			Name:      fmt.Sprintf("%s Component %d", materialTypes[i%len(materialTypes)], i+1),
 			Type:      materialTypes[i%len(materialTypes)],
 			Status:    entities.MaterialStatusAvailable,
+			UnitWeight: unitWeights[materialTypes[i%len(materialTypes)]],
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
@@ -62,8 +90,8 @@ func generateMockSlots(shelfCount, slotsPerShelf int) []*entities.Slot {
 	
 	index := 0
 	for shelfNum := 1; shelfNum <= shelfCount; shelfNum++ {
-		rows := 7    // 7 rows per shelf
-		cols := 100  // 100 columns per shelf
+		rows := SeedShelfRows    // 7 rows per shelf
+		cols := SeedShelfColumns // 100 columns per shelf
 		
 		for row := 1; row <= rows; row++ {
 			for col := 1; col <= cols; col++ {
@@ -72,8 +100,8 @@ func generateMockSlots(shelfCount, slotsPerShelf int) []*entities.Slot {
 				}
 				
 				slots[index] = &entities.Slot{
-					ID:        fmt.Sprintf("SHELF%03d-R%02d-C%02d", shelfNum, row, col),
-					ShelfID:   fmt.Sprintf("SHELF%03d", shelfNum),
+					ID:        SeedSlotID(shelfNum, row, col),
+					ShelfID:   SeedShelfID(shelfNum),
 					Row:       row,
 					Column:    col,
 					Status:    entities.SlotStatusEmpty,
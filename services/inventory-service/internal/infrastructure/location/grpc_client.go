@@ -0,0 +1,151 @@
+package location
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	locationpb "github.com/m1i3k0e7/warehouse-management-system/services/location-service/api/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"WMS/services/inventory-service/internal/config"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+// GRPCClient calls location-service over gRPC, every call is bounded by the configured timeout and
+// guarded by a circuit breaker so an unavailable location-service does not slow down inventory operations
+type GRPCClient struct {
+	conn    *grpc.ClientConn
+	client  locationpb.LocationServiceClient
+	timeout time.Duration
+	breaker *CircuitBreaker
+}
+
+var _ services.LocationClient = (*GRPCClient)(nil)
+
+// NewGRPCClient prepares a connection to location-service. The connection is established lazily,
+// so the inventory service starts even while location-service is down.
+func NewGRPCClient(cfg config.LocationServiceConfig) (*GRPCClient, error) {
+	conn, err := grpc.NewClient(cfg.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create location-service client for %s: %w", cfg.Addr, err)
+	}
+	return &GRPCClient{
+		conn:    conn,
+		client:  locationpb.NewLocationServiceClient(conn),
+		timeout: cfg.Timeout,
+		breaker: NewCircuitBreaker(cfg.FailureThreshold, cfg.OpenTimeout),
+	}, nil
+}
+
+func (c *GRPCClient) GetShelfZone(ctx context.Context, shelfID string) (string, error) {
+	var resp *locationpb.ShelfLayoutResponse
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.client.GetShelfLayout(ctx, &locationpb.GetShelfLayoutRequest{ShelfId: shelfID})
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get layout of shelf %s: %w", shelfID, err)
+	}
+	return resp.GetShelf().GetZoneId(), nil
+}
+
+func (c *GRPCClient) SuggestPlacement(ctx context.Context, req services.PlacementRequest) ([]*services.PlacementSuggestion, error) {
+	var resp *locationpb.SuggestPlacementResponse
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.client.SuggestPlacement(ctx, &locationpb.SuggestPlacementRequest{
+			MaterialType: req.MaterialType,
+			ZoneId:       req.ZoneID,
+			ShelfId:      req.ShelfID,
+			StorageClass: req.StorageClass,
+			Role:         req.Role,
+			Limit:        int32(req.Limit),
+		})
+		return err
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, services.ErrNoSuitableSlot
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placement suggestion for %s: %w", req.MaterialType, err)
+	}
+	if len(resp.GetCandidates()) == 0 {
+		return []*services.PlacementSuggestion{{ShelfID: resp.GetShelfId(), SlotID: resp.GetSlotId()}}, nil
+	}
+	suggestions := make([]*services.PlacementSuggestion, 0, len(resp.GetCandidates()))
+	for _, candidate := range resp.GetCandidates() {
+		suggestions = append(suggestions, &services.PlacementSuggestion{ShelfID: candidate.GetShelfId(), SlotID: candidate.GetSlotId()})
+	}
+	return suggestions, nil
+}
+
+func (c *GRPCClient) UpdateSlotStatus(ctx context.Context, slot *entities.Slot) error {
+	slotStatus, ok := services.LocationSlotStatus(slot.Status)
+	if !ok {
+		return fmt.Errorf("slot status %s has no location-service equivalent", slot.Status)
+	}
+	req := &locationpb.UpdateSlotStatusRequest{ShelfId: slot.ShelfID, SlotId: slot.ID, Status: slotStatus}
+	if slot.MaterialID != nil {
+		req.MaterialId = *slot.MaterialID
+	}
+
+	err := c.call(ctx, func(ctx context.Context) error {
+		_, err := c.client.UpdateSlotStatus(ctx, req)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update slot %s in location-service: %w", slot.ID, err)
+	}
+	return nil
+}
+
+func (c *GRPCClient) GetShelfSlots(ctx context.Context, shelfID string) ([]*services.LocationSlot, error) {
+	var resp *locationpb.ShelfLayoutResponse
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.client.GetShelfLayout(ctx, &locationpb.GetShelfLayoutRequest{ShelfId: shelfID})
+		return err
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layout of shelf %s: %w", shelfID, err)
+	}
+
+	slots := make([]*services.LocationSlot, 0, len(resp.GetShelf().GetSlots()))
+	for _, slot := range resp.GetShelf().GetSlots() {
+		locationSlot := &services.LocationSlot{ID: slot.GetId(), Status: slot.GetStatus(), MaterialID: slot.GetMaterialId()}
+		if slot.GetReservedUntil() > 0 {
+			locationSlot.ReservedUntil = time.Unix(slot.GetReservedUntil(), 0)
+		}
+		slots = append(slots, locationSlot)
+	}
+	return slots, nil
+}
+
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// call runs fn with the configured timeout through the circuit breaker. Only errors that mean
+// location-service is unhealthy count as failures, a rejected request does not.
+func (c *GRPCClient) call(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := c.breaker.Allow(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	err := fn(ctx)
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.ResourceExhausted:
+		c.breaker.Failure()
+	default:
+		c.breaker.Success()
+	}
+	return err
+}
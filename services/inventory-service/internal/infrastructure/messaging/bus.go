@@ -0,0 +1,68 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"WMS/services/inventory-service/internal/config"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+// A failed message is retried, and a lost connection to the broker restored, with a delay that doubles up to maxRetryDelay
+const (
+	initialRetryDelay = time.Second
+	maxRetryDelay     = 30 * time.Second
+)
+
+const (
+	BackendKafka  = "kafka"
+	BackendNATS   = "nats"
+	BackendMemory = "memory"
+)
+
+// NewMessageBus creates the bus backend selected by cfg.Backend
+func NewMessageBus(ctx context.Context, cfg config.MessageBusConfig, kafka config.KafkaConfig) (services.MessageBus, error) {
+	switch cfg.Backend {
+	case BackendKafka, "":
+		return NewKafkaBus(kafka.Brokers)
+	case BackendNATS:
+		topics := []string{kafka.Topic}
+		if kafka.CommandTopic != "" {
+			topics = append(topics, kafka.CommandTopic)
+		}
+		return NewNATSBus(ctx, cfg.NATSURL, cfg.NATSStream, topics)
+	case BackendMemory:
+		return NewMemoryBus(), nil
+	default:
+		return nil, fmt.Errorf("unknown message bus backend %q, expected %s, %s or %s", cfg.Backend, BackendKafka, BackendNATS, BackendMemory)
+	}
+}
+
+// sleep waits for the delay and reports false if the context was cancelled first
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func nextRetryDelay(delay time.Duration) time.Duration {
+	if delay *= 2; delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+// retryDelay is the delay before retrying a message the handler failed on the given number of times
+func retryDelay(failures uint64) time.Duration {
+	delay := initialRetryDelay
+	for ; failures > 1 && delay < maxRetryDelay; failures-- {
+		delay = nextRetryDelay(delay)
+	}
+	return delay
+}
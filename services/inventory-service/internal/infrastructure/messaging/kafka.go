@@ -0,0 +1,130 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// KafkaBus publishes with a synchronous producer and subscribes through consumer groups
+type KafkaBus struct {
+	brokers  []string
+	producer sarama.SyncProducer
+}
+
+var _ services.MessageBus = (*KafkaBus)(nil)
+
+func NewKafkaBus(brokers []string) (*KafkaBus, error) {
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = 5
+	config.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KafkaBus{
+		brokers:  brokers,
+		producer: producer,
+	}, nil
+}
+
+func (b *KafkaBus) Publish(ctx context.Context, msg services.BusMessage) error {
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers))
+	for key, value := range msg.Headers {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+	}
+
+	_, _, err := b.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   msg.Topic,
+		Key:     sarama.StringEncoder(msg.Key),
+		Value:   sarama.ByteEncoder(msg.Payload),
+		Headers: headers,
+	})
+	return err
+}
+
+// Subscribe keeps consuming until the context is cancelled, reconnecting to Kafka whenever the group fails
+func (b *KafkaBus) Subscribe(ctx context.Context, topic, group string, handler services.BusHandler) error {
+	config := sarama.NewConfig()
+	config.Consumer.Offsets.Initial = sarama.OffsetNewest
+
+	var consumerGroup sarama.ConsumerGroup
+	delay := initialRetryDelay
+	for {
+		var err error
+		consumerGroup, err = sarama.NewConsumerGroup(b.brokers, group, config)
+		if err == nil {
+			break
+		}
+		logger.Error(fmt.Sprintf("Failed to join Kafka consumer group %s, retrying in %s", group, delay), err)
+		if !sleep(ctx, delay) {
+			return nil
+		}
+		delay = nextRetryDelay(delay)
+	}
+	defer consumerGroup.Close()
+
+	groupHandler := &kafkaGroupHandler{ctx: ctx, handler: handler}
+	delay = initialRetryDelay
+	for ctx.Err() == nil {
+		// Consume returns on every rebalance
+		if err := consumerGroup.Consume(ctx, []string{topic}, groupHandler); err != nil {
+			logger.Error(fmt.Sprintf("Failed to consume Kafka topic %s, retrying in %s", topic, delay), err)
+			sleep(ctx, delay)
+			delay = nextRetryDelay(delay)
+			continue
+		}
+		delay = initialRetryDelay
+	}
+	return nil
+}
+
+func (b *KafkaBus) Close() error {
+	return b.producer.Close()
+}
+
+type kafkaGroupHandler struct {
+	ctx     context.Context
+	handler services.BusHandler
+}
+
+func (h *kafkaGroupHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *kafkaGroupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (h *kafkaGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		headers := make(map[string]string, len(msg.Headers))
+		for _, header := range msg.Headers {
+			headers[string(header.Key)] = string(header.Value)
+		}
+		busMessage := services.BusMessage{
+			Topic:   msg.Topic,
+			Key:     string(msg.Key),
+			Headers: headers,
+			Payload: msg.Value,
+		}
+
+		// Retry the message in place so the other partitions of the session keep flowing. When the session
+		// ends first, the offset is left where it is and the next owner of the partition starts from the message.
+		delay := initialRetryDelay
+		for {
+			err := h.handler(h.ctx, busMessage)
+			if err == nil {
+				break
+			}
+			logger.Error(fmt.Sprintf("Failed to handle Kafka message at %s/%d offset %d, retrying in %s", msg.Topic, msg.Partition, msg.Offset, delay), err)
+			if !sleep(session.Context(), delay) {
+				return nil
+			}
+			delay = nextRetryDelay(delay)
+		}
+		session.MarkMessage(msg, "")
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// MemoryBus is an in-process bus for tests and single-instance development setups.
+// Messages are delivered asynchronously and are lost if nobody subscribes to the topic.
+// A message the handler fails on is retried by the same subscriber with a delay that doubles up to
+// maxRetryDelay, before any later message, so messages stay in the order they were published.
+// The last MemoryBusHistory published messages are kept for inspection in tests.
+type MemoryBus struct {
+	mu        sync.Mutex
+	groups    map[string]map[string]*memoryGroup // topic -> group -> subscribers
+	published []services.BusMessage
+}
+
+// MemoryBusHistory is how many of the most recent published messages Published returns
+const MemoryBusHistory = 1000
+
+type memoryGroup struct {
+	handlers []chan services.BusMessage
+	next     int
+}
+
+var _ services.MessageBus = (*MemoryBus)(nil)
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{groups: make(map[string]map[string]*memoryGroup)}
+}
+
+func (b *MemoryBus) Publish(ctx context.Context, msg services.BusMessage) error {
+	b.mu.Lock()
+	b.published = append(b.published, msg)
+	if len(b.published) >= 2*MemoryBusHistory {
+		// drop the older half at once rather than shifting on every publish, copying so the dropped messages can be collected
+		b.published = append([]services.BusMessage(nil), b.published[len(b.published)-MemoryBusHistory:]...)
+	}
+	var inboxes []chan services.BusMessage
+	for _, group := range b.groups[msg.Topic] {
+		if len(group.handlers) == 0 {
+			continue
+		}
+		// round robin between the subscribers of a group
+		inboxes = append(inboxes, group.handlers[group.next%len(group.handlers)])
+		group.next++
+	}
+	b.mu.Unlock()
+
+	for _, inbox := range inboxes {
+		select {
+		case inbox <- msg:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBus) Subscribe(ctx context.Context, topic, group string, handler services.BusHandler) error {
+	inbox := make(chan services.BusMessage, 256)
+
+	b.mu.Lock()
+	if b.groups[topic] == nil {
+		b.groups[topic] = make(map[string]*memoryGroup)
+	}
+	if b.groups[topic][group] == nil {
+		b.groups[topic][group] = &memoryGroup{}
+	}
+	g := b.groups[topic][group]
+	g.handlers = append(g.handlers, inbox)
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		for i, h := range g.handlers {
+			if h == inbox {
+				g.handlers = append(g.handlers[:i], g.handlers[i+1:]...)
+				break
+			}
+		}
+		b.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case msg := <-inbox:
+			delay := initialRetryDelay
+			for {
+				err := handler(ctx, msg)
+				if err == nil {
+					break
+				}
+				logger.Error(fmt.Sprintf("Failed to handle in-memory message on %s, retrying in %s", msg.Topic, delay), err)
+				if !sleep(ctx, delay) {
+					return nil
+				}
+				delay = nextRetryDelay(delay)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Published returns the last MemoryBusHistory messages published, in order
+func (b *MemoryBus) Published() []services.BusMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	published := b.published
+	if len(published) > MemoryBusHistory {
+		published = published[len(published)-MemoryBusHistory:]
+	}
+	return append([]services.BusMessage(nil), published...)
+}
+
+func (b *MemoryBus) Close() error {
+	return nil
+}
@@ -0,0 +1,115 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// keyHeader carries BusMessage.Key, which has no equivalent in NATS
+const keyHeader = "Wms-Key"
+
+// NATSBus publishes to and consumes from a JetStream stream, one subject per topic
+type NATSBus struct {
+	conn   *nats.Conn
+	js     jetstream.JetStream
+	stream string
+}
+
+var _ services.MessageBus = (*NATSBus)(nil)
+
+// NewNATSBus connects to NATS and makes sure the stream exists and captures the given topics
+func NewNATSBus(ctx context.Context, url, stream string, topics []string) (*NATSBus, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     stream,
+		Subjects: topics,
+	}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create stream %s: %w", stream, err)
+	}
+
+	return &NATSBus{
+		conn:   conn,
+		js:     js,
+		stream: stream,
+	}, nil
+}
+
+func (b *NATSBus) Publish(ctx context.Context, msg services.BusMessage) error {
+	natsMsg := nats.NewMsg(msg.Topic)
+	natsMsg.Header.Set(keyHeader, msg.Key)
+	for key, value := range msg.Headers {
+		natsMsg.Header.Set(key, value)
+	}
+	natsMsg.Data = msg.Payload
+
+	_, err := b.js.PublishMsg(ctx, natsMsg)
+	return err
+}
+
+func (b *NATSBus) Subscribe(ctx context.Context, topic, group string, handler services.BusHandler) error {
+	consumer, err := b.js.CreateOrUpdateConsumer(ctx, b.stream, jetstream.ConsumerConfig{
+		// durable names may not contain '.', which topics often do
+		Durable:       strings.NewReplacer(".", "_", "*", "_", ">", "_").Replace(group + "_" + topic),
+		FilterSubject: topic,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		DeliverPolicy: jetstream.DeliverNewPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create consumer %s for %s: %w", group, topic, err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		headers := make(map[string]string, len(msg.Headers()))
+		for key := range msg.Headers() {
+			if key != keyHeader {
+				headers[key] = msg.Headers().Get(key)
+			}
+		}
+
+		err := handler(ctx, services.BusMessage{
+			Topic:   msg.Subject(),
+			Key:     msg.Headers().Get(keyHeader),
+			Headers: headers,
+			Payload: msg.Data(),
+		})
+		if err != nil {
+			// back off like the Kafka consumer does, the delay doubles with every delivery of the message
+			delay := initialRetryDelay
+			if metadata, metadataErr := msg.Metadata(); metadataErr == nil {
+				delay = retryDelay(metadata.NumDelivered)
+			}
+			logger.Error(fmt.Sprintf("Failed to handle NATS message on %s, requesting redelivery in %s", msg.Subject(), delay), err)
+			msg.NakWithDelay(delay)
+			return
+		}
+		msg.Ack()
+	})
+	if err != nil {
+		return err
+	}
+	defer consumeCtx.Stop()
+
+	<-ctx.Done()
+	return nil
+}
+
+func (b *NATSBus) Close() error {
+	return b.conn.Drain()
+}
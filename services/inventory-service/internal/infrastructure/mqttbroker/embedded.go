@@ -0,0 +1,82 @@
+package mqttbroker
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"WMS/services/inventory-service/internal/config"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// EmbeddedBroker is an in-process MQTT broker for local development and tests.
+// It supports shared subscriptions, so several service instances can be tested against it.
+type EmbeddedBroker struct {
+	server   *mochi.Server
+	listener *listeners.TCP
+}
+
+// Start starts an in-process broker listening on cfg.EmbeddedBrokerAddress.
+// If cfg.Username is set, only clients presenting these credentials may connect.
+func Start(cfg config.MQTTConfig) (*EmbeddedBroker, error) {
+	server := mochi.New(&mochi.Options{
+		// the broker logs through the service logger below, keep its own output quiet
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	var err error
+	if cfg.Username != "" {
+		err = server.AddHook(new(auth.Hook), &auth.Options{
+			Ledger: &auth.Ledger{
+				Auth: auth.AuthRules{
+					{Username: auth.RString(cfg.Username), Password: auth.RString(cfg.Password), Allow: true},
+				},
+			},
+		})
+	} else {
+		err = server.AddHook(new(auth.AllowHook), nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure embedded MQTT broker auth: %w", err)
+	}
+
+	listener := listeners.NewTCP(listeners.Config{
+		ID:      "embedded",
+		Address: cfg.EmbeddedBrokerAddress,
+	})
+	if err := server.AddListener(listener); err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", cfg.EmbeddedBrokerAddress, err)
+	}
+
+	if err := server.Serve(); err != nil {
+		return nil, fmt.Errorf("failed to start embedded MQTT broker: %w", err)
+	}
+
+	logger.Info(fmt.Sprintf("Embedded MQTT broker listening on %s", listener.Address()))
+	return &EmbeddedBroker{server: server, listener: listener}, nil
+}
+
+// Address returns the address the broker listens on, with the actual port if it was started on port 0
+func (b *EmbeddedBroker) Address() string {
+	return b.listener.Address()
+}
+
+// URL returns the broker URL clients of this process should connect to
+func (b *EmbeddedBroker) URL() string {
+	host, port, err := net.SplitHostPort(b.Address())
+	if err != nil {
+		return fmt.Sprintf("tcp://%s", b.Address())
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return fmt.Sprintf("tcp://%s", net.JoinHostPort(host, port))
+}
+
+func (b *EmbeddedBroker) Close() error {
+	return b.server.Close()
+}
@@ -0,0 +1,265 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"sync"
+
+	"WMS/shared/commands"
+	"WMS/shared/events"
+	appcommands "WMS/services/inventory-service/internal/application/commands"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/pkg/errors"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// unknownCommandType files poison messages whose command type cannot be read
+const unknownCommandType = "unknown"
+
+// CommandConsumer maps commands other services send over the message bus to the command handlers.
+// A command is only acknowledged, i.e. its offset committed, once it was handled or given up on:
+// malformed and invalid commands go straight to failed_events, others, including conflicts
+// such as a locked shelf, are redelivered until they succeed or fail maxAttempts times.
+// Redeliveries of a command that was already handled or given up on are skipped by its CommandID.
+type CommandConsumer struct {
+	bus         services.MessageBus
+	topic       string
+	group       string
+	maxAttempts int
+
+	placeMaterialHandler       *appcommands.PlaceMaterialCommandHandler
+	batchPlaceMaterialsHandler *appcommands.BatchPlaceMaterialsCommandHandler
+	removeMaterialHandler      *appcommands.RemoveMaterialCommandHandler
+	moveMaterialHandler        *appcommands.MoveMaterialCommandHandler
+	reserveSlotsHandler        *appcommands.ReserveSlotsCommandHandler
+	inventoryService           *services.InventoryService
+	commandGuardService        *services.CommandGuardService
+
+	mu       sync.Mutex
+	attempts map[string]int // command ID -> failed deliveries so far
+}
+
+func NewCommandConsumer(
+	bus services.MessageBus,
+	topic string,
+	group string,
+	maxAttempts int,
+	placeMaterialHandler *appcommands.PlaceMaterialCommandHandler,
+	batchPlaceMaterialsHandler *appcommands.BatchPlaceMaterialsCommandHandler,
+	removeMaterialHandler *appcommands.RemoveMaterialCommandHandler,
+	moveMaterialHandler *appcommands.MoveMaterialCommandHandler,
+	reserveSlotsHandler *appcommands.ReserveSlotsCommandHandler,
+	inventoryService *services.InventoryService,
+	commandGuardService *services.CommandGuardService,
+) *CommandConsumer {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &CommandConsumer{
+		bus:                        bus,
+		topic:                      topic,
+		group:                      group,
+		maxAttempts:                maxAttempts,
+		placeMaterialHandler:       placeMaterialHandler,
+		batchPlaceMaterialsHandler: batchPlaceMaterialsHandler,
+		removeMaterialHandler:      removeMaterialHandler,
+		moveMaterialHandler:        moveMaterialHandler,
+		reserveSlotsHandler:        reserveSlotsHandler,
+		inventoryService:           inventoryService,
+		commandGuardService:        commandGuardService,
+		attempts:                   make(map[string]int),
+	}
+}
+
+// Run consumes the command topic as a member of the consumer group until the context is cancelled
+func (c *CommandConsumer) Run(ctx context.Context) error {
+	logger.Info(fmt.Sprintf("Consuming commands from %s as %s", c.topic, c.group))
+	return c.bus.Subscribe(ctx, c.topic, c.group, c.Handle)
+}
+
+// Handle processes one command message. Returning an error asks the bus to redeliver it.
+func (c *CommandConsumer) Handle(ctx context.Context, msg services.BusMessage) error {
+	var envelope commands.Envelope
+	if err := json.Unmarshal(msg.Payload, &envelope); err != nil {
+		return c.park(ctx, msg, msg.Headers[commands.HeaderCommandType], errors.NewValidationError("malformed command", err))
+	}
+	if envelope.CommandID == "" || envelope.CommandType == "" {
+		return c.park(ctx, msg, envelope.CommandType, errors.NewValidationError("command_id and command_type are required", nil))
+	}
+
+	// events published while handling the command are correlated with it
+	correlationID := envelope.CorrelationID
+	if correlationID == "" {
+		correlationID = envelope.CommandID
+	}
+	ctx = events.WithTrace(ctx, correlationID, envelope.CommandID)
+
+	guarded := true
+	verdict, err := c.commandGuardService.Admit(ctx, envelope.CommandID)
+	if err != nil {
+		// prefer handling a possible duplicate over stalling the topic while the dedup store is unavailable
+		logger.Error(fmt.Sprintf("Failed to check command %s for duplicates, handling it unguarded", envelope.CommandID), err)
+		guarded = false
+		verdict = services.CommandGuardAccepted
+	}
+	switch verdict {
+	case services.CommandGuardHandled:
+		logger.Info(fmt.Sprintf("Skipping command %s (%s), it was handled before", envelope.CommandID, envelope.CommandType))
+		return nil
+	case services.CommandGuardInProgress:
+		// redelivered once the other consumer is done, and skipped then unless it failed
+		return fmt.Errorf("command %s is being handled by another consumer", envelope.CommandID)
+	}
+
+	redeliver := c.handle(ctx, msg, envelope)
+	if guarded {
+		if err := c.commandGuardService.Complete(ctx, envelope.CommandID, redeliver != nil); err != nil {
+			logger.Error(fmt.Sprintf("Failed to record outcome of command %s", envelope.CommandID), err)
+		}
+	}
+	return redeliver
+}
+
+// handle dispatches an admitted command and returns an error if it is to be redelivered
+func (c *CommandConsumer) handle(ctx context.Context, msg services.BusMessage, envelope commands.Envelope) error {
+	err := c.dispatch(ctx, envelope)
+	if err == nil {
+		c.forget(envelope.CommandID)
+		logger.Info(fmt.Sprintf("Handled command %s (%s) from %s", envelope.CommandID, envelope.CommandType, envelope.Source))
+		return nil
+	}
+
+	if isPermanent(err) {
+		if err := c.park(ctx, msg, envelope.CommandType, err); err != nil {
+			return err
+		}
+		c.forget(envelope.CommandID)
+		return nil
+	}
+
+	attempt := c.recordFailure(envelope.CommandID)
+	if attempt >= c.maxAttempts {
+		if err := c.park(ctx, msg, envelope.CommandType, fmt.Errorf("giving up after %d attempts: %w", attempt, err)); err != nil {
+			return err
+		}
+		c.forget(envelope.CommandID)
+		return nil
+	}
+	logger.Error(fmt.Sprintf("Failed to handle command %s, attempt %d/%d", envelope.CommandID, attempt, c.maxAttempts), err)
+	return err
+}
+
+func (c *CommandConsumer) dispatch(ctx context.Context, envelope commands.Envelope) error {
+	switch envelope.CommandType {
+	case commands.CommandTypePlaceMaterial:
+		var payload commands.PlaceMaterial
+		if err := decodePayload(envelope, &payload); err != nil {
+			return err
+		}
+		return c.placeMaterialHandler.Handle(ctx, toPlaceMaterialCommand(payload))
+
+	case commands.CommandTypeBatchPlaceMaterials:
+		var payload commands.BatchPlaceMaterials
+		if err := decodePayload(envelope, &payload); err != nil {
+			return err
+		}
+		cmd := appcommands.BatchPlaceMaterialsCommand{Commands: make([]appcommands.PlaceMaterialCommand, len(payload.Placements))}
+		for i, placement := range payload.Placements {
+			cmd.Commands[i] = toPlaceMaterialCommand(placement)
+		}
+		return c.batchPlaceMaterialsHandler.Handle(ctx, cmd)
+
+	case commands.CommandTypeRemoveMaterial:
+		var payload commands.RemoveMaterial
+		if err := decodePayload(envelope, &payload); err != nil {
+			return err
+		}
+		return c.removeMaterialHandler.Handle(ctx, appcommands.RemoveMaterialCommand{
+			SlotID:     payload.SlotID,
+			OperatorID: payload.OperatorID,
+			Reason:     payload.Reason,
+		})
+
+	case commands.CommandTypeMoveMaterial:
+		var payload commands.MoveMaterial
+		if err := decodePayload(envelope, &payload); err != nil {
+			return err
+		}
+		return c.moveMaterialHandler.Handle(ctx, appcommands.MoveMaterialCommand{
+			FromSlotID: payload.FromSlotID,
+			ToSlotID:   payload.ToSlotID,
+			OperatorID: payload.OperatorID,
+			Reason:     payload.Reason,
+		})
+
+	case commands.CommandTypeReserveSlots:
+		var payload commands.ReserveSlots
+		if err := decodePayload(envelope, &payload); err != nil {
+			return err
+		}
+		return c.reserveSlotsHandler.Handle(ctx, appcommands.ReserveSlotsCommand{
+			SlotIDs:    payload.SlotIDs,
+			OperatorID: payload.OperatorID,
+			Duration:   payload.Duration,
+			Purpose:    payload.Purpose,
+		})
+
+	default:
+		return errors.NewValidationError(fmt.Sprintf("unknown command type %q", envelope.CommandType), nil)
+	}
+}
+
+func toPlaceMaterialCommand(payload commands.PlaceMaterial) appcommands.PlaceMaterialCommand {
+	return appcommands.PlaceMaterialCommand{
+		MaterialBarcode: payload.MaterialBarcode,
+		SlotID:          payload.SlotID,
+		OperatorID:      payload.OperatorID,
+	}
+}
+
+func decodePayload(envelope commands.Envelope, payload interface{}) error {
+	if err := json.Unmarshal(envelope.Payload, payload); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("invalid %s payload", envelope.CommandType), err)
+	}
+	return nil
+}
+
+// isPermanent reports whether redelivering the command cannot change the outcome
+func isPermanent(err error) bool {
+	var validationErr *errors.ValidationError
+	var notFoundErr *errors.NotFoundError
+	return stderrors.As(err, &validationErr) || stderrors.As(err, &notFoundErr)
+}
+
+// park stores a command that will not be retried in failed_events, from where it can be inspected and replayed.
+// A command that could not be stored is to be redelivered, so it is not lost.
+func (c *CommandConsumer) park(ctx context.Context, msg services.BusMessage, commandType string, err error) error {
+	if commandType == "" {
+		commandType = unknownCommandType
+	}
+	logger.Error(fmt.Sprintf("Moving %s command from %s to failed events", commandType, msg.Topic), err)
+
+	var payload interface{} = json.RawMessage(msg.Payload)
+	if !json.Valid(msg.Payload) {
+		payload = string(msg.Payload)
+	}
+	if err := c.inventoryService.SaveFailedEventToDLQ(ctx, msg.Topic, commandType, payload, err); err != nil {
+		return fmt.Errorf("failed to park %s command: %w", commandType, err)
+	}
+	return nil
+}
+
+func (c *CommandConsumer) recordFailure(commandID string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempts[commandID]++
+	return c.attempts[commandID]
+}
+
+func (c *CommandConsumer) forget(commandID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.attempts, commandID)
+}
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"WMS/services/inventory-service/pkg/errors"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// ToStatusError maps the application errors to gRPC status codes the same way the HTTP error
+// middleware maps them to status codes. Internal details are logged and not sent to the client.
+func ToStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch e := err.(type) {
+	case *errors.ValidationError:
+		return status.Error(codes.InvalidArgument, e.Message)
+	case *errors.NotFoundError:
+		return status.Error(codes.NotFound, e.Message)
+	case *errors.ConflictError:
+		return status.Error(codes.Aborted, e.Message)
+	case *errors.InternalError:
+		logger.Error("Internal server error", e)
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return status.FromContextError(err).Err()
+	}
+	logger.Error("Unhandled error", err)
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// errorInterceptor applies ToStatusError to every unary response
+func errorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	return resp, ToStatusError(err)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	pb "WMS/shared/proto/inventory"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+)
+
+var materialStatuses = map[entities.MaterialStatus]pb.MaterialStatus{
+	entities.MaterialStatusAvailable:   pb.MaterialStatus_MATERIAL_STATUS_AVAILABLE,
+	entities.MaterialStatusInUse:       pb.MaterialStatus_MATERIAL_STATUS_IN_USE,
+	entities.MaterialStatusReserved:    pb.MaterialStatus_MATERIAL_STATUS_RESERVED,
+	entities.MaterialStatusMaintenance: pb.MaterialStatus_MATERIAL_STATUS_MAINTENANCE,
+}
+
+var slotStatuses = map[entities.SlotStatus]pb.SlotStatus{
+	entities.SlotStatusEmpty:          pb.SlotStatus_SLOT_STATUS_EMPTY,
+	entities.SlotStatusOccupied:       pb.SlotStatus_SLOT_STATUS_OCCUPIED,
+	entities.SlotStatusReserved:       pb.SlotStatus_SLOT_STATUS_RESERVED,
+	entities.SlotStatusMaintenance:    pb.SlotStatus_SLOT_STATUS_MAINTENANCE,
+	entities.SlotStatusRemovalPending: pb.SlotStatus_SLOT_STATUS_REMOVAL_PENDING,
+}
+
+var operationTypes = map[entities.OperationType]pb.OperationType{
+	entities.OperationTypePlacement:   pb.OperationType_OPERATION_TYPE_PLACEMENT,
+	entities.OperationTypeRemoval:     pb.OperationType_OPERATION_TYPE_REMOVAL,
+	entities.OperationTypeMove:        pb.OperationType_OPERATION_TYPE_MOVE,
+	entities.OperationTypeReservation: pb.OperationType_OPERATION_TYPE_RESERVATION,
+}
+
+var operationStatuses = map[entities.OperationStatus]pb.OperationStatus{
+	entities.OperationStatusPending:                     pb.OperationStatus_OPERATION_STATUS_PENDING,
+	entities.OperationStatusCompleted:                   pb.OperationStatus_OPERATION_STATUS_COMPLETED,
+	entities.OperationStatusFailed:                      pb.OperationStatus_OPERATION_STATUS_FAILED,
+	entities.OperationStatusCancelled:                   pb.OperationStatus_OPERATION_STATUS_CANCELLED,
+	entities.OperationStatusPendingPhysicalConfirmation: pb.OperationStatus_OPERATION_STATUS_PENDING_PHYSICAL_CONFIRMATION,
+	entities.OperationStatusPendingRemovalConfirmation:  pb.OperationStatus_OPERATION_STATUS_PENDING_REMOVAL_CONFIRMATION,
+}
+
+func toProtoMaterial(material *entities.Material) *pb.Material {
+	return &pb.Material{
+		Id:        material.ID,
+		Barcode:   material.Barcode,
+		Name:      material.Name,
+		Type:      material.Type,
+		Status:    materialStatuses[material.Status],
+		CreatedAt: material.CreatedAt.Unix(),
+		UpdatedAt: material.UpdatedAt.Unix(),
+	}
+}
+
+func toProtoSlot(slot *entities.Slot) *pb.Slot {
+	s := &pb.Slot{
+		Id:        slot.ID,
+		ShelfId:   slot.ShelfID,
+		Row:       int32(slot.Row),
+		Column:    int32(slot.Column),
+		Status:    slotStatuses[slot.Status],
+		UpdatedAt: slot.UpdatedAt.Unix(),
+		Version:   slot.Version,
+	}
+	if slot.MaterialID != nil {
+		s.MaterialId = *slot.MaterialID
+	}
+	return s
+}
+
+func toProtoShelfStatus(status *entities.ShelfStatus, includeSlots bool) *pb.ShelfStatus {
+	s := &pb.ShelfStatus{
+		ShelfId:       status.ShelfID,
+		TotalSlots:    int32(status.TotalSlots),
+		EmptySlots:    int32(status.EmptySlots),
+		OccupiedSlots: int32(status.OccupiedSlots),
+		UpdatedAt:     status.UpdatedAt.Unix(),
+	}
+	if includeSlots {
+		for i := range status.Slots {
+			s.Slots = append(s.Slots, toProtoSlot(&status.Slots[i]))
+		}
+	}
+	return s
+}
+
+func toProtoOperation(operation *entities.Operation) *pb.Operation {
+	return &pb.Operation{
+		Id:         operation.ID,
+		Type:       operationTypes[operation.Type],
+		MaterialId: operation.MaterialID,
+		SlotId:     operation.SlotID,
+		OperatorId: operation.OperatorID,
+		ShelfId:    operation.ShelfID,
+		Timestamp:  operation.Timestamp.Unix(),
+		Status:     operationStatuses[operation.Status],
+	}
+}
+
+func toProtoFacetCounts(counts []repositories.MaterialFacetCount) []*pb.FacetCount {
+	facets := make([]*pb.FacetCount, 0, len(counts))
+	for _, count := range counts {
+		facets = append(facets, &pb.FacetCount{Value: count.Value, Count: count.Count})
+	}
+	return facets
+}
+
+// The request enums map back to the entity values. UNSPECIFIED means no filter, a value this
+// server does not know is passed on by name so the query rejects it instead of ignoring it.
+
+func fromProtoMaterialStatus(status pb.MaterialStatus) entities.MaterialStatus {
+	if status == pb.MaterialStatus_MATERIAL_STATUS_UNSPECIFIED {
+		return ""
+	}
+	for value, protoValue := range materialStatuses {
+		if protoValue == status {
+			return value
+		}
+	}
+	return entities.MaterialStatus(status.String())
+}
+
+func fromProtoOperationType(operationType pb.OperationType) entities.OperationType {
+	if operationType == pb.OperationType_OPERATION_TYPE_UNSPECIFIED {
+		return ""
+	}
+	for value, protoValue := range operationTypes {
+		if protoValue == operationType {
+			return value
+		}
+	}
+	return entities.OperationType(operationType.String())
+}
+
+func fromProtoOperationStatus(status pb.OperationStatus) entities.OperationStatus {
+	if status == pb.OperationStatus_OPERATION_STATUS_UNSPECIFIED {
+		return ""
+	}
+	for value, protoValue := range operationStatuses {
+		if protoValue == status {
+			return value
+		}
+	}
+	return entities.OperationStatus(status.String())
+}
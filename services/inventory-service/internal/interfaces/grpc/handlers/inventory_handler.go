@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	pb "WMS/shared/proto/inventory"
+	"WMS/services/inventory-service/internal/application/commands"
+	"WMS/services/inventory-service/internal/application/queries"
+)
+
+// InventoryServer implements the gRPC InventoryService on top of the same command and query
+// handlers as the HTTP API. Errors are returned as they are, the server interceptor maps them.
+type InventoryServer struct {
+	pb.UnimplementedInventoryServiceServer
+
+	placeMaterialHandler   *commands.PlaceMaterialCommandHandler
+	removeMaterialHandler  *commands.RemoveMaterialCommandHandler
+	getShelfStatusHandler  *queries.GetShelfStatusQueryHandler
+	searchMaterialsHandler *queries.SearchMaterialsQueryHandler
+	getOperationsHandler   *queries.GetOperationsQueryHandler
+}
+
+func NewInventoryServer(
+	placeMaterialHandler *commands.PlaceMaterialCommandHandler,
+	removeMaterialHandler *commands.RemoveMaterialCommandHandler,
+	getShelfStatusHandler *queries.GetShelfStatusQueryHandler,
+	searchMaterialsHandler *queries.SearchMaterialsQueryHandler,
+	getOperationsHandler *queries.GetOperationsQueryHandler,
+) *InventoryServer {
+	return &InventoryServer{
+		placeMaterialHandler:   placeMaterialHandler,
+		removeMaterialHandler:  removeMaterialHandler,
+		getShelfStatusHandler:  getShelfStatusHandler,
+		searchMaterialsHandler: searchMaterialsHandler,
+		getOperationsHandler:   getOperationsHandler,
+	}
+}
+
+func (s *InventoryServer) PlaceMaterial(ctx context.Context, req *pb.PlaceMaterialRequest) (*pb.PlaceMaterialResponse, error) {
+	cmd := commands.PlaceMaterialCommand{
+		MaterialBarcode: req.MaterialBarcode,
+		SlotID:          req.SlotId,
+		OperatorID:      req.OperatorId,
+	}
+	if err := s.placeMaterialHandler.Handle(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return &pb.PlaceMaterialResponse{Success: true, Message: "Material placed successfully"}, nil
+}
+
+func (s *InventoryServer) RemoveMaterial(ctx context.Context, req *pb.RemoveMaterialRequest) (*pb.RemoveMaterialResponse, error) {
+	cmd := commands.RemoveMaterialCommand{
+		SlotID:     req.SlotId,
+		OperatorID: req.OperatorId,
+		Reason:     req.Reason,
+	}
+	if err := s.removeMaterialHandler.Handle(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return &pb.RemoveMaterialResponse{Success: true, Message: "Material removed successfully"}, nil
+}
+
+// GetShelfStatus only returns the slots when include_details is set
+func (s *InventoryServer) GetShelfStatus(ctx context.Context, req *pb.GetShelfStatusRequest) (*pb.GetShelfStatusResponse, error) {
+	status, err := s.getShelfStatusHandler.Handle(ctx, queries.GetShelfStatusQuery{ShelfID: req.ShelfId})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetShelfStatusResponse{Status: toProtoShelfStatus(status, req.IncludeDetails)}, nil
+}
+
+func (s *InventoryServer) SearchMaterials(ctx context.Context, req *pb.SearchMaterialsRequest) (*pb.SearchMaterialsResponse, error) {
+	q := queries.SearchMaterialsQuery{
+		Query:   req.Query,
+		Type:    req.Type,
+		Status:  fromProtoMaterialStatus(req.Status),
+		ShelfID: req.ShelfId,
+		Limit:   int(req.Limit),
+		Offset:  int(req.Offset),
+	}
+	page, err := s.searchMaterialsHandler.Handle(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.SearchMaterialsResponse{TotalCount: page.TotalCount}
+	for _, material := range page.Materials {
+		resp.Materials = append(resp.Materials, toProtoMaterial(material))
+	}
+	if page.Facets != nil {
+		resp.TypeFacets = toProtoFacetCounts(page.Facets.Types)
+		resp.StatusFacets = toProtoFacetCounts(page.Facets.Statuses)
+		resp.ShelfFacets = toProtoFacetCounts(page.Facets.Shelves)
+	}
+	return resp, nil
+}
+
+// GetOperationHistory lists operations newest first, paging with the returned cursor
+func (s *InventoryServer) GetOperationHistory(ctx context.Context, req *pb.GetOperationHistoryRequest) (*pb.GetOperationHistoryResponse, error) {
+	q := queries.GetOperationsQuery{
+		ShelfID:    req.ShelfId,
+		SlotID:     req.SlotId,
+		OperatorID: req.OperatorId,
+		MaterialID: req.MaterialId,
+		Type:       fromProtoOperationType(req.Type),
+		Status:     fromProtoOperationStatus(req.Status),
+		Cursor:     req.Cursor,
+		Limit:      int(req.Limit),
+	}
+	if req.From != 0 {
+		q.From = time.Unix(req.From, 0)
+	}
+	if req.To != 0 {
+		q.To = time.Unix(req.To, 0)
+	}
+
+	page, err := s.getOperationsHandler.Handle(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetOperationHistoryResponse{TotalCount: page.TotalCount, NextCursor: page.NextCursor}
+	for _, operation := range page.Operations {
+		resp.Operations = append(resp.Operations, toProtoOperation(operation))
+	}
+	return resp, nil
+}
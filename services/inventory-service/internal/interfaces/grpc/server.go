@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"net"
+
+	"google.golang.org/grpc"
+	pb "WMS/shared/proto/inventory"
+	"WMS/services/inventory-service/internal/interfaces/grpc/handlers"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// Server serves the inventory gRPC API
+type Server struct {
+	grpcServer *grpc.Server
+	listener   net.Listener
+}
+
+// NewServer listens on addr and registers the inventory service
+func NewServer(addr string, inventoryServer *handlers.InventoryServer) (*Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := grpc.NewServer(grpc.UnaryInterceptor(errorInterceptor))
+	pb.RegisterInventoryServiceServer(s, inventoryServer)
+
+	return &Server{
+		grpcServer: s,
+		listener:   lis,
+	}, nil
+}
+
+// Start serves until Stop is called
+func (s *Server) Start() error {
+	logger.Info("gRPC server listening on " + s.listener.Addr().String())
+	return s.grpcServer.Serve(s.listener)
+}
+
+// Stop waits for in-flight calls to finish
+func (s *Server) Stop() {
+	s.grpcServer.GracefulStop()
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"WMS/services/inventory-service/internal/application/commands"
+	"WMS/services/inventory-service/internal/application/queries"
+	"WMS/services/inventory-service/internal/domain/entities"
+)
+
+// MaintenanceHandler handles HTTP requests related to slot maintenance.
+
+type MaintenanceHandler struct {
+	startSlotMaintenanceHandler    *commands.StartSlotMaintenanceCommandHandler
+	completeSlotMaintenanceHandler *commands.CompleteSlotMaintenanceCommandHandler
+	getMaintenanceTicketsHandler   *queries.GetMaintenanceTicketsQueryHandler
+}
+
+func NewMaintenanceHandler(
+	startSlotMaintenanceHandler *commands.StartSlotMaintenanceCommandHandler,
+	completeSlotMaintenanceHandler *commands.CompleteSlotMaintenanceCommandHandler,
+	getMaintenanceTicketsHandler *queries.GetMaintenanceTicketsQueryHandler,
+) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		startSlotMaintenanceHandler:    startSlotMaintenanceHandler,
+		completeSlotMaintenanceHandler: completeSlotMaintenanceHandler,
+		getMaintenanceTicketsHandler:   getMaintenanceTicketsHandler,
+	}
+}
+
+func (h *MaintenanceHandler) StartMaintenance(c *gin.Context) {
+	var cmd commands.StartSlotMaintenanceCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	cmd.SlotID = c.Param("slotId")
+
+	ticket, err := h.startSlotMaintenanceHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ticket)
+}
+
+func (h *MaintenanceHandler) CompleteMaintenance(c *gin.Context) {
+	var cmd commands.CompleteSlotMaintenanceCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	cmd.SlotID = c.Param("slotId")
+
+	ticket, err := h.completeSlotMaintenanceHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ticket)
+}
+
+func (h *MaintenanceHandler) GetSlotMaintenanceTickets(c *gin.Context) {
+	h.getTickets(c, queries.GetMaintenanceTicketsQuery{SlotID: c.Param("slotId")})
+}
+
+func (h *MaintenanceHandler) GetMaintenanceTickets(c *gin.Context) {
+	h.getTickets(c, queries.GetMaintenanceTicketsQuery{ShelfID: c.Query("shelf_id")})
+}
+
+func (h *MaintenanceHandler) getTickets(c *gin.Context, q queries.GetMaintenanceTicketsQuery) {
+	q.Status = entities.MaintenanceTicketStatus(c.Query("status"))
+	q.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "20"))
+	q.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	tickets, err := h.getMaintenanceTicketsHandler.Handle(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tickets": tickets})
+}
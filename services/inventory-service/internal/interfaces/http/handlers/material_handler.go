@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"WMS/services/inventory-service/internal/application/commands"
 	"WMS/services/inventory-service/internal/application/queries"
+	"WMS/services/inventory-service/internal/domain/entities"
 )
 
 // MaterialHandler handles HTTP requests related to materials.
@@ -16,6 +17,7 @@ type MaterialHandler struct {
 	removeMaterialHandler *commands.RemoveMaterialCommandHandler
 	moveMaterialHandler *commands.MoveMaterialCommandHandler
 	searchMaterialsHandler *queries.SearchMaterialsQueryHandler
+	getMaterialTraceHandler *queries.GetMaterialTraceQueryHandler
 }
 
 func NewMaterialHandler(
@@ -23,12 +25,14 @@ func NewMaterialHandler(
 	removeMaterialHandler *commands.RemoveMaterialCommandHandler,
 	moveMaterialHandler *commands.MoveMaterialCommandHandler,
 	searchMaterialsHandler *queries.SearchMaterialsQueryHandler,
+	getMaterialTraceHandler *queries.GetMaterialTraceQueryHandler,
 ) *MaterialHandler {
 	return &MaterialHandler{
 		placeMaterialHandler: placeMaterialHandler,
 		removeMaterialHandler: removeMaterialHandler,
 		moveMaterialHandler: moveMaterialHandler,
 		searchMaterialsHandler: searchMaterialsHandler,
+		getMaterialTraceHandler: getMaterialTraceHandler,
 	}
 }
 
@@ -77,21 +81,41 @@ func (h *MaterialHandler) MoveMaterial(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Material moved successfully"})
 }
 
+// SearchMaterials finds materials by text and type, status or shelf, returning facet and total counts
 func (h *MaterialHandler) SearchMaterials(c *gin.Context) {
-	query := c.Query("q")
 	limitStr := c.DefaultQuery("limit", "20")
 	offsetStr := c.DefaultQuery("offset", "0")
 
 	limit, _ := strconv.Atoi(limitStr)
 	offset, _ := strconv.Atoi(offsetStr)
 
-	q := queries.SearchMaterialsQuery{Query: query, Limit: limit, Offset: offset}
+	q := queries.SearchMaterialsQuery{
+		Query:   c.Query("q"),
+		Type:    c.Query("type"),
+		Status:  entities.MaterialStatus(c.Query("status")),
+		ShelfID: c.Query("shelf_id"),
+		Limit:   limit,
+		Offset:  offset,
+	}
+
+	page, err := h.searchMaterialsHandler.Handle(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// TraceMaterial returns where a material is now and every operation that moved it
+func (h *MaterialHandler) TraceMaterial(c *gin.Context) {
+	q := queries.GetMaterialTraceQuery{Barcode: c.Param("barcode")}
 
-	materials, err := h.searchMaterialsHandler.Handle(c.Request.Context(), q)
+	trace, err := h.getMaterialTraceHandler.Handle(c.Request.Context(), q)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"materials": materials})
+	c.JSON(http.StatusOK, trace)
 }
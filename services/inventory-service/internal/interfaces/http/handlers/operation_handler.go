@@ -6,32 +6,79 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"WMS/services/inventory-service/internal/application/queries"
+	"WMS/services/inventory-service/internal/domain/entities"
 )
 
 // OperationHandler handles HTTP requests related to operations.
 
 type OperationHandler struct {
-	getOperationsHandler *queries.GetOperationsQueryHandler
+	getOperationsHandler        *queries.GetOperationsQueryHandler
+	getOperationTimelineHandler *queries.GetOperationTimelineQueryHandler
 }
 
-func NewOperationHandler(getOperationsHandler *queries.GetOperationsQueryHandler) *OperationHandler {
-	return &OperationHandler{getOperationsHandler: getOperationsHandler}
+func NewOperationHandler(
+	getOperationsHandler *queries.GetOperationsQueryHandler,
+	getOperationTimelineHandler *queries.GetOperationTimelineQueryHandler,
+) *OperationHandler {
+	return &OperationHandler{
+		getOperationsHandler:        getOperationsHandler,
+		getOperationTimelineHandler: getOperationTimelineHandler,
+	}
 }
 
+// GetOperations lists operations filtered by shelf_id, slot_id, operator_id, material_id, type, status and
+// a from/to time range, sorted by sort and order. Pages are addressed by offset or by the next_cursor of
+// the previous page.
 func (h *OperationHandler) GetOperations(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
+	from, err := parseTimeParam(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be an RFC3339 timestamp"})
+		return
+	}
+
+	to, err := parseTimeParam(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be an RFC3339 timestamp"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	limit, _ := strconv.Atoi(limitStr)
-	offset, _ := strconv.Atoi(offsetStr)
+	q := queries.GetOperationsQuery{
+		ShelfID:    c.Query("shelf_id"),
+		SlotID:     c.Query("slot_id"),
+		OperatorID: c.Query("operator_id"),
+		MaterialID: c.Query("material_id"),
+		Type:       entities.OperationType(c.Query("type")),
+		Status:     entities.OperationStatus(c.Query("status")),
+		From:       from,
+		To:         to,
+		SortBy:     c.Query("sort"),
+		Order:      c.Query("order"),
+		Cursor:     c.Query("cursor"),
+		Limit:      limit,
+		Offset:     offset,
+	}
+
+	page, err := h.getOperationsHandler.Handle(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
 
-	q := queries.GetOperationsQuery{Limit: limit, Offset: offset}
+// GetOperationTimeline returns every status transition of an operation, oldest first
+func (h *OperationHandler) GetOperationTimeline(c *gin.Context) {
+	q := queries.GetOperationTimelineQuery{OperationID: c.Param("id")}
 
-	operations, err := h.getOperationsHandler.Handle(c.Request.Context(), q)
+	transitions, err := h.getOperationTimelineHandler.Handle(c.Request.Context(), q)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"operations": operations})
+	c.JSON(http.StatusOK, gin.H{"operation_id": q.OperationID, "transitions": transitions})
 }
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"WMS/services/inventory-service/internal/application/commands"
+)
+
+// ReconciliationHandler handles HTTP requests that compare inventory state with other services.
+
+type ReconciliationHandler struct {
+	reconcileLocationSlotsHandler *commands.ReconcileLocationSlotsCommandHandler
+}
+
+func NewReconciliationHandler(reconcileLocationSlotsHandler *commands.ReconcileLocationSlotsCommandHandler) *ReconciliationHandler {
+	return &ReconciliationHandler{reconcileLocationSlotsHandler: reconcileLocationSlotsHandler}
+}
+
+// ReconcileLocationSlots reports slots whose status in location-service drifted from inventory and
+// repairs them when asked to. An empty body checks every shelf without repairing.
+func (h *ReconciliationHandler) ReconcileLocationSlots(c *gin.Context) {
+	var cmd commands.ReconcileLocationSlotsCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.reconcileLocationSlotsHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
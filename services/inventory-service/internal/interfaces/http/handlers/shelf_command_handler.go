@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"WMS/services/inventory-service/internal/application/commands"
+	"WMS/services/inventory-service/internal/application/queries"
+)
+
+// ShelfCommandHandler handles HTTP requests related to commands sent to the shelf hardware.
+
+type ShelfCommandHandler struct {
+	sendShelfCommandHandler *commands.SendShelfCommandCommandHandler
+	getShelfCommandHandler  *queries.GetShelfCommandQueryHandler
+}
+
+func NewShelfCommandHandler(
+	sendShelfCommandHandler *commands.SendShelfCommandCommandHandler,
+	getShelfCommandHandler *queries.GetShelfCommandQueryHandler,
+) *ShelfCommandHandler {
+	return &ShelfCommandHandler{
+		sendShelfCommandHandler: sendShelfCommandHandler,
+		getShelfCommandHandler:  getShelfCommandHandler,
+	}
+}
+
+func (h *ShelfCommandHandler) SendCommand(c *gin.Context) {
+	var cmd commands.SendShelfCommandCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	cmd.ShelfID = c.Param("shelfId")
+
+	command, err := h.sendShelfCommandHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, command)
+}
+
+func (h *ShelfCommandHandler) GetCommand(c *gin.Context) {
+	q := queries.GetShelfCommandQuery{CommandID: c.Param("commandId")}
+
+	command, err := h.getShelfCommandHandler.Handle(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, command)
+}
@@ -49,13 +49,16 @@ func (h *SlotHandler) ReserveSlots(c *gin.Context) {
 func (h *SlotHandler) FindOptimalSlot(c *gin.Context) {
 	materialType := c.Query("material_type")
 	shelfID := c.Query("shelf_id")
+	zoneID := c.Query("zone_id")
+	storageClass := c.Query("storage_class")
+	role := c.Query("role")
 
 	if materialType == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "material_type is required"})
 		return
 	}
 
-	q := queries.FindOptimalSlotQuery{MaterialType: materialType, ShelfID: shelfID}
+	q := queries.FindOptimalSlotQuery{MaterialType: materialType, ShelfID: shelfID, ZoneID: zoneID, StorageClass: storageClass, Role: role}
 
 	slot, err := h.findOptimalSlotHandler.Handle(c.Request.Context(), q)
 	if err != nil {
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"WMS/services/inventory-service/internal/application/queries"
+	"WMS/services/inventory-service/internal/domain/entities"
+)
+
+// TelemetryHandler handles HTTP requests related to shelf sensor telemetry.
+
+type TelemetryHandler struct {
+	getSensorReadingsHandler *queries.GetSensorReadingsQueryHandler
+}
+
+func NewTelemetryHandler(getSensorReadingsHandler *queries.GetSensorReadingsQueryHandler) *TelemetryHandler {
+	return &TelemetryHandler{getSensorReadingsHandler: getSensorReadingsHandler}
+}
+
+func (h *TelemetryHandler) GetShelfTelemetry(c *gin.Context) {
+	h.getTelemetry(c, c.Param("shelfId"), c.Query("slot_id"))
+}
+
+func (h *TelemetryHandler) GetSlotTelemetry(c *gin.Context) {
+	h.getTelemetry(c, c.Param("shelfId"), c.Param("slotId"))
+}
+
+func (h *TelemetryHandler) getTelemetry(c *gin.Context, shelfID, slotID string) {
+	from, err := parseTimeParam(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be an RFC3339 timestamp"})
+		return
+	}
+
+	to, err := parseTimeParam(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be an RFC3339 timestamp"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+
+	q := queries.GetSensorReadingsQuery{
+		ShelfID:    shelfID,
+		SlotID:     slotID,
+		From:       from,
+		To:         to,
+		Resolution: entities.SensorResolution(c.DefaultQuery("resolution", string(entities.SensorResolutionRaw))),
+		Limit:      limit,
+	}
+
+	result, err := h.getSensorReadingsHandler.Handle(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
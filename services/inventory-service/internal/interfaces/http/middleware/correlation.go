@@ -0,0 +1,25 @@
+package middleware
+
+import (
+    "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
+    "WMS/shared/events"
+)
+
+// CorrelationIDHeader lets callers tie the events caused by a request to their own trace
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// CorrelationID stores the request's correlation ID in the request context, generating one if the caller sent none,
+// so every event published while handling the request carries it
+func CorrelationID() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        correlationID := c.GetHeader(CorrelationIDHeader)
+        if correlationID == "" {
+            correlationID = uuid.New().String()
+        }
+
+        c.Request = c.Request.WithContext(events.WithTrace(c.Request.Context(), correlationID, ""))
+        c.Header(CorrelationIDHeader, correlationID)
+        c.Next()
+    }
+}
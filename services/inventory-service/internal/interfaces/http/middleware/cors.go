@@ -12,8 +12,8 @@ func CORS() gin.HandlerFunc {
     return cors.New(cors.Config{
         AllowOrigins:     []string{cfg.Service.AllowedOrigins},
         AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-        AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"},
-        ExposeHeaders:    []string{"Content-Length"},
+        AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With", CorrelationIDHeader},
+        ExposeHeaders:    []string{"Content-Length", CorrelationIDHeader},
         AllowCredentials: true,
         MaxAge:           12 * time.Hour,
     })
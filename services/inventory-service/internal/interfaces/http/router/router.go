@@ -6,10 +6,11 @@ import (
     "WMS/services/inventory-service/internal/interfaces/http/middleware"
 )
 
-func SetupRoutes(r *gin.Engine, materialHandler *handlers.MaterialHandler, slotHandler *handlers.SlotHandler, operationHandler *handlers.OperationHandler) {
+func SetupRoutes(r *gin.Engine, materialHandler *handlers.MaterialHandler, slotHandler *handlers.SlotHandler, operationHandler *handlers.OperationHandler, telemetryHandler *handlers.TelemetryHandler, maintenanceHandler *handlers.MaintenanceHandler, shelfCommandHandler *handlers.ShelfCommandHandler, reconciliationHandler *handlers.ReconciliationHandler) {
     // apply global middleware
     r.Use(middleware.CORS())
     r.Use(middleware.RequestLogger())
+    r.Use(middleware.CorrelationID())
     r.Use(middleware.ErrorHandler())
     
     // group routes under /api/v1
@@ -21,17 +22,36 @@ func SetupRoutes(r *gin.Engine, materialHandler *handlers.MaterialHandler, slotH
         v1.POST("/materials/move", materialHandler.MoveMaterial)
         v1.POST("/materials/batch-place", materialHandler.BatchPlaceMaterials)
         v1.GET("/materials/search", materialHandler.SearchMaterials)
+        v1.GET("/materials/:barcode/trace", materialHandler.TraceMaterial)
         
         // slot operations
         v1.POST("/slots/reserve", slotHandler.ReserveSlots)
         v1.GET("/slots/optimal", slotHandler.FindOptimalSlot)
+
+        // slot maintenance
+        v1.POST("/slots/:slotId/maintenance/start", maintenanceHandler.StartMaintenance)
+        v1.POST("/slots/:slotId/maintenance/complete", maintenanceHandler.CompleteMaintenance)
+        v1.GET("/slots/:slotId/maintenance", maintenanceHandler.GetSlotMaintenanceTickets)
+        v1.GET("/maintenance/tickets", maintenanceHandler.GetMaintenanceTickets)
         
         // shelf status info
         v1.GET("/shelves/:shelfId/status", slotHandler.GetShelfStatus)
         v1.GET("/shelves/:shelfId/health", slotHandler.HealthCheckShelf)
 
+        // downlink commands to the shelf hardware
+        v1.POST("/shelves/:shelfId/commands", shelfCommandHandler.SendCommand)
+        v1.GET("/shelf-commands/:commandId", shelfCommandHandler.GetCommand)
+
+        // sensor telemetry history
+        v1.GET("/shelves/:shelfId/telemetry", telemetryHandler.GetShelfTelemetry)
+        v1.GET("/shelves/:shelfId/slots/:slotId/telemetry", telemetryHandler.GetSlotTelemetry)
+
         // operation logs
         v1.GET("/operations", operationHandler.GetOperations)
+        v1.GET("/operations/:id/timeline", operationHandler.GetOperationTimeline)
+
+        // consistency checks against other services
+        v1.POST("/reconciliation/location-slots", reconciliationHandler.ReconcileLocationSlots)
     }
     
     // check health endpoint
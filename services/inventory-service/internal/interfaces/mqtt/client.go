@@ -0,0 +1,108 @@
+package mqtt
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"WMS/services/inventory-service/internal/config"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// defaultTopicPrefix is the root of all shelf topics, e.g. {prefix}/{shelf_id}/events
+const defaultTopicPrefix = "WMS/services/inventory-service/shelf"
+
+const defaultClientIDPrefix = "inventory-service"
+
+// NewClient creates the MQTT client shared by the shelf event handler and the shelf command publisher.
+// The client is connected by MQTTHandler.Connect.
+func NewClient(cfg config.MQTTConfig) (mqtt.Client, error) {
+	opts := mqtt.NewClientOptions()
+	opts.AddBroker(cfg.BrokerURL)
+	opts.SetClientID(clientID(cfg.ClientID))
+
+	if cfg.Username != "" {
+		opts.SetUsername(cfg.Username)
+		opts.SetPassword(cfg.Password)
+	}
+
+	if cfg.TLSEnabled {
+		tlsConfig, err := newTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	keepAlive := cfg.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = 60 * time.Second
+	}
+	opts.SetKeepAlive(keepAlive)
+	opts.SetPingTimeout(10 * time.Second)
+	opts.SetAutoReconnect(true)
+	opts.SetMaxReconnectInterval(10 * time.Second)
+
+	opts.SetConnectionLostHandler(func(client mqtt.Client, err error) {
+		logger.Error("MQTT connection lost", err)
+	})
+
+	opts.SetReconnectingHandler(func(client mqtt.Client, options *mqtt.ClientOptions) {
+		logger.Info("MQTT reconnecting...")
+	})
+
+	return mqtt.NewClient(opts), nil
+}
+
+// clientID returns the configured client ID, or a unique one so replicas don't disconnect each other
+func clientID(configured string) string {
+	if configured != "" {
+		return configured
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Sprintf("%s-%s-%d", defaultClientIDPrefix, hostname, time.Now().UnixNano())
+	}
+	return fmt.Sprintf("%s-%s-%s", defaultClientIDPrefix, hostname, hex.EncodeToString(suffix))
+}
+
+func newTLSConfig(cfg config.MQTTConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+	}
+
+	if cfg.TLSCAFile != "" {
+		caCert, err := os.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MQTT CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in MQTT CA file %s", cfg.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	// client certificates are optional, brokers may authenticate by username/password over TLS instead
+	if cfg.TLSCertFile != "" || cfg.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load MQTT client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -2,36 +2,67 @@ package mqtt
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/google/uuid"
+	"WMS/shared/events"
 	"WMS/services/inventory-service/internal/application/commands"
+	"WMS/services/inventory-service/internal/domain/entities"
 	"WMS/services/inventory-service/internal/domain/services"
 	"WMS/services/inventory-service/pkg/utils/logger"
 )
 
 // MQTT message structure for shelf events and status updates
 type ShelfEvent struct {
+	EventID         string     `json:"event_id,omitempty"` // stable across redeliveries, see fallbackEventID if missing
+	Sequence        int64      `json:"sequence,omitempty"` // per shelf, increasing
 	ShelfID         string     `json:"shelf_id"`
 	SlotID          string     `json:"slot_id"`
-	EventType       string     `json:"event_type"` // "material_detected", "material_removed", "slot_error"
+	EventType       string     `json:"event_type"` // "material.detected", "material.removed", "slot.error"
 	MaterialBarcode string     `json:"material_barcode,omitempty"`
-	Timestamp       int64      `json:"timestamp"`
+	ErrorType       string     `json:"error_type,omitempty"`    // slot.error only, see entities.SlotErrorType
+	ErrorDetails    map[string]interface{} `json:"error_details,omitempty"` // slot.error only, raw diagnostics from the shelf
+	SlotVersion     int64      `json:"slot_version,omitempty"` // slot version from the last command the shelf received for the slot
+	Timestamp       int64      `json:"timestamp"` // unix milliseconds
 	SensorData      *SensorData `json:"sensor_data,omitempty"`
 }
 
+// SensorData fields are pointers so a reading that was not reported can be told apart from a zero reading
 type SensorData struct {
-	Weight      float64 `json:"weight,omitempty"`
-	Temperature float64 `json:"temperature,omitempty"`
-	Humidity    float64 `json:"humidity,omitempty"`
-	LightLevel  int     `json:"light_level,omitempty"`
+	Weight      *float64 `json:"weight,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	Humidity    *float64 `json:"humidity,omitempty"`
+	LightLevel  *int     `json:"light_level,omitempty"`
+}
+
+func (e *ShelfEvent) guardMeta() services.ShelfEventMeta {
+	return services.ShelfEventMeta{
+		ShelfID:          e.ShelfID,
+		SlotID:           e.SlotID,
+		EventID:          e.EventID,
+		Sequence:         e.Sequence,
+		Timestamp:        time.UnixMilli(e.Timestamp),
+		SlotVersion:      e.SlotVersion,
+		ChangesSlotState: e.EventType == services.EventTypeMaterialDetected || e.EventType == services.EventTypeMaterialRemoved,
+	}
+}
+
+// weight returns the slot weight reported with the event, or nil if the event carried none
+func (e *ShelfEvent) weight() *float64 {
+	if e.SensorData == nil {
+		return nil
+	}
+	return e.SensorData.Weight
 }
 
 type ShelfStatus struct {
 	ShelfID   string `json:"shelf_id"`
-	Status    string `json:"status"` // "online", "offline", "maintenance"
+	Status    string `json:"status"` // "online", "offline", "maintenance", "degraded"
 	Timestamp int64  `json:"timestamp"`
 }
 
@@ -42,37 +73,28 @@ type MQTTHandler struct {
 	handleSlotErrorHandler   *commands.HandleSlotErrorCommandHandler
 	updateShelfStatusHandler *commands.UpdateShelfStatusCommandHandler
 	inventoryService         *services.InventoryService // New dependency
-	topicPrefix              string
+	telemetryService         *services.TelemetryService
+	eventGuardService        *services.EventGuardService
+	shelfCommandService      *services.ShelfCommandService
+	topics                   Topics
+	qos                      byte
 	retryService             *services.RetryService
 }
 
 func NewMQTTHandler(
-	brokerURL string,
+	client mqtt.Client,
+	topics Topics,
+	qos byte,
 	placeMaterialHandler *commands.PlaceMaterialCommandHandler,
 	removeMaterialHandler *commands.RemoveMaterialCommandHandler,
 	handleSlotErrorHandler *commands.HandleSlotErrorCommandHandler,
 	updateShelfStatusHandler *commands.UpdateShelfStatusCommandHandler,
 	inventoryService *services.InventoryService, // New parameter
+	telemetryService *services.TelemetryService,
+	eventGuardService *services.EventGuardService,
+	shelfCommandService *services.ShelfCommandService,
 	retryService *services.RetryService,
 ) *MQTTHandler {
-	opts := mqtt.NewClientOptions()
-	opts.AddBroker(brokerURL)
-	opts.SetClientID("inventory-service")
-	opts.SetKeepAlive(60 * time.Second)
-	opts.SetPingTimeout(10 * time.Second)
-	opts.SetAutoReconnect(true)
-	opts.SetMaxReconnectInterval(10 * time.Second)
-
-	opts.SetConnectionLostHandler(func(client mqtt.Client, err error) {
-		logger.Error("MQTT connection lost", err)
-	})
-
-	opts.SetReconnectingHandler(func(client mqtt.Client, options *mqtt.ClientOptions) {
-		logger.Info("MQTT reconnecting...")
-	})
-
-	client := mqtt.NewClient(opts)
-
 	return &MQTTHandler{
 		client:                   client,
 		placeMaterialHandler:     placeMaterialHandler,
@@ -80,7 +102,11 @@ func NewMQTTHandler(
 		handleSlotErrorHandler:   handleSlotErrorHandler,
 		updateShelfStatusHandler: updateShelfStatusHandler,
 		inventoryService:         inventoryService, // Initialize new dependency
-		topicPrefix:              "WMS/services/inventory-service/shelf",
+		telemetryService:         telemetryService,
+		eventGuardService:        eventGuardService,
+		shelfCommandService:      shelfCommandService,
+		topics:                   topics,
+		qos:                      qos,
 		retryService:             retryService,
 	}
 }
@@ -90,16 +116,19 @@ func (h *MQTTHandler) Connect() error {
 		return token.Error()
 	}
 
-	// subscribe to shelf events and status updates
-	eventTopic := fmt.Sprintf("%s/+/events", h.topicPrefix)
-	if token := h.client.Subscribe(eventTopic, 1, h.handleShelfEvent); token.Wait() && token.Error() != nil {
-		return token.Error()
+	subscriptions := []struct {
+		template string
+		handler  mqtt.MessageHandler
+	}{
+		{h.topics.Event, h.handleShelfEvent},      // shelf events
+		{h.topics.Status, h.handleShelfStatus},    // shelf status updates
+		{h.topics.CommandAck, h.handleCommandAck}, // command acknowledgements
 	}
-
-	// subscribe to shelf status updates
-	statusTopic := fmt.Sprintf("%s/+/status", h.topicPrefix)
-	if token := h.client.Subscribe(statusTopic, 1, h.handleShelfStatus); token.Wait() && token.Error() != nil {
-		return token.Error()
+	for _, sub := range subscriptions {
+		filter := h.topics.Subscription(sub.template)
+		if token := h.client.Subscribe(filter, h.qos, sub.handler); token.Wait() && token.Error() != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", filter, token.Error())
+		}
 	}
 
 	logger.Info("MQTT Handler connected and subscribed")
@@ -113,35 +142,150 @@ func (h *MQTTHandler) handleShelfEvent(client mqtt.Client, msg mqtt.Message) {
 		return
 	}
 
-	// handle the event with retry logic
-	h.retryService.Execute(func() error {
-		return h.processShelfEvent(&event)
-	})
+	if event.EventID == "" {
+		event.EventID = fallbackEventID(&event, msg.Payload())
+	}
+	// stamp events without a device timestamp so telemetry and verification agree on the detection time
+	if event.Timestamp <= 0 {
+		event.Timestamp = time.Now().UnixMilli()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	meta := event.guardMeta()
+	verdict, err := h.eventGuardService.Admit(ctx, meta)
+	if err != nil {
+		// prefer processing a possible duplicate over dropping events while the dedup store is unavailable
+		logger.Error(fmt.Sprintf("Failed to check shelf event %s for duplicates, processing it unguarded", event.EventID), err)
+		verdict = services.EventGuardAccepted
+	}
+	if verdict == services.EventGuardDuplicate {
+		logger.Info(fmt.Sprintf("Ignoring duplicate shelf event %s from shelf %s", event.EventID, event.ShelfID))
+		return
+	}
+
+	// any event proves the shelf is alive
+	h.recordHeartbeat(event.ShelfID, time.UnixMilli(event.Timestamp))
+
+	// persist sensor telemetry independently of the event outcome
+	if event.SensorData != nil {
+		h.recordSensorData(&event)
+	}
+
+	var processingErr error
+	if verdict == services.EventGuardAccepted {
+		// handle the event with retry logic
+		processingErr = h.retryService.Execute(func() error {
+			return h.processShelfEvent(&event)
+		})
+	} else {
+		logger.Info(fmt.Sprintf("Skipping %s %s event %s from shelf %s", verdict, event.EventType, event.EventID, event.ShelfID))
+	}
+
+	// processing with retries can outlast the context of the admission
+	completeCtx, completeCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer completeCancel()
+	if err := h.eventGuardService.Complete(completeCtx, meta, processingErr); err != nil {
+		logger.Error(fmt.Sprintf("Failed to record outcome of shelf event %s", event.EventID), err)
+	}
+}
+
+// fallbackEventID identifies an event the shelf sent without an ID. A redelivered QoS 1 message carries the
+// exact same payload, so events with a device timestamp or sequence are identified by the hash of the payload.
+// Without either, a repeated detection cannot be told apart from a redelivery, so the event gets an ID of its
+// own rather than being dropped as a duplicate.
+func fallbackEventID(event *ShelfEvent, payload []byte) string {
+	if event.Timestamp <= 0 && event.Sequence <= 0 {
+		return uuid.New().String()
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
 }
 
 func (h *MQTTHandler) processShelfEvent(event *ShelfEvent) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// events published while handling the shelf event are caused by it
+	ctx = events.WithTrace(ctx, event.EventID, event.EventID)
+
 	switch event.EventType {
 		case services.EventTypeMaterialDetected:
-			return h.inventoryService.HandleMaterialDetectedEvent(ctx, event.SlotID, event.MaterialBarcode)
+			return h.inventoryService.HandleMaterialDetectedEvent(ctx, event.SlotID, event.MaterialBarcode, event.weight(), time.UnixMilli(event.Timestamp))
 
 		case services.EventTypeMaterialRemoved:
 			return h.inventoryService.HandleMaterialRemovedEvent(ctx, event.SlotID, event.MaterialBarcode)
 
-		// case services.EventTypeSlotError:
-		// 	cmd := commands.HandleSlotErrorCommand{
-		// 		SlotID:    event.SlotID,
-		// 		ErrorType: string(entities.AlertTypeSlotError),
-		// 	}
-		// 	return h.handleSlotErrorHandler.Handle(ctx, cmd)
+		case services.EventTypeSlotError:
+			cmd := commands.HandleSlotErrorCommand{
+				SlotID:    event.SlotID,
+				ErrorType: event.ErrorType,
+				Details:   event.ErrorDetails,
+			}
+			return h.handleSlotErrorHandler.Handle(ctx, cmd)
 
 		default:
 			return fmt.Errorf("unknown event type: %s", event.EventType)
 	}
 }
 
+func (h *MQTTHandler) recordSensorData(event *ShelfEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reading := &entities.SensorReading{
+		ShelfID:     event.ShelfID,
+		SlotID:      event.SlotID,
+		Weight:      event.SensorData.Weight,
+		Temperature: event.SensorData.Temperature,
+		Humidity:    event.SensorData.Humidity,
+		LightLevel:  event.SensorData.LightLevel,
+		RecordedAt:  time.UnixMilli(event.Timestamp),
+	}
+
+	if err := h.telemetryService.RecordSensorReading(ctx, reading); err != nil {
+		logger.Error(fmt.Sprintf("Failed to record sensor data for shelf %s", event.ShelfID), err)
+	}
+}
+
+func (h *MQTTHandler) recordHeartbeat(shelfID string, at time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.inventoryService.RecordShelfHeartbeat(ctx, shelfID, at); err != nil {
+		logger.Error(fmt.Sprintf("Failed to record heartbeat for shelf %s", shelfID), err)
+	}
+}
+
+func (h *MQTTHandler) handleCommandAck(client mqtt.Client, msg mqtt.Message) {
+	var ack ShelfCommandAck
+	if err := json.Unmarshal(msg.Payload(), &ack); err != nil {
+		logger.Error("Failed to unmarshal shelf command acknowledgement", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if ack.ShelfID != "" {
+		h.recordHeartbeat(ack.ShelfID, time.Now())
+	}
+
+	domainAck := services.ShelfCommandAck{
+		CommandID: ack.CommandID,
+		ShelfID:   ack.ShelfID,
+		Success:   ack.Success,
+		Error:     ack.Error,
+	}
+	if ack.Timestamp > 0 {
+		domainAck.Timestamp = time.UnixMilli(ack.Timestamp)
+	}
+	if err := h.shelfCommandService.HandleAcknowledgement(ctx, domainAck); err != nil {
+		logger.Error(fmt.Sprintf("Failed to handle acknowledgement for shelf command %s", ack.CommandID), err)
+	}
+}
+
 func (h *MQTTHandler) handleShelfStatus(client mqtt.Client, msg mqtt.Message) {
 	var status ShelfStatus
 	if err := json.Unmarshal(msg.Payload(), &status); err != nil {
@@ -0,0 +1,70 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+// ShelfCommandMessage is the payload published to {prefix}/{shelf_id}/commands
+type ShelfCommandMessage struct {
+	CommandID   string                 `json:"command_id"` // correlation ID, echoed back in the acknowledgement
+	CommandType string                 `json:"command_type"`
+	ShelfID     string                 `json:"shelf_id"`
+	SlotID      string                 `json:"slot_id,omitempty"`
+	Params      map[string]interface{} `json:"params,omitempty"`
+	Timestamp   int64                  `json:"timestamp"` // unix milliseconds
+}
+
+// ShelfCommandAck is the payload shelves publish to {prefix}/{shelf_id}/commands/ack
+type ShelfCommandAck struct {
+	CommandID string `json:"command_id"`
+	ShelfID   string `json:"shelf_id"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+	Timestamp int64  `json:"timestamp"` // unix milliseconds
+}
+
+type ShelfCommandPublisher struct {
+	client mqtt.Client
+	topics Topics
+	qos    byte
+}
+
+var _ services.ShelfCommandPublisher = (*ShelfCommandPublisher)(nil)
+
+func NewShelfCommandPublisher(client mqtt.Client, topics Topics, qos byte) *ShelfCommandPublisher {
+	return &ShelfCommandPublisher{
+		client: client,
+		topics: topics,
+		qos:    qos,
+	}
+}
+
+func (p *ShelfCommandPublisher) PublishCommand(ctx context.Context, command *entities.ShelfCommand) error {
+	payload, err := json.Marshal(ShelfCommandMessage{
+		CommandID:   command.ID,
+		CommandType: string(command.Type),
+		ShelfID:     command.ShelfID,
+		SlotID:      command.SlotID,
+		Params:      command.Params,
+		Timestamp:   time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+
+	topic := p.topics.ForShelf(p.topics.Command, command.ShelfID)
+	token := p.client.Publish(topic, p.qos, false, payload)
+
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,94 @@
+package mqtt
+
+import (
+	"fmt"
+	"strings"
+
+	"WMS/services/inventory-service/internal/config"
+)
+
+// shelfIDPlaceholder is replaced by the shelf ID when publishing and by a single-level wildcard when subscribing
+const shelfIDPlaceholder = "{shelf_id}"
+
+const (
+	defaultEventTopic      = "{prefix}/{shelf_id}/events"
+	defaultStatusTopic     = "{prefix}/{shelf_id}/status"
+	defaultCommandTopic    = "{prefix}/{shelf_id}/commands"
+	defaultCommandAckTopic = "{prefix}/{shelf_id}/commands/ack"
+)
+
+// Topics describes the topic layout used to talk to the shelves.
+// Each topic is a template containing {shelf_id}, and may contain {prefix}.
+type Topics struct {
+	Event      string
+	Status     string
+	Command    string
+	CommandAck string
+
+	// SharedGroup, if set, turns every subscription into a $share/<group>/ subscription
+	// so replicas of the service split the shelf traffic instead of each receiving all of it
+	SharedGroup string
+}
+
+// NewTopics builds the topic layout from configuration, falling back to {prefix}/{shelf_id}/... for unset topics
+func NewTopics(cfg config.MQTTConfig) (Topics, error) {
+	prefix := strings.TrimSuffix(cfg.TopicPrefix, "/")
+	if prefix == "" {
+		prefix = defaultTopicPrefix
+	}
+
+	expand := func(template, fallback string) string {
+		if template == "" {
+			template = fallback
+		}
+		return strings.ReplaceAll(template, "{prefix}", prefix)
+	}
+
+	topics := Topics{
+		Event:       expand(cfg.EventTopic, defaultEventTopic),
+		Status:      expand(cfg.StatusTopic, defaultStatusTopic),
+		Command:     expand(cfg.CommandTopic, defaultCommandTopic),
+		CommandAck:  expand(cfg.CommandAckTopic, defaultCommandAckTopic),
+		SharedGroup: cfg.SharedSubscriptionGroup,
+	}
+
+	if err := topics.validate(); err != nil {
+		return Topics{}, err
+	}
+	return topics, nil
+}
+
+func (t Topics) validate() error {
+	for name, template := range map[string]string{
+		"event":       t.Event,
+		"status":      t.Status,
+		"command":     t.Command,
+		"command ack": t.CommandAck,
+	} {
+		if strings.Count(template, shelfIDPlaceholder) != 1 {
+			return fmt.Errorf("MQTT %s topic %q must contain %s exactly once", name, template, shelfIDPlaceholder)
+		}
+		if strings.ContainsAny(template, "+#") {
+			return fmt.Errorf("MQTT %s topic %q must not contain wildcards", name, template)
+		}
+	}
+
+	if strings.ContainsAny(t.SharedGroup, "/+#") {
+		return fmt.Errorf("MQTT shared subscription group %q must not contain '/', '+' or '#'", t.SharedGroup)
+	}
+	return nil
+}
+
+// ForShelf returns the concrete topic for a shelf
+func (t Topics) ForShelf(template, shelfID string) string {
+	return strings.Replace(template, shelfIDPlaceholder, shelfID, 1)
+}
+
+// Subscription returns the filter matching the topic for all shelves
+func (t Topics) Subscription(template string) string {
+	filter := strings.Replace(template, shelfIDPlaceholder, "+", 1)
+	if t.SharedGroup != "" {
+		return fmt.Sprintf("$share/%s/%s", t.SharedGroup, filter)
+	}
+	return filter
+}
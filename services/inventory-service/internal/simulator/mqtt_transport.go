@@ -0,0 +1,31 @@
+package simulator
+
+import (
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// MQTTTransport connects the simulated shelves to a real broker
+type MQTTTransport struct {
+	client paho.Client
+	qos    byte
+}
+
+var _ Transport = (*MQTTTransport)(nil)
+
+func NewMQTTTransport(client paho.Client, qos byte) *MQTTTransport {
+	return &MQTTTransport{client: client, qos: qos}
+}
+
+func (t *MQTTTransport) Publish(topic string, payload []byte) error {
+	token := t.client.Publish(topic, t.qos, false, payload)
+	token.Wait()
+	return token.Error()
+}
+
+func (t *MQTTTransport) Subscribe(topic string, handler func(topic string, payload []byte)) error {
+	token := t.client.Subscribe(topic, t.qos, func(_ paho.Client, msg paho.Message) {
+		handler(msg.Topic(), msg.Payload())
+	})
+	token.Wait()
+	return token.Error()
+}
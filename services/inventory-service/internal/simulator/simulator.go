@@ -0,0 +1,298 @@
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/infrastructure/database"
+	"WMS/services/inventory-service/internal/interfaces/mqtt"
+	"WMS/services/inventory-service/pkg/utils"
+	"WMS/services/inventory-service/pkg/utils/logger"
+)
+
+// simulatedErrorTypes are the faults injected at Config.ErrorRate
+var simulatedErrorTypes = []entities.SlotErrorType{
+	entities.SlotErrorTypeSensorFault,
+	entities.SlotErrorTypeJammed,
+	entities.SlotErrorTypeLEDFault,
+	entities.SlotErrorTypeRFIDReadFailure,
+	entities.SlotErrorTypeDoorOpen,
+}
+
+type Config struct {
+	ShelfCount        int           // shelves emulated, SHELF001 to SHELF<n> of the seed layout
+	HeartbeatInterval time.Duration // how often every shelf publishes an online status
+	PlacementDelay    time.Duration // time between a placement request and the material being detected
+	RemovalDelay      time.Duration // time between a removal light-up command and the material being removed
+
+	// Fault injection, as the probability per shelf and heartbeat
+	ErrorRate            float64
+	UnplannedRemovalRate float64
+
+	Seed int64
+}
+
+// Transport is the broker connection used by the simulated shelves
+type Transport interface {
+	Publish(topic string, payload []byte) error
+	Subscribe(topic string, handler func(topic string, payload []byte)) error
+}
+
+// inventoryEvent holds the fields the simulator needs from the inventory service's events
+type inventoryEvent struct {
+	EventType       string `json:"event_type"`
+	ShelfID         string `json:"shelf_id"`
+	SlotID          string `json:"slot_id"`
+	MaterialBarcode string `json:"material_barcode"`
+}
+
+type shelf struct {
+	id       string
+	number   int
+	sequence int64
+	contents map[string]string // slot ID -> barcode of the material the simulator placed there
+}
+
+// Simulator emulates smart shelves of the seed layout over MQTT
+type Simulator struct {
+	cfg       Config
+	transport Transport
+	topics    mqtt.Topics
+
+	mu      sync.Mutex
+	rand    *rand.Rand
+	shelves map[string]*shelf
+}
+
+func New(cfg Config, transport Transport, topics mqtt.Topics) *Simulator {
+	// shelves subscribe to their own topics, they never share them
+	topics.SharedGroup = ""
+
+	s := &Simulator{
+		cfg:       cfg,
+		transport: transport,
+		topics:    topics,
+		rand:      rand.New(rand.NewSource(cfg.Seed)),
+		shelves:   make(map[string]*shelf, cfg.ShelfCount),
+	}
+	for n := 1; n <= cfg.ShelfCount; n++ {
+		id := database.SeedShelfID(n)
+		s.shelves[id] = &shelf{id: id, number: n, contents: make(map[string]string)}
+	}
+	return s
+}
+
+// ShelfIDs returns the IDs of the simulated shelves in order
+func (s *Simulator) ShelfIDs() []string {
+	ids := make([]string, 0, len(s.shelves))
+	for id := range s.shelves {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Run subscribes every shelf to its commands and publishes heartbeats until the context is cancelled.
+// The shelves report themselves offline on the way out.
+func (s *Simulator) Run(ctx context.Context) error {
+	for _, id := range s.ShelfIDs() {
+		topic := s.topics.ForShelf(s.topics.Command, id)
+		if err := s.transport.Subscribe(topic, func(_ string, payload []byte) {
+			s.HandleCommand(ctx, payload)
+		}); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+
+	logger.Info(fmt.Sprintf("Simulating %d shelves", len(s.shelves)))
+
+	interval := s.cfg.HeartbeatInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.heartbeat()
+	for {
+		select {
+		case <-ticker.C:
+			s.heartbeat()
+			s.injectFaults()
+		case <-ctx.Done():
+			for _, id := range s.ShelfIDs() {
+				s.publishStatus(id, string(entities.ShelfStateOffline))
+			}
+			return nil
+		}
+	}
+}
+
+// HandleInventoryEvent reacts to an event published by the inventory service
+func (s *Simulator) HandleInventoryEvent(ctx context.Context, payload []byte) {
+	var event inventoryEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		logger.Error("Failed to unmarshal inventory event", err)
+		return
+	}
+
+	if event.EventType != services.EventTypePhysicalPlacementRequested || !s.simulates(event.ShelfID) {
+		return
+	}
+
+	s.after(ctx, s.cfg.PlacementDelay, func() {
+		s.placeMaterial(event.ShelfID, event.SlotID, event.MaterialBarcode)
+	})
+}
+
+// HandleCommand acknowledges a downlink command and carries out removals the worker was guided to
+func (s *Simulator) HandleCommand(ctx context.Context, payload []byte) {
+	var command mqtt.ShelfCommandMessage
+	if err := json.Unmarshal(payload, &command); err != nil {
+		logger.Error("Failed to unmarshal shelf command", err)
+		return
+	}
+	if !s.simulates(command.ShelfID) {
+		return
+	}
+
+	s.publish(s.topics.ForShelf(s.topics.CommandAck, command.ShelfID), mqtt.ShelfCommandAck{
+		CommandID: command.CommandID,
+		ShelfID:   command.ShelfID,
+		Success:   true,
+		Timestamp: time.Now().UnixMilli(),
+	})
+
+	if entities.ShelfCommandType(command.CommandType) == entities.ShelfCommandLightUpSlot &&
+		command.Params["purpose"] == string(entities.OperationTypeRemoval) {
+		s.after(ctx, s.cfg.RemovalDelay, func() {
+			s.removeMaterial(command.ShelfID, command.SlotID)
+		})
+	}
+}
+
+func (s *Simulator) simulates(shelfID string) bool {
+	_, ok := s.shelves[shelfID]
+	return ok
+}
+
+// after runs fn once the delay has passed, unless the simulator is stopped first
+func (s *Simulator) after(ctx context.Context, delay time.Duration, fn func()) {
+	time.AfterFunc(delay, func() {
+		if ctx.Err() == nil {
+			fn()
+		}
+	})
+}
+
+func (s *Simulator) placeMaterial(shelfID, slotID, barcode string) {
+	s.mu.Lock()
+	s.shelves[shelfID].contents[slotID] = barcode
+	s.mu.Unlock()
+
+	s.publishEvent(shelfID, mqtt.ShelfEvent{
+		SlotID:          slotID,
+		EventType:       services.EventTypeMaterialDetected,
+		MaterialBarcode: barcode,
+	})
+}
+
+func (s *Simulator) removeMaterial(shelfID, slotID string) {
+	s.mu.Lock()
+	barcode := s.shelves[shelfID].contents[slotID]
+	delete(s.shelves[shelfID].contents, slotID)
+	s.mu.Unlock()
+
+	s.publishEvent(shelfID, mqtt.ShelfEvent{
+		SlotID:          slotID,
+		EventType:       services.EventTypeMaterialRemoved,
+		MaterialBarcode: barcode,
+	})
+}
+
+func (s *Simulator) heartbeat() {
+	for _, id := range s.ShelfIDs() {
+		s.publishStatus(id, string(entities.ShelfStateOnline))
+	}
+}
+
+func (s *Simulator) injectFaults() {
+	for _, id := range s.ShelfIDs() {
+		s.mu.Lock()
+		injectError := s.rand.Float64() < s.cfg.ErrorRate
+		injectRemoval := s.rand.Float64() < s.cfg.UnplannedRemovalRate
+		errorType := simulatedErrorTypes[s.rand.Intn(len(simulatedErrorTypes))]
+		slotID := database.SeedSlotID(s.shelves[id].number, 1+s.rand.Intn(database.SeedShelfRows), 1+s.rand.Intn(database.SeedShelfColumns))
+		occupiedSlotID := s.randomOccupiedSlot(s.shelves[id])
+		s.mu.Unlock()
+
+		if injectError {
+			s.publishEvent(id, mqtt.ShelfEvent{
+				SlotID:       slotID,
+				EventType:    services.EventTypeSlotError,
+				ErrorType:    string(errorType),
+				ErrorDetails: map[string]interface{}{"simulated": true},
+			})
+		}
+
+		// only material the simulator placed itself can be taken out unannounced
+		if injectRemoval && occupiedSlotID != "" {
+			s.removeMaterial(id, occupiedSlotID)
+		}
+	}
+}
+
+// randomOccupiedSlot must be called with s.mu held
+func (s *Simulator) randomOccupiedSlot(sh *shelf) string {
+	if len(sh.contents) == 0 {
+		return ""
+	}
+	slotIDs := make([]string, 0, len(sh.contents))
+	for slotID := range sh.contents {
+		slotIDs = append(slotIDs, slotID)
+	}
+	sort.Strings(slotIDs)
+	return slotIDs[s.rand.Intn(len(slotIDs))]
+}
+
+func (s *Simulator) publishStatus(shelfID, status string) {
+	s.publish(s.topics.ForShelf(s.topics.Status, shelfID), mqtt.ShelfStatus{
+		ShelfID:   shelfID,
+		Status:    status,
+		Timestamp: time.Now().UnixMilli(),
+	})
+}
+
+// publishEvent stamps the event like shelf firmware does and publishes it.
+// Sequence numbers are assigned and published under the lock so they reach the broker in order.
+func (s *Simulator) publishEvent(shelfID string, event mqtt.ShelfEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh := s.shelves[shelfID]
+	sh.sequence++
+	event.EventID = utils.GenerateUUID()
+	event.Sequence = sh.sequence
+	event.ShelfID = shelfID
+	event.Timestamp = time.Now().UnixMilli()
+
+	s.publish(s.topics.ForShelf(s.topics.Event, shelfID), event)
+}
+
+func (s *Simulator) publish(topic string, message interface{}) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to marshal message for %s", topic), err)
+		return
+	}
+	if err := s.transport.Publish(topic, payload); err != nil {
+		logger.Error(fmt.Sprintf("Failed to publish to %s", topic), err)
+	}
+}
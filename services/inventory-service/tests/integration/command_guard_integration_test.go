@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+// guardCommandID keeps the dedup keys of test runs apart
+func guardCommandID() string {
+	return fmt.Sprintf("guard-command-%d", time.Now().UnixNano())
+}
+
+func TestCommandGuardService_AdmitsACommandOnce(t *testing.T) {
+	guard := services.NewCommandGuardService(newTestRedisClient(t), time.Minute)
+	ctx := context.Background()
+	commandID := guardCommandID()
+
+	verdict, err := guard.Admit(ctx, commandID)
+	assert.NoError(t, err)
+	assert.Equal(t, services.CommandGuardAccepted, verdict)
+
+	// a redelivery to another consumer after a rebalance
+	verdict, err = guard.Admit(ctx, commandID)
+	assert.NoError(t, err)
+	assert.Equal(t, services.CommandGuardInProgress, verdict)
+
+	assert.NoError(t, guard.Complete(ctx, commandID, false))
+
+	verdict, err = guard.Admit(ctx, commandID)
+	assert.NoError(t, err)
+	assert.Equal(t, services.CommandGuardHandled, verdict)
+}
+
+func TestCommandGuardService_ReleasesCommandsToRedeliver(t *testing.T) {
+	guard := services.NewCommandGuardService(newTestRedisClient(t), time.Minute)
+	ctx := context.Background()
+	commandID := guardCommandID()
+
+	verdict, err := guard.Admit(ctx, commandID)
+	assert.NoError(t, err)
+	assert.Equal(t, services.CommandGuardAccepted, verdict)
+	assert.NoError(t, guard.Complete(ctx, commandID, true))
+
+	verdict, err = guard.Admit(ctx, commandID)
+	assert.NoError(t, err)
+	assert.Equal(t, services.CommandGuardAccepted, verdict)
+}
+
+func TestCommandGuardService_ForgetsCommandsAfterTheTTL(t *testing.T) {
+	guard := services.NewCommandGuardService(newTestRedisClient(t), 100*time.Millisecond)
+	ctx := context.Background()
+	commandID := guardCommandID()
+
+	_, err := guard.Admit(ctx, commandID)
+	assert.NoError(t, err)
+	assert.NoError(t, guard.Complete(ctx, commandID, false))
+
+	time.Sleep(200 * time.Millisecond)
+	verdict, err := guard.Admit(ctx, commandID)
+	assert.NoError(t, err)
+	assert.Equal(t, services.CommandGuardAccepted, verdict)
+}
@@ -0,0 +1,173 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/infrastructure/database/repositories"
+)
+
+// newTestRedisClient connects to the Redis at TEST_REDIS_ADDR, localhost:6379 by default
+func newTestRedisClient(t *testing.T) *redis.Client {
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	redisClient := redis.NewClient(&redis.Options{Addr: addr})
+	if !assert.NoError(t, redisClient.Ping(context.Background()).Err()) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { redisClient.Close() })
+	return redisClient
+}
+
+func newEventGuardService(t *testing.T) *services.EventGuardService {
+	return services.NewEventGuardService(newTestRedisClient(t), repositories.NewSlotRepository(db), time.Minute, 2*time.Second)
+}
+
+// guardShelfID keeps the dedup and sequence keys of test runs apart
+func guardShelfID() string {
+	return fmt.Sprintf("guard-shelf-%d", time.Now().UnixNano())
+}
+
+func TestEventGuardService_AdmitsAnEventOnce(t *testing.T) {
+	guard := newEventGuardService(t)
+	ctx := context.Background()
+	meta := services.ShelfEventMeta{ShelfID: guardShelfID(), SlotID: "guard-slot-1", EventID: "event-1", Timestamp: time.Now()}
+
+	verdict, err := guard.Admit(ctx, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, services.EventGuardAccepted, verdict)
+
+	// a redelivery while the event is still being processed
+	verdict, err = guard.Admit(ctx, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, services.EventGuardDuplicate, verdict)
+
+	assert.NoError(t, guard.Complete(ctx, meta, nil))
+
+	verdict, err = guard.Admit(ctx, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, services.EventGuardDuplicate, verdict)
+}
+
+func TestEventGuardService_ReleasesFailedEvents(t *testing.T) {
+	guard := newEventGuardService(t)
+	ctx := context.Background()
+	meta := services.ShelfEventMeta{ShelfID: guardShelfID(), SlotID: "guard-slot-1", EventID: "event-1", Timestamp: time.Now()}
+
+	verdict, err := guard.Admit(ctx, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, services.EventGuardAccepted, verdict)
+
+	assert.NoError(t, guard.Complete(ctx, meta, errors.New("slot is locked")))
+
+	// the redelivery of a failed event is processed again
+	verdict, err = guard.Admit(ctx, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, services.EventGuardAccepted, verdict)
+}
+
+func TestEventGuardService_ExpiresClaimsAndSequences(t *testing.T) {
+	redisClient := newTestRedisClient(t)
+	guard := services.NewEventGuardService(redisClient, repositories.NewSlotRepository(db), time.Hour, 2*time.Second)
+	ctx := context.Background()
+	meta := services.ShelfEventMeta{ShelfID: guardShelfID(), SlotID: "guard-slot-1", EventID: "event-1", Sequence: 1, Timestamp: time.Now()}
+
+	verdict, err := guard.Admit(ctx, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, services.EventGuardAccepted, verdict)
+
+	// a replica that crashes while processing only holds the event back for a few minutes
+	claimTTL := redisClient.TTL(ctx, fmt.Sprintf("shelf_event:%s:%s", meta.ShelfID, meta.EventID)).Val()
+	assert.True(t, claimTTL > 0 && claimTTL <= 5*time.Minute, "claim TTL %s", claimTTL)
+	sequenceTTL := redisClient.TTL(ctx, fmt.Sprintf("shelf_event_seq:%s:%s", meta.ShelfID, meta.SlotID)).Val()
+	assert.True(t, sequenceTTL > 5*time.Minute && sequenceTTL <= time.Hour, "sequence TTL %s", sequenceTTL)
+
+	assert.NoError(t, guard.Complete(ctx, meta, nil))
+	doneTTL := redisClient.TTL(ctx, fmt.Sprintf("shelf_event:%s:%s", meta.ShelfID, meta.EventID)).Val()
+	assert.True(t, doneTTL > 5*time.Minute && doneTTL <= time.Hour, "dedup TTL %s", doneTTL)
+}
+
+func TestEventGuardService_OrdersEventsBySequence(t *testing.T) {
+	guard := newEventGuardService(t)
+	ctx := context.Background()
+	shelfID := guardShelfID()
+	start := time.Now()
+
+	tests := []struct {
+		name     string
+		eventID  string
+		slotID   string
+		sequence int64
+		at       time.Time
+		expect   services.EventGuardVerdict
+	}{
+		{name: "first event", eventID: "event-5", slotID: "guard-slot-1", sequence: 5, at: start, expect: services.EventGuardAccepted},
+		{name: "older sequence and timestamp", eventID: "event-4", slotID: "guard-slot-1", sequence: 4, at: start.Add(-time.Second), expect: services.EventGuardOutOfOrder},
+		{name: "older event of another slot", eventID: "event-3", slotID: "guard-slot-2", sequence: 3, at: start.Add(-2 * time.Second), expect: services.EventGuardAccepted},
+		{name: "newer sequence", eventID: "event-6", slotID: "guard-slot-1", sequence: 6, at: start.Add(time.Second), expect: services.EventGuardAccepted},
+		{name: "shelf restarted its numbering", eventID: "event-1", slotID: "guard-slot-1", sequence: 1, at: start.Add(time.Minute), expect: services.EventGuardAccepted},
+	}
+
+	for _, tt := range tests {
+		verdict, err := guard.Admit(ctx, services.ShelfEventMeta{ShelfID: shelfID, SlotID: tt.slotID, EventID: tt.eventID, Sequence: tt.sequence, Timestamp: tt.at})
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expect, verdict, tt.name)
+	}
+}
+
+func TestEventGuardService_RejectsEventsOlderThanTheSlot(t *testing.T) {
+	guard := newEventGuardService(t)
+	ctx := context.Background()
+
+	db.Exec("DELETE FROM slots WHERE id = ?", "guard-slot-2")
+	updatedAt := time.Now()
+	assert.NoError(t, repositories.NewSlotRepository(db).Create(ctx, &entities.Slot{
+		ID: "guard-slot-2", ShelfID: "guard-shelf", Row: 1, Column: 1, Status: entities.SlotStatusOccupied, UpdatedAt: updatedAt, Version: 5,
+	}))
+	shelfID := guardShelfID()
+
+	tests := []struct {
+		name   string
+		meta   services.ShelfEventMeta
+		expect services.EventGuardVerdict
+	}{
+		{
+			name:   "detection for an older slot version",
+			meta:   services.ShelfEventMeta{EventID: "event-1", SlotVersion: 4, Timestamp: updatedAt, ChangesSlotState: true},
+			expect: services.EventGuardStale,
+		},
+		{
+			name:   "detection from before the last slot change",
+			meta:   services.ShelfEventMeta{EventID: "event-2", Timestamp: updatedAt.Add(-time.Minute), ChangesSlotState: true},
+			expect: services.EventGuardStale,
+		},
+		{
+			name:   "detection for the current slot version",
+			meta:   services.ShelfEventMeta{EventID: "event-3", SlotVersion: 5, Timestamp: updatedAt, ChangesSlotState: true},
+			expect: services.EventGuardAccepted,
+		},
+		{
+			name:   "events that leave the slot state alone are not compared",
+			meta:   services.ShelfEventMeta{EventID: "event-4", Timestamp: updatedAt.Add(-time.Minute)},
+			expect: services.EventGuardAccepted,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.meta.ShelfID = shelfID
+		tt.meta.SlotID = "guard-slot-2"
+		verdict, err := guard.Admit(ctx, tt.meta)
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expect, verdict, tt.name)
+	}
+}
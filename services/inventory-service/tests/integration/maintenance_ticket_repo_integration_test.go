@@ -0,0 +1,58 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+	domainrepos "WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/internal/infrastructure/database/repositories"
+)
+
+func TestMaintenanceTicketRepository_GetActiveBySlotID(t *testing.T) {
+	repo := repositories.NewMaintenanceTicketRepository(db)
+	ctx := context.Background()
+
+	// Clean up previous test data to ensure isolation
+	db.Exec("DELETE FROM maintenance_tickets WHERE shelf_id = ?", "maintenance-shelf-1")
+
+	completedAt := time.Now().Add(-time.Hour)
+	completed := &entities.MaintenanceTicket{
+		ID:          "maintenance-ticket-1",
+		SlotID:      "maintenance-slot-1",
+		ShelfID:     "maintenance-shelf-1",
+		Reason:      "jammed",
+		Status:      entities.MaintenanceTicketStatusCompleted,
+		CreatedAt:   time.Now().Add(-2 * time.Hour),
+		CompletedAt: &completedAt,
+		UpdatedAt:   completedAt,
+	}
+	open := &entities.MaintenanceTicket{
+		ID:               "maintenance-ticket-2",
+		SlotID:           "maintenance-slot-1",
+		ShelfID:          "maintenance-shelf-1",
+		Reason:           "sensor_fault",
+		Status:           entities.MaintenanceTicketStatusOpen,
+		SuggestedSlotIDs: []string{"maintenance-slot-2", "maintenance-slot-3"},
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+	assert.NoError(t, repo.Create(ctx, completed))
+	assert.NoError(t, repo.Create(ctx, open))
+
+	active, err := repo.GetActiveBySlotID(ctx, "maintenance-slot-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "maintenance-ticket-2", active.ID)
+	assert.Equal(t, []string{"maintenance-slot-2", "maintenance-slot-3"}, []string(active.SuggestedSlotIDs))
+
+	tickets, err := repo.List(ctx, domainrepos.MaintenanceTicketFilter{
+		ShelfID: "maintenance-shelf-1",
+		Status:  entities.MaintenanceTicketStatusCompleted,
+		Limit:   10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, tickets, 1)
+	assert.Equal(t, "maintenance-ticket-1", tickets[0].ID)
+}
@@ -12,6 +12,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"WMS/services/inventory-service/internal/domain/entities"
+	domainrepositories "WMS/services/inventory-service/internal/domain/repositories"
 	"WMS/services/inventory-service/internal/infrastructure/database/repositories"
 )
 
@@ -34,7 +35,7 @@ func TestMain(m *testing.M) {
 	}
 
 	// Migrate the schema
-	err = db.AutoMigrate(&entities.Material{}, &entities.Slot{}, &entities.Operation{}, &entities.Alert{}, &entities.FailedEvent{})
+	err = db.AutoMigrate(&entities.Material{}, &entities.Slot{}, &entities.Operation{}, &entities.OperationTransition{}, &entities.Alert{}, &entities.FailedEvent{}, &entities.SensorReading{}, &entities.SensorReadingRollup{}, &entities.MaintenanceTicket{}, &entities.ShelfState{}, &entities.ShelfCommand{})
 	if err != nil {
 		log.Fatalf("Failed to auto migrate database: %v", err)
 	}
@@ -121,3 +122,68 @@ func TestMaterialRepository_Update(t *testing.T) {
 	assert.NotNil(t, foundMaterial)
 	assert.Equal(t, entities.MaterialStatusInUse, foundMaterial.Status)
 }
+
+func TestMaterialRepository_Search(t *testing.T) {
+	repo := repositories.NewMaterialRepository(db)
+	ctx := context.Background()
+
+	materials := []*entities.Material{
+		{ID: "search-material-1", Barcode: "SRCH-0001", Name: "Graphics Card", Type: "search-gpu", Status: entities.MaterialStatusAvailable},
+		{ID: "search-material-2", Barcode: "SRCH-0002", Name: "Graphics Adapter", Type: "search-gpu", Status: entities.MaterialStatusInUse},
+		{ID: "search-material-3", Barcode: "SRCH-0003", Name: "Cooling Fan", Type: "search-fan", Status: entities.MaterialStatusAvailable},
+	}
+	for _, material := range materials {
+		material.CreatedAt = time.Now()
+		material.UpdatedAt = time.Now()
+		assert.NoError(t, repo.Create(ctx, material))
+	}
+	materialID := "search-material-2"
+	assert.NoError(t, db.Create(&entities.Slot{
+		ID:         "search-slot-1",
+		ShelfID:    "search-shelf-1",
+		Row:        1,
+		Column:     1,
+		Status:     entities.SlotStatusOccupied,
+		MaterialID: &materialID,
+		UpdatedAt:  time.Now(),
+		Version:    1,
+	}).Error)
+
+	// whole words match through the full-text index, the exact barcode ranks first
+	found, err := repo.Search(ctx, domainrepositories.MaterialSearchFilter{Query: "graphics"}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, found, 2)
+
+	found, err = repo.Search(ctx, domainrepositories.MaterialSearchFilter{Query: "SRCH-0003"}, 10, 0)
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, found) {
+		assert.Equal(t, "search-material-3", found[0].ID)
+	}
+
+	// fragments match too, filters narrow the result
+	filter := domainrepositories.MaterialSearchFilter{Query: "SRCH-000", Type: "search-gpu"}
+	found, err = repo.Search(ctx, filter, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, found, 2)
+
+	count, err := repo.CountSearch(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// the type facet ignores the type filter
+	facets, err := repo.SearchFacets(ctx, filter)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domainrepositories.MaterialFacetCount{{Value: "search-gpu", Count: 2}, {Value: "search-fan", Count: 1}}, facets.Types)
+	assert.ElementsMatch(t, []domainrepositories.MaterialFacetCount{{Value: "available", Count: 1}, {Value: "in_use", Count: 1}}, facets.Statuses)
+	assert.Equal(t, []domainrepositories.MaterialFacetCount{{Value: "search-shelf-1", Count: 1}}, facets.Shelves)
+
+	found, err = repo.Search(ctx, domainrepositories.MaterialSearchFilter{Query: "SRCH-000", ShelfID: "search-shelf-1"}, 10, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, found, 1) {
+		assert.Equal(t, "search-material-2", found[0].ID)
+	}
+
+	found, err = repo.Search(ctx, domainrepositories.MaterialSearchFilter{Query: "no-such-material"}, 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+}
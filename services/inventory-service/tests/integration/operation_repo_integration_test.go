@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"WMS/services/inventory-service/internal/domain/entities"
+	domainrepositories "WMS/services/inventory-service/internal/domain/repositories"
 	"WMS/services/inventory-service/internal/infrastructure/database/repositories"
 )
 
@@ -118,3 +119,112 @@ func TestOperationRepository_List(t *testing.T) {
 	assert.True(t, foundOp2)
 	assert.True(t, foundOp3)
 }
+
+func TestOperationRepository_FindWithCursor(t *testing.T) {
+	repo := repositories.NewOperationRepository(db)
+	ctx := context.Background()
+
+	// Clean up previous test data to ensure isolation
+	db.Exec("DELETE FROM operations WHERE shelf_id = ?", "OP-SHELF-PAGE")
+
+	material := &entities.Material{ID: "op-mat-page", Barcode: "OP-BARCODE-PAGE", Name: "Paging Material", Type: "TEST", Status: entities.MaterialStatusAvailable, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	db.Create(material)
+	slot := &entities.Slot{ID: "op-slot-page", ShelfID: "OP-SHELF-PAGE", Row: 1, Column: 1, Status: entities.SlotStatusEmpty, UpdatedAt: time.Now(), Version: 1}
+	db.Create(slot)
+
+	// two operations share a timestamp, the ID keeps their order stable across pages
+	base := time.Now().Truncate(time.Microsecond)
+	timestamps := []time.Time{base, base, base.Add(time.Second), base.Add(2 * time.Second), base.Add(3 * time.Second)}
+	for i, timestamp := range timestamps {
+		status := entities.OperationStatusCompleted
+		if i == 4 {
+			status = entities.OperationStatusFailed
+		}
+		assert.NoError(t, repo.Create(ctx, &entities.Operation{
+			ID:         "op-page-" + string(rune('a'+i)),
+			Type:       entities.OperationTypePlacement,
+			MaterialID: material.ID,
+			SlotID:     slot.ID,
+			OperatorID: "operator-page",
+			ShelfID:    slot.ShelfID,
+			Timestamp:  timestamp,
+			Status:     status,
+		}))
+	}
+
+	filter := domainrepositories.OperationFilter{ShelfID: "OP-SHELF-PAGE", Status: entities.OperationStatusCompleted}
+	count, err := repo.Count(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	options := domainrepositories.OperationListOptions{
+		Filter:     filter,
+		SortBy:     domainrepositories.OperationSortByTimestamp,
+		Descending: true,
+		Limit:      3,
+	}
+	firstPage, err := repo.Find(ctx, options)
+	assert.NoError(t, err)
+	if !assert.Equal(t, 3, len(firstPage)) {
+		return
+	}
+
+	last := firstPage[len(firstPage)-1]
+	options.After = &domainrepositories.OperationKey{SortValue: options.SortBy.ValueOf(last), ID: last.ID}
+	secondPage, err := repo.Find(ctx, options)
+	assert.NoError(t, err)
+
+	ids := make([]string, 0)
+	for _, operation := range append(firstPage, secondPage...) {
+		ids = append(ids, operation.ID)
+	}
+	assert.Equal(t, []string{"op-page-d", "op-page-c", "op-page-b", "op-page-a"}, ids)
+}
+
+func TestOperationRepository_TransitionStatusWithTx(t *testing.T) {
+	repo := repositories.NewOperationRepository(db)
+	ctx := context.Background()
+
+	// Clean up previous test data to ensure isolation
+	db.Exec("DELETE FROM operations WHERE id = ?", "transition-operation-1")
+	db.Exec("DELETE FROM slots WHERE id = ?", "transition-slot-1")
+	db.Exec("DELETE FROM materials WHERE id = ?", "transition-mat-1")
+
+	material := &entities.Material{ID: "transition-mat-1", Barcode: "TRANSITION-BARCODE-001", Name: "Transition Test Material", Type: "TEST", Status: entities.MaterialStatusInUse, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	db.Create(material)
+	slot := &entities.Slot{ID: "transition-slot-1", ShelfID: "TRANSITION-SHELF-1", Row: 1, Column: 1, Status: entities.SlotStatusOccupied, MaterialID: &material.ID, UpdatedAt: time.Now(), Version: 1}
+	db.Create(slot)
+
+	createdAt := time.Now().Add(-time.Minute).Truncate(time.Microsecond)
+	operation := &entities.Operation{
+		ID:         "transition-operation-1",
+		Type:       entities.OperationTypePlacement,
+		MaterialID: material.ID,
+		SlotID:     slot.ID,
+		OperatorID: "operator-1",
+		ShelfID:    slot.ShelfID,
+		Timestamp:  createdAt,
+		Status:     entities.OperationStatusPendingPhysicalConfirmation,
+	}
+	assert.NoError(t, repo.Create(ctx, operation))
+
+	tx, err := repo.BeginTx(ctx)
+	assert.NoError(t, err)
+	transitioned, err := repo.TransitionStatusWithTx(ctx, tx, operation.ID, entities.OperationStatusPendingPhysicalConfirmation, entities.OperationStatusCompleted)
+	assert.NoError(t, err)
+	assert.True(t, transitioned)
+	assert.NoError(t, tx.Commit().Error)
+
+	// a timeout that read the operation before the confirmation does not overwrite it
+	tx, err = repo.BeginTx(ctx)
+	assert.NoError(t, err)
+	transitioned, err = repo.TransitionStatusWithTx(ctx, tx, operation.ID, entities.OperationStatusPendingPhysicalConfirmation, entities.OperationStatusFailed)
+	assert.NoError(t, err)
+	assert.False(t, transitioned)
+	assert.NoError(t, tx.Commit().Error)
+
+	stored, err := repo.GetByID(ctx, operation.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OperationStatusCompleted, stored.Status)
+	assert.True(t, createdAt.Equal(stored.Timestamp))
+}
@@ -0,0 +1,64 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/infrastructure/database/repositories"
+)
+
+func TestOperationTransitionRepository_Timeline(t *testing.T) {
+	repo := repositories.NewOperationTransitionRepository(db)
+	ctx := context.Background()
+
+	// Clean up previous test data to ensure isolation
+	db.Exec("DELETE FROM operation_transitions WHERE operation_id LIKE ?", "transition-op-%")
+
+	now := time.Now()
+	statuses := []entities.OperationStatus{
+		entities.OperationStatusRequested,
+		entities.OperationStatusPendingPhysicalConfirmation,
+		entities.OperationStatusCompleted,
+	}
+	var from entities.OperationStatus
+	for _, status := range statuses {
+		tx := db.Begin()
+		err := repo.CreateWithTx(ctx, tx, &entities.OperationTransition{
+			OperationID:   "transition-op-1",
+			OperationType: entities.OperationTypePlacement,
+			FromStatus:    from,
+			ToStatus:      status,
+			Actor:         "operator-1",
+			MaterialID:    "transition-mat-1",
+			SlotID:        "transition-slot-1",
+			ShelfID:       "transition-shelf-1",
+			// same timestamp on purpose, the log order must not depend on it
+			OccurredAt: now,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, tx.Commit().Error)
+		from = status
+	}
+
+	timeline, err := repo.GetByOperationID(ctx, "transition-op-1")
+	assert.NoError(t, err)
+	if assert.Equal(t, 3, len(timeline)) {
+		for i, transition := range timeline {
+			assert.Equal(t, statuses[i], transition.ToStatus)
+		}
+		assert.Empty(t, timeline[0].FromStatus)
+
+		after, err := repo.ListAfter(ctx, timeline[0].ID, 10)
+		assert.NoError(t, err)
+		ids := make([]int64, 0)
+		for _, transition := range after {
+			if transition.OperationID == "transition-op-1" {
+				ids = append(ids, transition.ID)
+			}
+		}
+		assert.Equal(t, []int64{timeline[1].ID, timeline[2].ID}, ids)
+	}
+}
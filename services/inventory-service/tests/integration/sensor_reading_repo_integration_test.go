@@ -0,0 +1,158 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+	domainrepos "WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/internal/infrastructure/database/repositories"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestSensorReadingRepository_ListReadings(t *testing.T) {
+	repo := repositories.NewSensorReadingRepository(db)
+	ctx := context.Background()
+
+	// Clean up previous test data to ensure isolation
+	db.Exec("DELETE FROM sensor_readings WHERE shelf_id = ?", "telemetry-shelf-1")
+
+	base := time.Now().Truncate(time.Minute).Add(-time.Hour)
+	for i := 0; i < 3; i++ {
+		err := repo.Create(ctx, &entities.SensorReading{
+			ID:          "telemetry-reading-1-" + string(rune('a'+i)),
+			ShelfID:     "telemetry-shelf-1",
+			SlotID:      "telemetry-slot-1",
+			Weight:      floatPtr(100 + float64(i)),
+			Temperature: floatPtr(22.5),
+			RecordedAt:  base.Add(time.Duration(i) * time.Minute),
+			CreatedAt:   time.Now(),
+		})
+		assert.NoError(t, err)
+	}
+
+	readings, err := repo.ListReadings(ctx, domainrepos.SensorReadingFilter{
+		ShelfID: "telemetry-shelf-1",
+		SlotID:  "telemetry-slot-1",
+		From:    base,
+		To:      base.Add(2 * time.Minute),
+		Limit:   10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, readings, 2) // upper bound is exclusive
+	assert.Equal(t, 100.0, *readings[0].Weight)
+}
+
+func TestSensorReadingRepository_ListRollups_Minute(t *testing.T) {
+	repo := repositories.NewSensorReadingRepository(db)
+	ctx := context.Background()
+
+	db.Exec("DELETE FROM sensor_readings WHERE shelf_id = ?", "telemetry-shelf-2")
+
+	base := time.Now().Truncate(time.Minute).Add(-time.Hour)
+	weights := []float64{10, 20, 30}
+	for i, w := range weights {
+		err := repo.Create(ctx, &entities.SensorReading{
+			ID:         "telemetry-reading-2-" + string(rune('a'+i)),
+			ShelfID:    "telemetry-shelf-2",
+			SlotID:     "telemetry-slot-2",
+			Weight:     floatPtr(w),
+			RecordedAt: base.Add(time.Duration(i*10) * time.Second),
+			CreatedAt:  time.Now(),
+		})
+		assert.NoError(t, err)
+	}
+
+	buckets, err := repo.ListRollups(ctx, domainrepos.SensorReadingFilter{
+		ShelfID:    "telemetry-shelf-2",
+		From:       base,
+		To:         base.Add(time.Minute),
+		Resolution: entities.SensorResolutionMinute,
+		Limit:      10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, buckets, 1)
+	assert.Equal(t, int64(3), buckets[0].SampleCount)
+	assert.Equal(t, 20.0, *buckets[0].AvgWeight)
+	assert.Equal(t, 10.0, *buckets[0].MinWeight)
+	assert.Equal(t, 30.0, *buckets[0].MaxWeight)
+}
+
+func TestSensorReadingRepository_Rollup_Hour(t *testing.T) {
+	repo := repositories.NewSensorReadingRepository(db)
+	ctx := context.Background()
+
+	db.Exec("DELETE FROM sensor_readings WHERE shelf_id = ?", "telemetry-shelf-3")
+	db.Exec("DELETE FROM sensor_reading_rollups WHERE shelf_id = ?", "telemetry-shelf-3")
+
+	hour := time.Now().Truncate(time.Hour).Add(-2 * time.Hour)
+	for i, humidity := range []float64{40, 60} {
+		err := repo.Create(ctx, &entities.SensorReading{
+			ID:         "telemetry-reading-3-" + string(rune('a'+i)),
+			ShelfID:    "telemetry-shelf-3",
+			SlotID:     "telemetry-slot-3",
+			Humidity:   floatPtr(humidity),
+			RecordedAt: hour.Add(time.Duration(i*20) * time.Minute),
+			CreatedAt:  time.Now(),
+		})
+		assert.NoError(t, err)
+	}
+
+	// running the rollup twice must not duplicate buckets
+	assert.NoError(t, repo.Rollup(ctx, entities.SensorResolutionHour, hour, hour.Add(time.Hour)))
+	assert.NoError(t, repo.Rollup(ctx, entities.SensorResolutionHour, hour, hour.Add(time.Hour)))
+
+	buckets, err := repo.ListRollups(ctx, domainrepos.SensorReadingFilter{
+		ShelfID:    "telemetry-shelf-3",
+		SlotID:     "telemetry-slot-3",
+		From:       hour,
+		To:         hour.Add(time.Hour),
+		Resolution: entities.SensorResolutionHour,
+		Limit:      10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, buckets, 1)
+	assert.Equal(t, int64(2), buckets[0].SampleCount)
+	assert.Equal(t, 50.0, *buckets[0].AvgHumidity)
+	assert.Nil(t, buckets[0].AvgWeight)
+}
+
+func TestSensorReadingRepository_Rollup_UnsupportedResolution(t *testing.T) {
+	repo := repositories.NewSensorReadingRepository(db)
+
+	err := repo.Rollup(context.Background(), entities.SensorResolutionMinute, time.Now().Add(-time.Hour), time.Now())
+	assert.Error(t, err)
+}
+
+func TestSensorReadingRepository_GetLatestWeightBySlotID(t *testing.T) {
+	repo := repositories.NewSensorReadingRepository(db)
+	ctx := context.Background()
+
+	db.Exec("DELETE FROM sensor_readings WHERE shelf_id = ?", "telemetry-shelf-4")
+
+	base := time.Now().Truncate(time.Minute).Add(-time.Hour)
+	readings := []*entities.SensorReading{
+		{ID: "telemetry-reading-4-a", Weight: floatPtr(50), RecordedAt: base},
+		{ID: "telemetry-reading-4-b", Temperature: floatPtr(21), RecordedAt: base.Add(time.Minute)}, // no weight
+		{ID: "telemetry-reading-4-c", Weight: floatPtr(170), RecordedAt: base.Add(2 * time.Minute)},
+	}
+	for _, reading := range readings {
+		reading.ShelfID = "telemetry-shelf-4"
+		reading.SlotID = "telemetry-slot-4"
+		reading.CreatedAt = time.Now()
+		assert.NoError(t, repo.Create(ctx, reading))
+	}
+
+	latest, err := repo.GetLatestWeightBySlotID(ctx, "telemetry-slot-4", base.Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "telemetry-reading-4-a", latest.ID)
+	assert.Equal(t, 50.0, *latest.Weight)
+
+	_, err = repo.GetLatestWeightBySlotID(ctx, "telemetry-slot-4", base)
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/infrastructure/database/repositories"
+)
+
+func TestShelfCommandRepository_MarkSent(t *testing.T) {
+	repo := repositories.NewShelfCommandRepository(db)
+	ctx := context.Background()
+
+	// Clean up previous test data to ensure isolation
+	db.Exec("DELETE FROM shelf_commands WHERE id LIKE ?", "mark-sent-%")
+
+	now := time.Now()
+	pending := &entities.ShelfCommand{ID: "mark-sent-1", ShelfID: "SHELF001", Type: entities.ShelfCommandRescan, Status: entities.ShelfCommandStatusPending, CreatedAt: now, UpdatedAt: now}
+	assert.NoError(t, repo.Create(ctx, pending))
+
+	marked, err := repo.MarkSent(ctx, pending.ID, now)
+	assert.NoError(t, err)
+	assert.True(t, marked)
+	stored, err := repo.GetByID(ctx, pending.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ShelfCommandStatusSent, stored.Status)
+	assert.NotNil(t, stored.SentAt)
+
+	// an acknowledgement that arrived first is kept
+	acknowledged := &entities.ShelfCommand{ID: "mark-sent-2", ShelfID: "SHELF001", Type: entities.ShelfCommandRescan, Status: entities.ShelfCommandStatusAcknowledged, AcknowledgedAt: &now, CreatedAt: now, UpdatedAt: now}
+	assert.NoError(t, repo.Create(ctx, acknowledged))
+
+	marked, err = repo.MarkSent(ctx, acknowledged.ID, now)
+	assert.NoError(t, err)
+	assert.False(t, marked)
+	stored, err = repo.GetByID(ctx, acknowledged.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ShelfCommandStatusAcknowledged, stored.Status)
+	assert.NotNil(t, stored.AcknowledgedAt)
+}
@@ -0,0 +1,52 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/infrastructure/database/repositories"
+)
+
+func TestShelfStateRepository_ListStale(t *testing.T) {
+	repo := repositories.NewShelfStateRepository(db)
+	ctx := context.Background()
+
+	// Clean up previous test data to ensure isolation
+	db.Exec("DELETE FROM shelf_states WHERE shelf_id LIKE ?", "state-shelf-%")
+
+	now := time.Now()
+	states := []*entities.ShelfState{
+		{ShelfID: "state-shelf-1", Status: entities.ShelfStateOnline, LastHeartbeatAt: now.Add(-5 * time.Minute)},
+		{ShelfID: "state-shelf-2", Status: entities.ShelfStateOnline, LastHeartbeatAt: now},
+		{ShelfID: "state-shelf-3", Status: entities.ShelfStateMaintenance, LastHeartbeatAt: now.Add(-5 * time.Minute)},
+	}
+	for _, state := range states {
+		state.StatusChangedAt = state.LastHeartbeatAt
+		state.UpdatedAt = now
+		assert.NoError(t, repo.Save(ctx, state))
+	}
+
+	stale, err := repo.ListStale(ctx, now.Add(-time.Minute))
+	assert.NoError(t, err)
+	ids := make([]string, 0)
+	for _, state := range stale {
+		ids = append(ids, state.ShelfID)
+	}
+	assert.Contains(t, ids, "state-shelf-1")
+	assert.NotContains(t, ids, "state-shelf-2")
+	assert.NotContains(t, ids, "state-shelf-3") // shelves in maintenance are not expected to send heartbeats
+
+	// the heartbeat only moves forward
+	assert.NoError(t, repo.TouchHeartbeat(ctx, "state-shelf-1", now))
+	assert.NoError(t, repo.TouchHeartbeat(ctx, "state-shelf-1", now.Add(-time.Hour)))
+	state, err := repo.GetByShelfID(ctx, "state-shelf-1")
+	assert.NoError(t, err)
+	assert.WithinDuration(t, now, state.LastHeartbeatAt, time.Millisecond)
+
+	missing, err := repo.GetByShelfID(ctx, "state-shelf-unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,109 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/infrastructure/database/repositories"
+	"WMS/services/inventory-service/internal/infrastructure/messaging"
+	apperrors "WMS/services/inventory-service/pkg/errors"
+)
+
+// discardShelfCommands stands in for the MQTT publisher, the shelves are not part of these tests
+type discardShelfCommands struct{}
+
+func (discardShelfCommands) PublishCommand(ctx context.Context, command *entities.ShelfCommand) error {
+	return nil
+}
+
+func newMaintenanceService(t *testing.T) (*services.InventoryService, *services.LockService) {
+	lockService := services.NewLockService(newTestRedisClient(t))
+	inventoryService := services.NewInventoryService(
+		repositories.NewMaterialRepository(db),
+		repositories.NewSlotRepository(db),
+		repositories.NewOperationRepository(db),
+		repositories.NewOperationTransitionRepository(db),
+		repositories.NewAlertRepository(db),
+		lockService,
+		services.NewEventService(messaging.NewMemoryBus(), "inventory_events", nil),
+		nil, nil, nil, nil, nil, nil,
+		repositories.NewMaintenanceTicketRepository(db),
+		nil,
+		services.NewShelfCommandService(repositories.NewShelfCommandRepository(db), discardShelfCommands{}),
+		nil,
+		nil,
+	)
+	return inventoryService, lockService
+}
+
+func TestSlotMaintenance_TakesASlotOutOfServiceAndBack(t *testing.T) {
+	inventoryService, _ := newMaintenanceService(t)
+	slotRepo := repositories.NewSlotRepository(db)
+	ctx := context.Background()
+
+	// Clean up previous test data to ensure isolation
+	db.Exec("DELETE FROM slots WHERE shelf_id = ?", "maintenance-shelf-2")
+	db.Exec("DELETE FROM maintenance_tickets WHERE shelf_id = ?", "maintenance-shelf-2")
+
+	materialID := "maintenance-material-1"
+	assert.NoError(t, slotRepo.Create(ctx, &entities.Slot{ID: "maintenance-slot-4", ShelfID: "maintenance-shelf-2", Row: 1, Column: 1, Status: entities.SlotStatusOccupied, MaterialID: &materialID, UpdatedAt: time.Now(), Version: 1}))
+	assert.NoError(t, slotRepo.Create(ctx, &entities.Slot{ID: "maintenance-slot-5", ShelfID: "maintenance-shelf-2", Row: 1, Column: 2, Status: entities.SlotStatusEmpty, UpdatedAt: time.Now(), Version: 1}))
+
+	ticket, err := inventoryService.StartSlotMaintenance(ctx, services.StartSlotMaintenanceParams{SlotID: "maintenance-slot-4", Reason: "jammed", Assignee: "tech-1"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, entities.MaintenanceTicketStatusInProgress, ticket.Status)
+	assert.Equal(t, []string{"maintenance-slot-5"}, []string(ticket.SuggestedSlotIDs))
+
+	slot, err := slotRepo.GetByID(ctx, "maintenance-slot-4")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.SlotStatusMaintenance, slot.Status)
+
+	// starting again by the same assignee keeps the ticket
+	again, err := inventoryService.StartSlotMaintenance(ctx, services.StartSlotMaintenanceParams{SlotID: "maintenance-slot-4", Assignee: "tech-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, ticket.ID, again.ID)
+
+	completed, err := inventoryService.CompleteSlotMaintenance(ctx, services.CompleteSlotMaintenanceParams{SlotID: "maintenance-slot-4", OperatorID: "tech-1", Notes: "cleared"})
+	assert.NoError(t, err)
+	assert.Equal(t, entities.MaintenanceTicketStatusCompleted, completed.Status)
+
+	// the material was left in the slot
+	slot, err = slotRepo.GetByID(ctx, "maintenance-slot-4")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.SlotStatusOccupied, slot.Status)
+}
+
+func TestSlotMaintenance_RespectsTheShelfLock(t *testing.T) {
+	inventoryService, lockService := newMaintenanceService(t)
+	slotRepo := repositories.NewSlotRepository(db)
+	ctx := context.Background()
+
+	// Clean up previous test data to ensure isolation
+	db.Exec("DELETE FROM slots WHERE shelf_id = ?", "maintenance-shelf-3")
+	db.Exec("DELETE FROM maintenance_tickets WHERE shelf_id = ?", "maintenance-shelf-3")
+	assert.NoError(t, slotRepo.Create(ctx, &entities.Slot{ID: "maintenance-slot-6", ShelfID: "maintenance-shelf-3", Row: 1, Column: 1, Status: entities.SlotStatusEmpty, UpdatedAt: time.Now(), Version: 1}))
+
+	// a placement on the same shelf is in progress
+	unlock, err := lockService.AcquireLock(ctx, "shelf:maintenance-shelf-3", 30*time.Second)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = inventoryService.StartSlotMaintenance(ctx, services.StartSlotMaintenanceParams{SlotID: "maintenance-slot-6", Assignee: "tech-1"})
+	var conflict *apperrors.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+
+	slot, err := slotRepo.GetByID(ctx, "maintenance-slot-6")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.SlotStatusEmpty, slot.Status)
+
+	unlock()
+	_, err = inventoryService.StartSlotMaintenance(ctx, services.StartSlotMaintenanceParams{SlotID: "maintenance-slot-6", Assignee: "tech-1"})
+	assert.NoError(t, err)
+}
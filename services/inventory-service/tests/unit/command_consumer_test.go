@@ -0,0 +1,244 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"WMS/shared/commands"
+	appcommands "WMS/services/inventory-service/internal/application/commands"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/infrastructure/messaging"
+	"WMS/services/inventory-service/internal/interfaces/consumer"
+)
+
+const commandTopic = "inventory_commands"
+
+// MockSlotRepository is a mock type for the SlotRepository
+type MockSlotRepository struct {
+	mock.Mock
+}
+
+func (m *MockSlotRepository) Create(ctx context.Context, slot *entities.Slot) error {
+	return m.Called(ctx, slot).Error(0)
+}
+
+func (m *MockSlotRepository) GetByID(ctx context.Context, id string) (*entities.Slot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Slot), args.Error(1)
+}
+
+func (m *MockSlotRepository) GetByShelfID(ctx context.Context, shelfID string) ([]*entities.Slot, error) {
+	args := m.Called(ctx, shelfID)
+	return args.Get(0).([]*entities.Slot), args.Error(1)
+}
+
+func (m *MockSlotRepository) ListShelfIDs(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSlotRepository) Update(ctx context.Context, slot *entities.Slot) error {
+	return m.Called(ctx, slot).Error(0)
+}
+
+func (m *MockSlotRepository) UpdateWithTx(ctx context.Context, tx *gorm.DB, slot *entities.Slot) error {
+	return m.Called(ctx, tx, slot).Error(0)
+}
+
+func (m *MockSlotRepository) BeginTx(ctx context.Context) (*gorm.DB, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*gorm.DB), args.Error(1)
+}
+
+func (m *MockSlotRepository) List(ctx context.Context, limit, offset int) ([]*entities.Slot, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*entities.Slot), args.Error(1)
+}
+
+func (m *MockSlotRepository) GetEmptySlotsByShelf(ctx context.Context, shelfID string) ([]*entities.Slot, error) {
+	args := m.Called(ctx, shelfID)
+	return args.Get(0).([]*entities.Slot), args.Error(1)
+}
+
+func (m *MockSlotRepository) GetByMaterialID(ctx context.Context, materialID string) (*entities.Slot, error) {
+	args := m.Called(ctx, materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Slot), args.Error(1)
+}
+
+// MockFailedEventRepository is a mock type for the FailedEventRepository
+type MockFailedEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockFailedEventRepository) Create(ctx context.Context, event *entities.FailedEvent) error {
+	return m.Called(ctx, event).Error(0)
+}
+
+func (m *MockFailedEventRepository) GetByID(ctx context.Context, id string) (*entities.FailedEvent, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.FailedEvent), args.Error(1)
+}
+
+func (m *MockFailedEventRepository) ListUnresolved(ctx context.Context, limit, offset int) ([]*entities.FailedEvent, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*entities.FailedEvent), args.Error(1)
+}
+
+func (m *MockFailedEventRepository) MarkAsResolved(ctx context.Context, id, notes string) error {
+	return m.Called(ctx, id, notes).Error(0)
+}
+
+func newTestCommandConsumer(bus services.MessageBus, slotRepo *MockSlotRepository, failedEventRepo *MockFailedEventRepository, maxAttempts int) *consumer.CommandConsumer {
+	// nothing listens there, so every lock attempt fails like it does while another instance holds the shelf
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	inventoryService := services.NewInventoryService(
+		nil, slotRepo, nil, nil, nil,
+		services.NewLockService(redisClient),
+		nil, nil, nil, nil,
+		services.NewRetryService(0, time.Millisecond),
+		failedEventRepo,
+		nil, nil, nil, nil, nil, nil,
+	)
+	return consumer.NewCommandConsumer(
+		bus,
+		commandTopic,
+		"inventory-service",
+		maxAttempts,
+		appcommands.NewPlaceMaterialCommandHandler(inventoryService),
+		appcommands.NewBatchPlaceMaterialsCommandHandler(inventoryService),
+		appcommands.NewRemoveMaterialCommandHandler(inventoryService),
+		appcommands.NewMoveMaterialCommandHandler(inventoryService),
+		appcommands.NewReserveSlotsCommandHandler(inventoryService),
+		inventoryService,
+		// without Redis every command is handled unguarded
+		services.NewCommandGuardService(redisClient, time.Hour),
+	)
+}
+
+func commandMessage(t *testing.T, commandType string, payload interface{}) services.BusMessage {
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	envelope, err := json.Marshal(commands.Envelope{
+		CommandID:   "cmd-1",
+		CommandType: commandType,
+		Source:      "erp-adapter",
+		IssuedAt:    time.Now(),
+		Payload:     data,
+	})
+	assert.NoError(t, err)
+	return services.BusMessage{Topic: commandTopic, Key: "cmd-1", Payload: envelope}
+}
+
+func TestCommandConsumer_ParksMalformedCommands(t *testing.T) {
+	failedEventRepo := new(MockFailedEventRepository)
+	commandConsumer := newTestCommandConsumer(messaging.NewMemoryBus(), new(MockSlotRepository), failedEventRepo, 3)
+
+	failedEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(event *entities.FailedEvent) bool {
+		return event.Topic == commandTopic && event.EventType == commands.CommandTypePlaceMaterial && string(event.Payload) == `"not json"`
+	})).Return(nil).Once()
+
+	// acknowledged right away, redelivering it would fail the same way
+	err := commandConsumer.Handle(context.Background(), services.BusMessage{
+		Topic:   commandTopic,
+		Headers: map[string]string{commands.HeaderCommandType: commands.CommandTypePlaceMaterial},
+		Payload: []byte("not json"),
+	})
+	assert.NoError(t, err)
+	failedEventRepo.AssertExpectations(t)
+}
+
+func TestCommandConsumer_RedeliversCommandsThatCannotBeParked(t *testing.T) {
+	failedEventRepo := new(MockFailedEventRepository)
+	commandConsumer := newTestCommandConsumer(messaging.NewMemoryBus(), new(MockSlotRepository), failedEventRepo, 3)
+	msg := commandMessage(t, "inventory.teleport_material", map[string]string{})
+
+	failedEventRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("database unavailable")).Once()
+	assert.Error(t, commandConsumer.Handle(context.Background(), msg))
+
+	// the redelivery is parked once the database is back
+	failedEventRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	assert.NoError(t, commandConsumer.Handle(context.Background(), msg))
+	failedEventRepo.AssertExpectations(t)
+}
+
+func TestCommandConsumer_ParksUnknownCommandTypes(t *testing.T) {
+	failedEventRepo := new(MockFailedEventRepository)
+	commandConsumer := newTestCommandConsumer(messaging.NewMemoryBus(), new(MockSlotRepository), failedEventRepo, 3)
+
+	failedEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(event *entities.FailedEvent) bool {
+		return event.EventType == "inventory.teleport_material"
+	})).Return(nil).Once()
+
+	assert.NoError(t, commandConsumer.Handle(context.Background(), commandMessage(t, "inventory.teleport_material", map[string]string{})))
+	failedEventRepo.AssertExpectations(t)
+}
+
+func TestCommandConsumer_ParksCommandsForUnknownSlots(t *testing.T) {
+	slotRepo := new(MockSlotRepository)
+	failedEventRepo := new(MockFailedEventRepository)
+	commandConsumer := newTestCommandConsumer(messaging.NewMemoryBus(), slotRepo, failedEventRepo, 3)
+
+	slotRepo.On("GetByID", mock.Anything, "missing-slot").Return(nil, gorm.ErrRecordNotFound).Once()
+	failedEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(event *entities.FailedEvent) bool {
+		return event.EventType == commands.CommandTypePlaceMaterial
+	})).Return(nil).Once()
+
+	err := commandConsumer.Handle(context.Background(), commandMessage(t, commands.CommandTypePlaceMaterial, commands.PlaceMaterial{
+		MaterialBarcode: "MAT-1",
+		SlotID:          "missing-slot",
+		OperatorID:      "operator-1",
+	}))
+	assert.NoError(t, err)
+	slotRepo.AssertExpectations(t)
+	failedEventRepo.AssertExpectations(t)
+}
+
+func TestCommandConsumer_RedeliversUntilMaxAttempts(t *testing.T) {
+	bus := messaging.NewMemoryBus()
+	slotRepo := new(MockSlotRepository)
+	failedEventRepo := new(MockFailedEventRepository)
+	commandConsumer := newTestCommandConsumer(bus, slotRepo, failedEventRepo, 3)
+
+	slotRepo.On("GetByID", mock.Anything, "slot-1").Return(&entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty}, nil)
+	parked := make(chan *entities.FailedEvent, 1)
+	failedEventRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		parked <- args.Get(1).(*entities.FailedEvent)
+	}).Return(nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go commandConsumer.Run(ctx)
+
+	// wait for the subscription to be registered before publishing
+	time.Sleep(50 * time.Millisecond)
+	msg := commandMessage(t, commands.CommandTypePlaceMaterial, commands.PlaceMaterial{
+		MaterialBarcode: "MAT-1",
+		SlotID:          "slot-1",
+		OperatorID:      "operator-1",
+	})
+	assert.NoError(t, bus.Publish(ctx, msg))
+
+	select {
+	case event := <-parked:
+		assert.Equal(t, commandTopic, event.Topic)
+		assert.Contains(t, event.Error, "giving up after 3 attempts")
+	case <-time.After(5 * time.Second):
+		t.Fatal("command was not moved to failed events")
+	}
+	// the shelf lock could not be taken on any of the deliveries
+	slotRepo.AssertNumberOfCalls(t, "GetByID", 3)
+}
@@ -0,0 +1,282 @@
+package unit
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/shared/events"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/infrastructure/messaging"
+)
+
+// allEventTypes lists every constant of event_types.go, each one needs a schema
+var allEventTypes = []string{
+	services.EventTypeMaterialPlaced,
+	services.EventTypeMaterialRemoved,
+	services.EventTypeMaterialMoved,
+	services.EventTypeMaterialReserved,
+	services.EventTypeSlotsReserved,
+	services.EventTypeSlotError,
+	services.EventTypeSlotTakenOutOfService,
+	services.EventTypeSlotReturnedToService,
+	services.EventTypeRelocationSuggested,
+	services.EventTypeMaterialDetected,
+	services.EventTypeUnplannedPlacement,
+	services.EventTypePhysicalPlacementRequested,
+	services.EventTypePhysicalPlacementConfirmed,
+	services.EventTypePhysicalPlacementFailed,
+	services.EventTypeMaterialRemovedFromShelf,
+	services.EventTypeUnplannedRemoval,
+	services.EventTypePhysicalRemovalRequested,
+	services.EventTypePhysicalRemovalConfirmed,
+	services.EventTypePhysicalRemovalFailed,
+	services.EventTypeShelfStatusChanged,
+	services.EventTypeShelfHealthAlert,
+	services.EventTypeSystemAlert,
+	services.EventTypeAuditLog,
+}
+
+// publishedEvents maps every event type the service publishes to the struct it is published as
+func publishedEvents() map[string]events.Event {
+	base := func(eventType string) events.BaseEvent {
+		return events.BaseEvent{EventType: eventType, AggregateID: "shelf-1"}
+	}
+	return map[string]events.Event{
+		services.EventTypeMaterialPlaced:             &events.MaterialPlacedEvent{BaseEvent: base(services.EventTypeMaterialPlaced)},
+		services.EventTypeMaterialRemoved:            &events.MaterialRemovedEvent{BaseEvent: base(services.EventTypeMaterialRemoved)},
+		services.EventTypeMaterialMoved:              &events.MaterialMovedEvent{BaseEvent: base(services.EventTypeMaterialMoved)},
+		services.EventTypeSlotError:                  &events.SlotErrorEvent{BaseEvent: base(services.EventTypeSlotError)},
+		services.EventTypeSlotsReserved:              &events.SlotsReservedEvent{BaseEvent: base(services.EventTypeSlotsReserved), SlotIDs: []string{"slot-1"}},
+		services.EventTypeSlotTakenOutOfService:      &events.SlotTakenOutOfServiceEvent{BaseEvent: base(services.EventTypeSlotTakenOutOfService)},
+		services.EventTypeSlotReturnedToService:      &events.SlotReturnedToServiceEvent{BaseEvent: base(services.EventTypeSlotReturnedToService)},
+		services.EventTypeRelocationSuggested:        &events.RelocationSuggestedEvent{BaseEvent: base(services.EventTypeRelocationSuggested)},
+		services.EventTypeUnplannedPlacement:         &events.UnplannedSlotEvent{BaseEvent: base(services.EventTypeUnplannedPlacement)},
+		services.EventTypePhysicalPlacementRequested: &events.PhysicalPlacementEvent{BaseEvent: base(services.EventTypePhysicalPlacementRequested), MaterialBarcode: "MAT-1"},
+		services.EventTypePhysicalPlacementConfirmed: &events.PhysicalPlacementEvent{BaseEvent: base(services.EventTypePhysicalPlacementConfirmed)},
+		services.EventTypePhysicalPlacementFailed:    &events.PhysicalPlacementEvent{BaseEvent: base(services.EventTypePhysicalPlacementFailed)},
+		services.EventTypeUnplannedRemoval:           &events.UnplannedSlotEvent{BaseEvent: base(services.EventTypeUnplannedRemoval)},
+		services.EventTypeShelfStatusChanged:         &events.ShelfStatusChangedEvent{BaseEvent: base(services.EventTypeShelfStatusChanged)},
+		services.EventTypeShelfHealthAlert:           &events.ShelfHealthAlertEvent{BaseEvent: base(services.EventTypeShelfHealthAlert)},
+		services.EventTypeSystemAlert: &events.SystemAlertEvent{
+			BaseEvent: base(services.EventTypeSystemAlert),
+			Details:   map[string]interface{}{"retry_count": 3},
+		},
+		services.EventTypeAuditLog: &events.AuditLogEvent{
+			BaseEvent: base(services.EventTypeAuditLog),
+			Changes:   map[string]interface{}{"status": "occupied"},
+			ErrorMsg:  "timeout",
+		},
+	}
+}
+
+func loadSchemaRegistry(t *testing.T) *events.SchemaRegistry {
+	registry, err := events.NewSchemaRegistry()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return registry
+}
+
+func TestEventSchemas_CoverEveryEventType(t *testing.T) {
+	registry := loadSchemaRegistry(t)
+
+	for _, eventType := range allEventTypes {
+		_, ok := registry.Document(eventType)
+		assert.True(t, ok, "no schema for %s", eventType)
+	}
+	assert.ElementsMatch(t, allEventTypes, registry.EventTypes(), "schemas and event_types.go are out of sync")
+}
+
+// TestEventSchemas_MatchEventStructs fails when a field is added to, removed from or retyped in an event struct
+// without updating its schema in shared/events/schemas
+func TestEventSchemas_MatchEventStructs(t *testing.T) {
+	registry := loadSchemaRegistry(t)
+
+	for eventType, event := range publishedEvents() {
+		document, ok := registry.Document(eventType)
+		if !assert.True(t, ok, "no schema for %s", eventType) {
+			continue
+		}
+		assert.True(t, document.Closed, "%s: schema must reject undeclared properties", eventType)
+
+		fields := jsonFields(reflect.TypeOf(event).Elem())
+		for name, field := range fields {
+			property, ok := document.Properties[name]
+			if !assert.True(t, ok, "%s: field %s is missing from the schema", eventType, name) {
+				continue
+			}
+			jsonType := jsonTypeOf(field.Type)
+			assert.True(t, property.Types.Allows(jsonType), "%s: field %s is %s but the schema allows %v", eventType, name, jsonType, property.Types)
+			if nullable(field.Type) && !field.omitEmpty {
+				assert.True(t, property.Types.Allows("null"), "%s: field %s may be null but the schema does not allow it", eventType, name)
+			}
+			assert.Equal(t, !field.omitEmpty, contains(document.Required, name), "%s: field %s is required only when it is always serialized", eventType, name)
+		}
+		for name := range document.Properties {
+			_, ok := fields[name]
+			assert.True(t, ok, "%s: schema property %s has no field in %T", eventType, name, event)
+		}
+	}
+}
+
+func TestEventSchemas_AcceptPublishedEvents(t *testing.T) {
+	bus := messaging.NewMemoryBus()
+	eventService := services.NewEventService(bus, "inventory_events", loadSchemaRegistry(t))
+
+	for eventType, event := range publishedEvents() {
+		assert.NoError(t, eventService.PublishEvent(context.Background(), event), eventType)
+	}
+	assert.Equal(t, len(publishedEvents()), len(bus.Published()))
+}
+
+type driftedMaterialPlacedEvent struct {
+	events.MaterialPlacedEvent
+	Quantity int `json:"quantity"`
+}
+
+func TestEventService_RejectsEventsNotMatchingTheirSchema(t *testing.T) {
+	bus := messaging.NewMemoryBus()
+	eventService := services.NewEventService(bus, "inventory_events", loadSchemaRegistry(t))
+
+	// a field the schema does not declare
+	err := eventService.PublishEvent(context.Background(), &driftedMaterialPlacedEvent{
+		MaterialPlacedEvent: events.MaterialPlacedEvent{BaseEvent: events.BaseEvent{EventType: services.EventTypeMaterialPlaced}},
+		Quantity:            2,
+	})
+	assert.Error(t, err)
+
+	// an event type without a schema
+	err = eventService.PublishEvent(context.Background(), &events.SystemAlertEvent{
+		BaseEvent: events.BaseEvent{EventType: "system_alert"},
+	})
+	assert.Error(t, err)
+
+	assert.Empty(t, bus.Published())
+}
+
+func TestCheckSchemaCompatibility(t *testing.T) {
+	registry := loadSchemaRegistry(t)
+	old := registry.Documents()
+
+	assert.Empty(t, events.CheckSchemaCompatibility(old, old))
+
+	changed := func(eventType string, change func(document *events.SchemaDocument)) map[string]*events.SchemaDocument {
+		documents := make(map[string]*events.SchemaDocument, len(old))
+		for k, v := range old {
+			documents[k] = v
+		}
+		document := *old[eventType]
+		document.Properties = make(map[string]*events.PropertySchema, len(old[eventType].Properties))
+		for k, v := range old[eventType].Properties {
+			property := *v
+			document.Properties[k] = &property
+		}
+		document.Required = append([]string(nil), old[eventType].Required...)
+		change(&document)
+		documents[eventType] = &document
+		return documents
+	}
+
+	// adding an optional property is safe for existing consumers
+	added := changed(services.EventTypeMaterialPlaced, func(document *events.SchemaDocument) {
+		document.Properties["batch_id"] = &events.PropertySchema{Types: events.SchemaTypes{"string"}}
+	})
+	assert.Empty(t, events.CheckSchemaCompatibility(old, added))
+
+	removed := changed(services.EventTypeMaterialPlaced, func(document *events.SchemaDocument) {
+		delete(document.Properties, "operator_id")
+	})
+	assert.Contains(t, events.CheckSchemaCompatibility(old, removed), "material.placed: property operator_id removed")
+
+	optional := changed(services.EventTypeMaterialPlaced, func(document *events.SchemaDocument) {
+		document.Required = document.Required[:0]
+	})
+	assert.Contains(t, events.CheckSchemaCompatibility(old, optional), "material.placed: property slot_id is no longer required")
+
+	widened := changed(services.EventTypeShelfHealthAlert, func(document *events.SchemaDocument) {
+		document.Properties["health_score"].Types = events.SchemaTypes{"number", "string"}
+	})
+	assert.Contains(t, events.CheckSchemaCompatibility(old, widened), "shelf.health_alert: property health_score now accepts type string")
+
+	withoutSchema := make(map[string]*events.SchemaDocument, len(old))
+	for k, v := range old {
+		withoutSchema[k] = v
+	}
+	delete(withoutSchema, services.EventTypeAuditLog)
+	assert.Contains(t, events.CheckSchemaCompatibility(old, withoutSchema), "audit.log: schema removed")
+}
+
+type jsonField struct {
+	Type      reflect.Type
+	omitEmpty bool
+}
+
+// jsonFields returns the fields of an event struct as encoding/json serializes them, embedded structs flattened
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := make(map[string]jsonField)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if field.Anonymous && tag == "" {
+			for name, embedded := range jsonFields(field.Type) {
+				if _, ok := fields[name]; !ok {
+					fields[name] = embedded
+				}
+			}
+			continue
+		}
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = jsonField{Type: field.Type, omitEmpty: strings.Contains(options, "omitempty")}
+	}
+	return fields
+}
+
+func jsonTypeOf(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Ptr:
+		return jsonTypeOf(t.Elem())
+	default:
+		return "object"
+	}
+}
+
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/shared/events"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/infrastructure/messaging"
+)
+
+func TestEventService_PublishEventOnMemoryBus(t *testing.T) {
+	bus := messaging.NewMemoryBus()
+	eventService := services.NewEventService(bus, "inventory_events", nil)
+
+	ctx := events.WithTrace(context.Background(), "request-1", "shelf-event-1")
+	err := eventService.PublishEvent(ctx, &events.MaterialPlacedEvent{
+		BaseEvent: events.BaseEvent{EventType: services.EventTypeMaterialPlaced, AggregateID: "shelf-1"},
+		SlotID:    "slot-1",
+		ShelfID:   "shelf-1",
+	})
+	assert.NoError(t, err)
+
+	published := bus.Published()
+	if assert.Len(t, published, 1) {
+		assert.Equal(t, "inventory_events", published[0].Topic)
+		// keyed by shelf so the events of one shelf stay in order
+		assert.Equal(t, "shelf-1", published[0].Key)
+		assert.Equal(t, services.EventTypeMaterialPlaced, published[0].Headers[events.HeaderEventType])
+		assert.Equal(t, events.EnvelopeVersion, published[0].Headers[events.HeaderEventVersion])
+
+		var payload map[string]interface{}
+		assert.NoError(t, json.Unmarshal(published[0].Payload, &payload))
+		// envelope and payload fields share the top level
+		assert.Equal(t, services.EventTypeMaterialPlaced, payload["event_type"])
+		assert.Equal(t, "slot-1", payload["slot_id"])
+		assert.Equal(t, "inventory-service", payload["source"])
+		assert.Equal(t, "request-1", payload["correlation_id"])
+		assert.Equal(t, "shelf-event-1", payload["causation_id"])
+		assert.NotEmpty(t, payload["event_id"])
+		assert.NotEmpty(t, payload["timestamp"])
+	}
+}
+
+func TestEventService_StartsCorrelationWithoutTrace(t *testing.T) {
+	bus := messaging.NewMemoryBus()
+	eventService := services.NewEventService(bus, "inventory_events", nil)
+
+	event := &events.SystemAlertEvent{BaseEvent: events.BaseEvent{EventType: services.EventTypeSystemAlert}}
+	assert.NoError(t, eventService.PublishEvent(context.Background(), event))
+
+	assert.Equal(t, event.EventID, event.CorrelationID)
+	assert.Empty(t, event.CausationID)
+	// events without an aggregate fall back to the event type as key
+	assert.Equal(t, services.EventTypeSystemAlert, bus.Published()[0].Key)
+}
+
+func TestMemoryBus_DeliversOncePerGroup(t *testing.T) {
+	bus := messaging.NewMemoryBus()
+	eventService := services.NewEventService(bus, "inventory_events", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	received := map[string]int{}
+	subscribe := func(group string) {
+		go eventService.Subscribe(ctx, group, func(ctx context.Context, msg services.BusMessage) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received[group]++
+			return nil
+		})
+	}
+	// two replicas of the same consumer share the messages, another group gets its own copy
+	subscribe("realtime")
+	subscribe("realtime")
+	subscribe("simulator")
+
+	// wait for the subscriptions to be registered before publishing
+	time.Sleep(50 * time.Millisecond)
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, eventService.PublishEvent(ctx, &events.MaterialPlacedEvent{
+			BaseEvent: events.BaseEvent{EventType: services.EventTypeMaterialPlaced, AggregateID: "shelf-1"},
+		}))
+	}
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return received["realtime"] == 4 && received["simulator"] == 4
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestMemoryBus_KeepsRecentMessages(t *testing.T) {
+	bus := messaging.NewMemoryBus()
+	ctx := context.Background()
+
+	total := 2*messaging.MemoryBusHistory + 5
+	for i := 0; i < total; i++ {
+		assert.NoError(t, bus.Publish(ctx, services.BusMessage{Topic: "inventory_events", Key: strconv.Itoa(i)}))
+	}
+
+	published := bus.Published()
+	if assert.Len(t, published, messaging.MemoryBusHistory) {
+		assert.Equal(t, strconv.Itoa(total-messaging.MemoryBusHistory), published[0].Key)
+		assert.Equal(t, strconv.Itoa(total-1), published[len(published)-1].Key)
+	}
+}
+
+func TestMemoryBus_RetriesFailedMessagesInOrder(t *testing.T) {
+	bus := messaging.NewMemoryBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var handled []string
+	var failedAt time.Time
+	retryDelay := make(chan time.Duration, 1)
+	go bus.Subscribe(ctx, "inventory_events", "realtime", func(ctx context.Context, msg services.BusMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if msg.Key == "1" && failedAt.IsZero() {
+			failedAt = time.Now()
+			return errors.New("database unavailable")
+		}
+		if msg.Key == "1" {
+			retryDelay <- time.Since(failedAt)
+		}
+		handled = append(handled, msg.Key)
+		return nil
+	})
+
+	// wait for the subscription to be registered before publishing
+	time.Sleep(50 * time.Millisecond)
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, bus.Publish(ctx, services.BusMessage{Topic: "inventory_events", Key: strconv.Itoa(i)}))
+	}
+
+	select {
+	case delay := <-retryDelay:
+		assert.GreaterOrEqual(t, delay, 900*time.Millisecond)
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed message was not retried")
+	}
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(handled) == 3
+	}, time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	// the later messages waited for the retry
+	assert.Equal(t, []string{"1", "2", "3"}, handled)
+}
@@ -0,0 +1,123 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	apperrors "WMS/services/inventory-service/pkg/errors"
+)
+
+func TestFindOptimalSlot_UsesLocationSuggestion(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	suggested := &entities.Slot{ID: "slot-9", ShelfID: "shelf-2", Status: entities.SlotStatusEmpty}
+	m.locationClient.On("SuggestPlacement", ctx, services.PlacementRequest{MaterialType: "CPU", ZoneID: "zone-a", Limit: 5}).Return([]*services.PlacementSuggestion{{ShelfID: "shelf-2", SlotID: "slot-9"}}, nil)
+	m.slotRepo.On("GetByID", ctx, "slot-9").Return(suggested, nil)
+
+	slot, err := inventoryService.FindOptimalSlot(ctx, services.FindOptimalSlotParams{MaterialType: "CPU", ZoneID: "zone-a"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, suggested, slot)
+	m.slotRepo.AssertNotCalled(t, "GetEmptySlotsByShelf", ctx, "shelf-2")
+}
+
+func TestFindOptimalSlot_FallsBackWhenLocationServiceFails(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	local := &entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty}
+	m.locationClient.On("SuggestPlacement", ctx, services.PlacementRequest{MaterialType: "CPU", ShelfID: "shelf-1", Limit: 5}).Return(nil, errors.New("location-service circuit breaker is open"))
+	m.slotRepo.On("GetEmptySlotsByShelf", ctx, "shelf-1").Return([]*entities.Slot{local}, nil)
+
+	slot, err := inventoryService.FindOptimalSlot(ctx, services.FindOptimalSlotParams{MaterialType: "CPU", ShelfID: "shelf-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, local, slot)
+}
+
+func TestFindOptimalSlot_IgnoresStaleSuggestion(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	materialID := "mat-1"
+	local := &entities.Slot{ID: "slot-2", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty}
+	m.locationClient.On("SuggestPlacement", ctx, services.PlacementRequest{MaterialType: "CPU", ShelfID: "shelf-1", Limit: 5}).Return([]*services.PlacementSuggestion{{ShelfID: "shelf-1", SlotID: "slot-1"}}, nil)
+	// location-service has not caught up with a placement yet
+	m.slotRepo.On("GetByID", ctx, "slot-1").Return(&entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusOccupied, MaterialID: &materialID}, nil)
+	m.slotRepo.On("GetEmptySlotsByShelf", ctx, "shelf-1").Return([]*entities.Slot{local}, nil)
+
+	slot, err := inventoryService.FindOptimalSlot(ctx, services.FindOptimalSlotParams{MaterialType: "CPU", ShelfID: "shelf-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, local, slot)
+}
+
+func TestFindOptimalSlot_PassesStoragePolicyToLocationService(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	suggested := &entities.Slot{ID: "slot-9", ShelfID: "shelf-2", Status: entities.SlotStatusEmpty}
+	m.locationClient.On("SuggestPlacement", ctx, services.PlacementRequest{MaterialType: "CPU", StorageClass: "QUARANTINE", Role: "quality", Limit: 5}).
+		Return([]*services.PlacementSuggestion{{ShelfID: "shelf-2", SlotID: "slot-9"}}, nil)
+	m.slotRepo.On("GetByID", ctx, "slot-9").Return(suggested, nil)
+
+	slot, err := inventoryService.FindOptimalSlot(ctx, services.FindOptimalSlotParams{MaterialType: "CPU", StorageClass: "QUARANTINE", Role: "quality"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, suggested, slot)
+}
+
+func TestFindOptimalSlot_DoesNotFallBackWhenStoragePolicyRejects(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	m.locationClient.On("SuggestPlacement", ctx, services.PlacementRequest{MaterialType: "CPU", ShelfID: "shelf-1", Limit: 5}).Return(nil, services.ErrNoSuitableSlot)
+
+	slot, err := inventoryService.FindOptimalSlot(ctx, services.FindOptimalSlotParams{MaterialType: "CPU", ShelfID: "shelf-1"})
+
+	assert.Nil(t, slot)
+	var notFound *apperrors.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	m.slotRepo.AssertNotCalled(t, "GetEmptySlotsByShelf", ctx, "shelf-1")
+}
+
+func TestFindOptimalSlot_TriesFurtherSuggestionsInAZone(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	materialID := "mat-1"
+	next := &entities.Slot{ID: "slot-4", ShelfID: "shelf-3", Status: entities.SlotStatusEmpty}
+	m.locationClient.On("SuggestPlacement", ctx, services.PlacementRequest{MaterialType: "CPU", ZoneID: "zone-a", Limit: 5}).Return([]*services.PlacementSuggestion{
+		{ShelfID: "shelf-2", SlotID: "slot-9"},
+		{ShelfID: "shelf-3", SlotID: "slot-4"},
+	}, nil)
+	// location-service has not caught up with a placement into its best slot yet
+	m.slotRepo.On("GetByID", ctx, "slot-9").Return(&entities.Slot{ID: "slot-9", ShelfID: "shelf-2", Status: entities.SlotStatusOccupied, MaterialID: &materialID}, nil)
+	m.slotRepo.On("GetByID", ctx, "slot-4").Return(next, nil)
+
+	slot, err := inventoryService.FindOptimalSlot(ctx, services.FindOptimalSlotParams{MaterialType: "CPU", ZoneID: "zone-a"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, next, slot)
+}
+
+func TestFindOptimalSlot_NotFoundWhenAllZoneSuggestionsAreStale(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	m.locationClient.On("SuggestPlacement", ctx, services.PlacementRequest{MaterialType: "CPU", ZoneID: "zone-a", Limit: 5}).Return([]*services.PlacementSuggestion{
+		{ShelfID: "shelf-2", SlotID: "slot-9"},
+	}, nil)
+	m.slotRepo.On("GetByID", ctx, "slot-9").Return(&entities.Slot{ID: "slot-9", ShelfID: "shelf-2", Status: entities.SlotStatusReserved}, nil)
+
+	slot, err := inventoryService.FindOptimalSlot(ctx, services.FindOptimalSlotParams{MaterialType: "CPU", ZoneID: "zone-a"})
+
+	assert.Nil(t, slot)
+	var notFound *apperrors.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+}
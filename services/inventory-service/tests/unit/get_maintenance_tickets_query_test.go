@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"WMS/services/inventory-service/internal/application/queries"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+)
+
+// MockMaintenanceTicketRepository is a mock type for the MaintenanceTicketRepository
+type MockMaintenanceTicketRepository struct {
+	mock.Mock
+}
+
+func (m *MockMaintenanceTicketRepository) Create(ctx context.Context, ticket *entities.MaintenanceTicket) error {
+	args := m.Called(ctx, ticket)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceTicketRepository) CreateWithTx(ctx context.Context, tx *gorm.DB, ticket *entities.MaintenanceTicket) error {
+	args := m.Called(ctx, tx, ticket)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceTicketRepository) GetByID(ctx context.Context, id string) (*entities.MaintenanceTicket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaintenanceTicket), args.Error(1)
+}
+
+func (m *MockMaintenanceTicketRepository) GetActiveBySlotID(ctx context.Context, slotID string) (*entities.MaintenanceTicket, error) {
+	args := m.Called(ctx, slotID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MaintenanceTicket), args.Error(1)
+}
+
+func (m *MockMaintenanceTicketRepository) Update(ctx context.Context, ticket *entities.MaintenanceTicket) error {
+	args := m.Called(ctx, ticket)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceTicketRepository) UpdateWithTx(ctx context.Context, tx *gorm.DB, ticket *entities.MaintenanceTicket) error {
+	args := m.Called(ctx, tx, ticket)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceTicketRepository) List(ctx context.Context, filter repositories.MaintenanceTicketFilter) ([]*entities.MaintenanceTicket, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entities.MaintenanceTicket), args.Error(1)
+}
+
+func TestGetMaintenanceTicketsQueryHandler_Handle(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockMaintenanceTicketRepository)
+	handler := queries.NewGetMaintenanceTicketsQueryHandler(mockRepo)
+
+	ctx := context.Background()
+	expectedTickets := []*entities.MaintenanceTicket{
+		{ID: "ticket-1", SlotID: "slot-1", ShelfID: "shelf-1", Status: entities.MaintenanceTicketStatusOpen},
+	}
+	expectedFilter := repositories.MaintenanceTicketFilter{
+		ShelfID: "shelf-1",
+		Status:  entities.MaintenanceTicketStatusOpen,
+		Limit:   20, // default limit
+	}
+
+	mockRepo.On("List", ctx, expectedFilter).Return(expectedTickets, nil).Once()
+
+	// Act
+	tickets, err := handler.Handle(ctx, queries.GetMaintenanceTicketsQuery{ShelfID: "shelf-1", Status: entities.MaintenanceTicketStatusOpen})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTickets, tickets)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetMaintenanceTicketsQueryHandler_Handle_InvalidStatus(t *testing.T) {
+	mockRepo := new(MockMaintenanceTicketRepository)
+	handler := queries.NewGetMaintenanceTicketsQueryHandler(mockRepo)
+
+	_, err := handler.Handle(context.Background(), queries.GetMaintenanceTicketsQuery{Status: "closed"})
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestMaintenanceTicket_AddNote(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ticket := &entities.MaintenanceTicket{}
+
+	ticket.AddNote("tech-1", "replaced load cell", at)
+	ticket.AddNote("tech-1", "", at) // empty notes are ignored
+	ticket.AddNote("", "recalibrated", at)
+
+	assert.Equal(t, "2024-01-02T03:04:05Z tech-1: replaced load cell\n2024-01-02T03:04:05Z: recalibrated", ticket.Notes)
+}
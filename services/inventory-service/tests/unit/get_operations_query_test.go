@@ -3,9 +3,11 @@ package unit
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 	"WMS/services/inventory-service/internal/application/queries"
 	"WMS/services/inventory-service/internal/domain/entities"
 	"WMS/services/inventory-service/internal/domain/repositories"
@@ -27,7 +29,7 @@ func (m *MockOperationRepository) Create(ctx context.Context, operation *entitie
 	args := m.Called(ctx, operation)
 	return args.Error(0)
 }
-func (m *MockOperationRepository) CreateWithTx(ctx context.Context, tx interface{}, operation *entities.Operation) error {
+func (m *MockOperationRepository) CreateWithTx(ctx context.Context, tx *gorm.DB, operation *entities.Operation) error {
 	args := m.Called(ctx, tx, operation)
 	return args.Error(0)
 }
@@ -43,6 +45,38 @@ func (m *MockOperationRepository) GetByOperatorID(ctx context.Context, operatorI
 	args := m.Called(ctx, operatorID, limit, offset)
 	return args.Get(0).([]*entities.Operation), args.Error(1)
 }
+func (m *MockOperationRepository) Find(ctx context.Context, options repositories.OperationListOptions) ([]*entities.Operation, error) {
+	args := m.Called(ctx, options)
+	return args.Get(0).([]*entities.Operation), args.Error(1)
+}
+func (m *MockOperationRepository) Count(ctx context.Context, filter repositories.OperationFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockOperationRepository) GetTimedOutPendingPhysicalConfirmations(ctx context.Context, timeout time.Duration) ([]*entities.Operation, error) {
+	args := m.Called(ctx, timeout)
+	return args.Get(0).([]*entities.Operation), args.Error(1)
+}
+func (m *MockOperationRepository) GetPendingPhysicalConfirmationsBySlotID(ctx context.Context, slotID string) ([]*entities.Operation, error) {
+	args := m.Called(ctx, slotID)
+	return args.Get(0).([]*entities.Operation), args.Error(1)
+}
+func (m *MockOperationRepository) GetPendingRemovalConfirmationsBySlotID(ctx context.Context, slotID string) ([]*entities.Operation, error) {
+	args := m.Called(ctx, slotID)
+	return args.Get(0).([]*entities.Operation), args.Error(1)
+}
+func (m *MockOperationRepository) BeginTx(ctx context.Context) (*gorm.DB, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*gorm.DB), args.Error(1)
+}
+func (m *MockOperationRepository) UpdateWithTx(ctx context.Context, tx *gorm.DB, operation *entities.Operation) error {
+	args := m.Called(ctx, tx, operation)
+	return args.Error(0)
+}
+func (m *MockOperationRepository) TransitionStatusWithTx(ctx context.Context, tx *gorm.DB, id string, from, to entities.OperationStatus) (bool, error) {
+	args := m.Called(ctx, tx, id, from, to)
+	return args.Bool(0), args.Error(1)
+}
 
 func TestGetOperationsQueryHandler_Handle(t *testing.T) {
 	// Arrange
@@ -51,23 +85,92 @@ func TestGetOperationsQueryHandler_Handle(t *testing.T) {
 
 	ctx := context.Background()
 	query := queries.GetOperationsQuery{
-		Limit:  10,
-		Offset: 0,
+		ShelfID: "shelf-1",
+		Status:  entities.OperationStatusCompleted,
+		Limit:   10,
+		Offset:  0,
 	}
 
 	expectedOperations := []*entities.Operation{
 		{ID: "op1", Type: "placement"},
 		{ID: "op2", Type: "removal"},
 	}
+	filter := repositories.OperationFilter{ShelfID: "shelf-1", Status: entities.OperationStatusCompleted}
 
-	// Expect the List method to be called once with the specified arguments
-	mockRepo.On("List", ctx, query.Limit, query.Offset).Return(expectedOperations, nil).Once()
+	// Expect the newest operations first, filtered by shelf and status
+	mockRepo.On("Find", ctx, repositories.OperationListOptions{
+		Filter:     filter,
+		SortBy:     repositories.OperationSortByTimestamp,
+		Descending: true,
+		Limit:      10,
+	}).Return(expectedOperations, nil).Once()
+	mockRepo.On("Count", ctx, filter).Return(int64(2), nil).Once()
 
 	// Act
-	operations, err := handler.Handle(ctx, query)
+	page, err := handler.Handle(ctx, query)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, expectedOperations, operations)
+	assert.Equal(t, expectedOperations, page.Operations)
+	assert.Equal(t, int64(2), page.TotalCount)
+	assert.Empty(t, page.NextCursor) // a short page is the last one
 	mockRepo.AssertExpectations(t)
 }
+
+func TestGetOperationsQueryHandler_PagesWithCursor(t *testing.T) {
+	mockRepo := new(MockOperationRepository)
+	handler := queries.NewGetOperationsQueryHandler(mockRepo)
+	ctx := context.Background()
+
+	timestamp := time.Date(2025, 3, 1, 12, 0, 0, 500, time.UTC)
+	firstPage := []*entities.Operation{
+		{ID: "op3", Timestamp: timestamp.Add(time.Minute)},
+		{ID: "op2", Timestamp: timestamp},
+	}
+	mockRepo.On("Find", ctx, mock.MatchedBy(func(options repositories.OperationListOptions) bool {
+		return options.After == nil
+	})).Return(firstPage, nil).Once()
+	mockRepo.On("Find", ctx, mock.MatchedBy(func(options repositories.OperationListOptions) bool {
+		return options.After != nil && options.After.ID == "op2" && options.After.SortValue == timestamp.Format(time.RFC3339Nano)
+	})).Return([]*entities.Operation{{ID: "op1", Timestamp: timestamp}}, nil).Once()
+	mockRepo.On("Count", ctx, repositories.OperationFilter{}).Return(int64(3), nil)
+
+	page, err := handler.Handle(ctx, queries.GetOperationsQuery{Limit: 2})
+	assert.NoError(t, err)
+	cursor := page.NextCursor
+	assert.NotEmpty(t, cursor)
+
+	page, err = handler.Handle(ctx, queries.GetOperationsQuery{Limit: 2, Cursor: cursor})
+	assert.NoError(t, err)
+	assert.Equal(t, "op1", page.Operations[0].ID)
+	assert.Equal(t, int64(3), page.TotalCount)
+	assert.Empty(t, page.NextCursor)
+	mockRepo.AssertExpectations(t)
+
+	// the cursor only makes sense for the order it was issued for
+	_, err = handler.Handle(ctx, queries.GetOperationsQuery{Limit: 2, Order: "asc", Cursor: cursor})
+	assert.Error(t, err)
+	_, err = handler.Handle(ctx, queries.GetOperationsQuery{Limit: 2, SortBy: "shelf_id", Cursor: cursor})
+	assert.Error(t, err)
+}
+
+func TestGetOperationsQueryHandler_RejectsInvalidQueries(t *testing.T) {
+	mockRepo := new(MockOperationRepository)
+	handler := queries.NewGetOperationsQueryHandler(mockRepo)
+	ctx := context.Background()
+
+	invalid := []queries.GetOperationsQuery{
+		{Type: "teleport"},
+		{Status: "lost"},
+		{SortBy: "material_id; DROP TABLE operations"},
+		{Order: "sideways"},
+		{Cursor: "not-a-cursor"},
+		{Cursor: "e30", Offset: 20},
+		{From: time.Now(), To: time.Now().Add(-time.Hour)},
+	}
+	for _, query := range invalid {
+		_, err := handler.Handle(ctx, query)
+		assert.Error(t, err, "%+v", query)
+	}
+	mockRepo.AssertNotCalled(t, "Find", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,143 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"WMS/services/inventory-service/internal/application/queries"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/pkg/errors"
+)
+
+// MockSensorReadingRepository is a mock type for the SensorReadingRepository
+type MockSensorReadingRepository struct {
+	mock.Mock
+}
+
+func (m *MockSensorReadingRepository) Create(ctx context.Context, reading *entities.SensorReading) error {
+	args := m.Called(ctx, reading)
+	return args.Error(0)
+}
+
+func (m *MockSensorReadingRepository) ListReadings(ctx context.Context, filter repositories.SensorReadingFilter) ([]*entities.SensorReading, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entities.SensorReading), args.Error(1)
+}
+
+func (m *MockSensorReadingRepository) GetLatestWeightBySlotID(ctx context.Context, slotID string, before time.Time) (*entities.SensorReading, error) {
+	args := m.Called(ctx, slotID, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.SensorReading), args.Error(1)
+}
+
+func (m *MockSensorReadingRepository) ListRollups(ctx context.Context, filter repositories.SensorReadingFilter) ([]*entities.SensorReadingRollup, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entities.SensorReadingRollup), args.Error(1)
+}
+
+func (m *MockSensorReadingRepository) Rollup(ctx context.Context, resolution entities.SensorResolution, from, to time.Time) error {
+	args := m.Called(ctx, resolution, from, to)
+	return args.Error(0)
+}
+
+func (m *MockSensorReadingRepository) CreateMonthlyPartition(ctx context.Context, month time.Time) error {
+	return m.Called(ctx, month).Error(0)
+}
+
+func TestGetSensorReadingsQueryHandler_Handle_Raw(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSensorReadingRepository)
+	handler := queries.NewGetSensorReadingsQueryHandler(mockRepo)
+
+	ctx := context.Background()
+	to := time.Now()
+	from := to.Add(-time.Hour)
+	query := queries.GetSensorReadingsQuery{
+		ShelfID: "shelf-1",
+		SlotID:  "slot-1",
+		From:    from,
+		To:      to,
+		Limit:   50,
+	}
+
+	weight := 12.5
+	expectedReadings := []*entities.SensorReading{
+		{ID: "reading-1", ShelfID: "shelf-1", SlotID: "slot-1", Weight: &weight, RecordedAt: from},
+	}
+	expectedFilter := repositories.SensorReadingFilter{
+		ShelfID:    "shelf-1",
+		SlotID:     "slot-1",
+		From:       from,
+		To:         to,
+		Resolution: entities.SensorResolutionRaw,
+		Limit:      50,
+	}
+
+	mockRepo.On("ListReadings", ctx, expectedFilter).Return(expectedReadings, nil).Once()
+
+	// Act
+	result, err := handler.Handle(ctx, query)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entities.SensorResolutionRaw, result.Resolution)
+	assert.Equal(t, expectedReadings, result.Readings)
+	assert.Nil(t, result.Buckets)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetSensorReadingsQueryHandler_Handle_Rollup(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSensorReadingRepository)
+	handler := queries.NewGetSensorReadingsQueryHandler(mockRepo)
+
+	ctx := context.Background()
+	to := time.Now()
+	from := to.Add(-7 * 24 * time.Hour)
+	query := queries.GetSensorReadingsQuery{
+		ShelfID:    "shelf-1",
+		From:       from,
+		To:         to,
+		Resolution: entities.SensorResolutionHour,
+	}
+
+	expectedBuckets := []*entities.SensorReadingRollup{
+		{ShelfID: "shelf-1", Resolution: entities.SensorResolutionHour, BucketStart: from, SampleCount: 60},
+	}
+
+	mockRepo.On("ListRollups", ctx, mock.MatchedBy(func(f repositories.SensorReadingFilter) bool {
+		return f.Resolution == entities.SensorResolutionHour && f.Limit > 0
+	})).Return(expectedBuckets, nil).Once()
+
+	// Act
+	result, err := handler.Handle(ctx, query)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedBuckets, result.Buckets)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetSensorReadingsQueryHandler_Handle_InvalidQuery(t *testing.T) {
+	mockRepo := new(MockSensorReadingRepository)
+	handler := queries.NewGetSensorReadingsQueryHandler(mockRepo)
+	ctx := context.Background()
+
+	_, err := handler.Handle(ctx, queries.GetSensorReadingsQuery{})
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	_, err = handler.Handle(ctx, queries.GetSensorReadingsQuery{ShelfID: "shelf-1", Resolution: "week"})
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	now := time.Now()
+	_, err = handler.Handle(ctx, queries.GetSensorReadingsQuery{ShelfID: "shelf-1", From: now, To: now.Add(-time.Hour)})
+	assert.IsType(t, &errors.ValidationError{}, err)
+
+	mockRepo.AssertNotCalled(t, "ListReadings", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,92 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "WMS/shared/proto/inventory"
+	"WMS/services/inventory-service/internal/application/queries"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	grpcserver "WMS/services/inventory-service/internal/interfaces/grpc"
+	"WMS/services/inventory-service/internal/interfaces/grpc/handlers"
+	"WMS/services/inventory-service/pkg/errors"
+)
+
+func TestToStatusError_MapsApplicationErrors(t *testing.T) {
+	tests := []struct {
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{errors.NewValidationError("slot ID is required", nil), codes.InvalidArgument, "slot ID is required"},
+		{errors.NewNotFoundError("material not found", nil), codes.NotFound, "material not found"},
+		{errors.NewConflictError("slot is occupied", nil), codes.Aborted, "slot is occupied"},
+		{errors.NewInternalError("failed to update slot", fmt.Errorf("connection refused")), codes.Internal, "internal server error"},
+		{fmt.Errorf("unexpected"), codes.Internal, "internal server error"},
+		{context.DeadlineExceeded, codes.DeadlineExceeded, context.DeadlineExceeded.Error()},
+	}
+
+	for _, tt := range tests {
+		st, ok := status.FromError(grpcserver.ToStatusError(tt.err))
+		assert.True(t, ok)
+		assert.Equal(t, tt.code, st.Code(), tt.err.Error())
+		assert.Equal(t, tt.message, st.Message())
+	}
+	assert.NoError(t, grpcserver.ToStatusError(nil))
+}
+
+func TestInventoryServer_GetOperationHistory(t *testing.T) {
+	mockRepo := new(MockOperationRepository)
+	server := handlers.NewInventoryServer(nil, nil, nil, nil, queries.NewGetOperationsQueryHandler(mockRepo))
+	ctx := context.Background()
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	filter := repositories.OperationFilter{
+		ShelfID: "shelf-1",
+		Type:    entities.OperationTypeRemoval,
+		Status:  entities.OperationStatusPendingRemovalConfirmation,
+		From:    time.Unix(from.Unix(), 0),
+	}
+	mockRepo.On("Find", ctx, mock.MatchedBy(func(options repositories.OperationListOptions) bool {
+		return options.Filter == filter && options.Limit == 10
+	})).Return([]*entities.Operation{
+		{ID: "op1", Type: entities.OperationTypeRemoval, Status: entities.OperationStatusPendingRemovalConfirmation, ShelfID: "shelf-1", Timestamp: from.Add(time.Hour)},
+	}, nil).Once()
+	mockRepo.On("Count", ctx, filter).Return(int64(1), nil).Once()
+
+	resp, err := server.GetOperationHistory(ctx, &pb.GetOperationHistoryRequest{
+		ShelfId: "shelf-1",
+		Type:    pb.OperationType_OPERATION_TYPE_REMOVAL,
+		Status:  pb.OperationStatus_OPERATION_STATUS_PENDING_REMOVAL_CONFIRMATION,
+		From:    from.Unix(),
+		Limit:   10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.TotalCount)
+	assert.Empty(t, resp.NextCursor)
+	if assert.Len(t, resp.Operations, 1) {
+		assert.Equal(t, "op1", resp.Operations[0].Id)
+		assert.Equal(t, pb.OperationType_OPERATION_TYPE_REMOVAL, resp.Operations[0].Type)
+		assert.Equal(t, pb.OperationStatus_OPERATION_STATUS_PENDING_REMOVAL_CONFIRMATION, resp.Operations[0].Status)
+		assert.Equal(t, from.Add(time.Hour).Unix(), resp.Operations[0].Timestamp)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestInventoryServer_UnknownEnumIsInvalidArgument(t *testing.T) {
+	mockRepo := new(MockOperationRepository)
+	server := handlers.NewInventoryServer(nil, nil, nil, nil, queries.NewGetOperationsQueryHandler(mockRepo))
+
+	_, err := server.GetOperationHistory(context.Background(), &pb.GetOperationHistoryRequest{Type: pb.OperationType(42)})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(grpcserver.ToStatusError(err)))
+	mockRepo.AssertNotCalled(t, "Find")
+}
@@ -16,8 +16,8 @@ import (
 // 	mock.Mock
 // }
 
-// func (m *MockInventoryService) HandleSlotError(ctx context.Context, slotID, errorType string) error {
-// 	args := m.Called(ctx, slotID, errorType)
+// func (m *MockInventoryService) HandleSlotError(ctx context.Context, slotID, errorType string, details map[string]interface{}) error {
+// 	args := m.Called(ctx, slotID, errorType, details)
 // 	return args.Error(0)
 // }
 
@@ -29,11 +29,12 @@ func TestHandleSlotErrorCommandHandler_Handle(t *testing.T) {
 	ctx := context.Background()
 	cmd := commands.HandleSlotErrorCommand{
 		SlotID:    "test-slot-id",
-		ErrorType: "sensor_error",
+		ErrorType: "jammed",
+		Details:   map[string]interface{}{"motor_current": 1.8},
 	}
 
 	// Expect the HandleSlotError method to be called once with the specified arguments
-	mockService.On("HandleSlotError", ctx, cmd.SlotID, cmd.ErrorType).Return(nil).Once()
+	mockService.On("HandleSlotError", ctx, cmd.SlotID, cmd.ErrorType, cmd.Details).Return(nil).Once()
 
 	// Act
 	err := handler.Handle(ctx, cmd)
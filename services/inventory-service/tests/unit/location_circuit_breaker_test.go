@@ -0,0 +1,50 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/infrastructure/location"
+)
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	breaker := location.NewCircuitBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, breaker.Allow())
+		breaker.Failure()
+	}
+	// a success resets the count
+	assert.NoError(t, breaker.Allow())
+	breaker.Success()
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, breaker.Allow())
+		breaker.Failure()
+	}
+	assert.ErrorIs(t, breaker.Allow(), location.ErrCircuitOpen)
+}
+
+func TestCircuitBreaker_LetsOneTrialCallThroughAfterTimeout(t *testing.T) {
+	breaker := location.NewCircuitBreaker(1, 20*time.Millisecond)
+
+	assert.NoError(t, breaker.Allow())
+	breaker.Failure()
+	assert.ErrorIs(t, breaker.Allow(), location.ErrCircuitOpen)
+
+	time.Sleep(30 * time.Millisecond)
+	assert.NoError(t, breaker.Allow())
+	// only the trial call is let through while it runs
+	assert.ErrorIs(t, breaker.Allow(), location.ErrCircuitOpen)
+
+	// a failed trial opens the circuit again
+	breaker.Failure()
+	assert.ErrorIs(t, breaker.Allow(), location.ErrCircuitOpen)
+
+	time.Sleep(30 * time.Millisecond)
+	assert.NoError(t, breaker.Allow())
+	breaker.Success()
+	assert.NoError(t, breaker.Allow())
+	assert.NoError(t, breaker.Allow())
+}
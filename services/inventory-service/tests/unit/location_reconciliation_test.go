@@ -0,0 +1,141 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+func TestReconcileLocationSlots_ReportsDrift(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	materialID := "mat-1"
+	m.slotRepo.On("ListShelfIDs", ctx).Return([]string{"shelf-1"}, nil)
+	m.slotRepo.On("GetByShelfID", ctx, "shelf-1").Return([]*entities.Slot{
+		{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusOccupied, MaterialID: &materialID},
+		{ID: "slot-2", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty},
+		{ID: "slot-3", ShelfID: "shelf-1", Status: entities.SlotStatusMaintenance},
+	}, nil)
+	m.alertRepo.On("GetActiveByShelfID", ctx, "shelf-1", entities.AlertTypeUnplannedChange).Return([]*entities.Alert{}, nil)
+	m.locationClient.On("GetShelfSlots", ctx, "shelf-1").Return([]*services.LocationSlot{
+		{ID: "slot-1", Status: "EMPTY"},
+		{ID: "slot-2", Status: "EMPTY"},
+		{ID: "slot-4", Status: "OCCUPIED", MaterialID: "mat-9"},
+	}, nil)
+
+	report, err := inventoryService.ReconcileLocationSlots(ctx, nil, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.DriftCount)
+	assert.Equal(t, 0, report.RepairedCount)
+	assert.Len(t, report.Shelves, 1)
+	assert.Equal(t, 3, report.Shelves[0].CheckedSlots)
+
+	drift := report.Shelves[0].Drift
+	assert.Equal(t, services.LocationDriftStatus, drift[0].Kind)
+	assert.Equal(t, "slot-1", drift[0].SlotID)
+	assert.Equal(t, "OCCUPIED", drift[0].ExpectedStatus)
+	assert.Equal(t, "EMPTY", drift[0].LocationStatus)
+	assert.Equal(t, services.LocationDriftMissingInLocation, drift[1].Kind)
+	assert.Equal(t, "slot-3", drift[1].SlotID)
+	assert.Equal(t, services.LocationDriftUnknownToInventory, drift[2].Kind)
+	assert.Equal(t, "slot-4", drift[2].SlotID)
+	m.locationClient.AssertNotCalled(t, "UpdateSlotStatus")
+}
+
+func TestReconcileLocationSlots_RepairsStatusDrift(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	slot := &entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty}
+	m.slotRepo.On("GetByShelfID", ctx, "shelf-1").Return([]*entities.Slot{slot}, nil)
+	m.alertRepo.On("GetActiveByShelfID", ctx, "shelf-1", entities.AlertTypeUnplannedChange).Return([]*entities.Alert{}, nil)
+	m.locationClient.On("GetShelfSlots", ctx, "shelf-1").Return([]*services.LocationSlot{
+		{ID: "slot-1", Status: "RESERVED"},
+	}, nil)
+	m.locationClient.On("UpdateSlotStatus", ctx, slot).Return(nil)
+
+	report, err := inventoryService.ReconcileLocationSlots(ctx, []string{"shelf-1"}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.DriftCount)
+	assert.Equal(t, 1, report.RepairedCount)
+	assert.True(t, report.Shelves[0].Drift[0].Repaired)
+	m.slotRepo.AssertNotCalled(t, "ListShelfIDs", ctx)
+}
+
+func TestReconcileLocationSlots_LeavesLiveAllocationsAlone(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	m.slotRepo.On("GetByShelfID", ctx, "shelf-1").Return([]*entities.Slot{
+		{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty},
+		{ID: "slot-2", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty},
+	}, nil)
+	m.alertRepo.On("GetActiveByShelfID", ctx, "shelf-1", entities.AlertTypeUnplannedChange).Return([]*entities.Alert{}, nil)
+	m.locationClient.On("GetShelfSlots", ctx, "shelf-1").Return([]*services.LocationSlot{
+		{ID: "slot-1", Status: "RESERVED", MaterialID: "mat-1", ReservedUntil: time.Now().Add(time.Minute)},
+		{ID: "slot-2", Status: "RESERVED", MaterialID: "mat-2", ReservedUntil: time.Now().Add(-time.Minute)},
+	}, nil)
+	m.locationClient.On("UpdateSlotStatus", ctx, mock.Anything).Return(nil)
+
+	report, err := inventoryService.ReconcileLocationSlots(ctx, []string{"shelf-1"}, true)
+
+	assert.NoError(t, err)
+	// only the allocation that ran out is drift
+	assert.Equal(t, 1, report.DriftCount)
+	assert.Equal(t, "slot-2", report.Shelves[0].Drift[0].SlotID)
+	m.locationClient.AssertNumberOfCalls(t, "UpdateSlotStatus", 1)
+}
+
+func TestReconcileLocationSlots_ShelfUnknownToLocation(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	m.slotRepo.On("GetByShelfID", ctx, "shelf-1").Return([]*entities.Slot{{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty}}, nil)
+	m.locationClient.On("GetShelfSlots", ctx, "shelf-1").Return(nil, nil)
+
+	report, err := inventoryService.ReconcileLocationSlots(ctx, []string{"shelf-1"}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.FailedShelves)
+	assert.Equal(t, 0, report.DriftCount)
+	assert.NotEmpty(t, report.Shelves[0].Error)
+}
+
+func TestReconcileLocationSlots_LeavesUnplannedChangesAlone(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	// a sensor found material in slot-1 without a placement behind it, location-service was told
+	m.slotRepo.On("GetByShelfID", ctx, "shelf-1").Return([]*entities.Slot{
+		{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty},
+		{ID: "slot-2", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty},
+	}, nil)
+	m.alertRepo.On("GetActiveByShelfID", ctx, "shelf-1", entities.AlertTypeUnplannedChange).Return([]*entities.Alert{
+		{ID: "alert-1", Type: entities.AlertTypeUnplannedChange, ShelfID: "shelf-1", SlotID: "slot-1", Status: entities.AlertStatusActive},
+	}, nil)
+	m.locationClient.On("GetShelfSlots", ctx, "shelf-1").Return([]*services.LocationSlot{
+		{ID: "slot-1", Status: "OCCUPIED", MaterialID: "mat-1"},
+		{ID: "slot-2", Status: "OCCUPIED", MaterialID: "mat-2"},
+	}, nil)
+	m.locationClient.On("UpdateSlotStatus", ctx, mock.Anything).Return(nil)
+
+	report, err := inventoryService.ReconcileLocationSlots(ctx, []string{"shelf-1"}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.DriftCount)
+	assert.Equal(t, "slot-2", report.Shelves[0].Drift[0].SlotID)
+	if assert.Len(t, report.Shelves[0].Unplanned, 1) {
+		assert.Equal(t, "slot-1", report.Shelves[0].Unplanned[0].SlotID)
+		assert.False(t, report.Shelves[0].Unplanned[0].Repaired)
+	}
+	m.locationClient.AssertNumberOfCalls(t, "UpdateSlotStatus", 1)
+	m.locationClient.AssertNotCalled(t, "UpdateSlotStatus", ctx, mock.MatchedBy(func(slot *entities.Slot) bool { return slot.ID == "slot-1" }))
+}
@@ -0,0 +1,213 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+// MockMaterialRepository is a mock type for the MaterialRepository
+type MockMaterialRepository struct {
+	mock.Mock
+}
+
+func (m *MockMaterialRepository) Create(ctx context.Context, material *entities.Material) error {
+	return m.Called(ctx, material).Error(0)
+}
+
+func (m *MockMaterialRepository) GetByID(ctx context.Context, id string) (*entities.Material, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Material), args.Error(1)
+}
+
+func (m *MockMaterialRepository) GetByBarcode(ctx context.Context, barcode string) (*entities.Material, error) {
+	args := m.Called(ctx, barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Material), args.Error(1)
+}
+
+func (m *MockMaterialRepository) Update(ctx context.Context, material *entities.Material) error {
+	return m.Called(ctx, material).Error(0)
+}
+
+func (m *MockMaterialRepository) UpdateWithTx(ctx context.Context, tx *gorm.DB, material *entities.Material) error {
+	return m.Called(ctx, tx, material).Error(0)
+}
+
+func (m *MockMaterialRepository) List(ctx context.Context, limit, offset int) ([]*entities.Material, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*entities.Material), args.Error(1)
+}
+
+func (m *MockMaterialRepository) Search(ctx context.Context, filter repositories.MaterialSearchFilter, limit, offset int) ([]*entities.Material, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Material), args.Error(1)
+}
+
+func (m *MockMaterialRepository) CountSearch(ctx context.Context, filter repositories.MaterialSearchFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMaterialRepository) SearchFacets(ctx context.Context, filter repositories.MaterialSearchFilter) (*repositories.MaterialFacets, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.MaterialFacets), args.Error(1)
+}
+
+// MockLocationClient is a mock type for the LocationClient
+type MockLocationClient struct {
+	mock.Mock
+}
+
+func (m *MockLocationClient) GetShelfZone(ctx context.Context, shelfID string) (string, error) {
+	args := m.Called(ctx, shelfID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockLocationClient) SuggestPlacement(ctx context.Context, req services.PlacementRequest) ([]*services.PlacementSuggestion, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*services.PlacementSuggestion), args.Error(1)
+}
+
+func (m *MockLocationClient) UpdateSlotStatus(ctx context.Context, slot *entities.Slot) error {
+	return m.Called(ctx, slot).Error(0)
+}
+
+func (m *MockLocationClient) GetShelfSlots(ctx context.Context, shelfID string) ([]*services.LocationSlot, error) {
+	args := m.Called(ctx, shelfID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*services.LocationSlot), args.Error(1)
+}
+
+type traceMocks struct {
+	materialRepo   *MockMaterialRepository
+	slotRepo       *MockSlotRepository
+	operationRepo  *MockOperationRepository
+	transitionRepo *MockOperationTransitionRepository
+	alertRepo      *MockAlertRepository
+	locationClient *MockLocationClient
+}
+
+func newTraceService() (*services.InventoryService, *traceMocks) {
+	m := &traceMocks{
+		materialRepo:   new(MockMaterialRepository),
+		slotRepo:       new(MockSlotRepository),
+		operationRepo:  new(MockOperationRepository),
+		transitionRepo: new(MockOperationTransitionRepository),
+		alertRepo:      new(MockAlertRepository),
+		locationClient: new(MockLocationClient),
+	}
+	inventoryService := services.NewInventoryService(
+		m.materialRepo, m.slotRepo, m.operationRepo, m.transitionRepo, m.alertRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		m.locationClient,
+		nil,
+	)
+	return inventoryService, m
+}
+
+func TestTraceMaterial_ReturnsLocationAndHistory(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	placedAt := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	movedAt := placedAt.Add(2 * time.Hour)
+	materialID := "mat-1"
+
+	m.materialRepo.On("GetByBarcode", ctx, "MAT000123").Return(&entities.Material{ID: materialID, Barcode: "MAT000123"}, nil)
+	m.transitionRepo.On("GetByMaterialID", ctx, materialID).Return([]*entities.OperationTransition{
+		{ID: 1, OperationID: "op-place", ToStatus: entities.OperationStatusRequested, SlotID: "slot-1", OccurredAt: placedAt},
+		{ID: 2, OperationID: "op-place", ToStatus: entities.OperationStatusPendingPhysicalConfirmation, SlotID: "slot-1", OccurredAt: placedAt},
+		{ID: 3, OperationID: "op-place", ToStatus: entities.OperationStatusCompleted, SlotID: "slot-1", OccurredAt: placedAt.Add(time.Minute)},
+		{ID: 4, OperationID: "op-move", ToStatus: entities.OperationStatusRequested, SlotID: "slot-7", FromSlotID: "slot-1", OccurredAt: movedAt},
+		{ID: 5, OperationID: "op-move", ToStatus: entities.OperationStatusCompleted, SlotID: "slot-7", FromSlotID: "slot-1", OccurredAt: movedAt},
+	}, nil)
+	m.operationRepo.On("Find", ctx, mock.MatchedBy(func(options repositories.OperationListOptions) bool {
+		return options.Filter.MaterialID == materialID && !options.Descending
+	})).Return([]*entities.Operation{
+		{ID: "op-place", Type: entities.OperationTypePlacement, Status: entities.OperationStatusCompleted, SlotID: "slot-1", ShelfID: "SHELF001", Timestamp: placedAt.Add(time.Minute)},
+		{ID: "op-move", Type: entities.OperationTypeMove, Status: entities.OperationStatusCompleted, SlotID: "slot-7", ShelfID: "SHELF002", Timestamp: movedAt},
+	}, nil).Once()
+	m.slotRepo.On("GetByMaterialID", ctx, materialID).Return(&entities.Slot{ID: "slot-7", ShelfID: "SHELF002", Row: 2, Column: 3, Status: entities.SlotStatusOccupied}, nil)
+	m.locationClient.On("GetShelfZone", ctx, "SHELF002").Return("ZONE-A", nil)
+
+	trace, err := inventoryService.TraceMaterial(ctx, "MAT000123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "slot-7", trace.Current.SlotID)
+	assert.Equal(t, "ZONE-A", trace.Current.ZoneID)
+	assert.Equal(t, movedAt, *trace.Current.Since)
+	if assert.Equal(t, 2, len(trace.History)) {
+		assert.Equal(t, "slot-1", trace.History[0].ToSlotID)
+		assert.Empty(t, trace.History[0].FromSlotID)
+		assert.Equal(t, placedAt, *trace.History[0].RequestedAt)
+		assert.Equal(t, "slot-1", trace.History[1].FromSlotID)
+		assert.Equal(t, "slot-7", trace.History[1].ToSlotID)
+	}
+	m.operationRepo.AssertExpectations(t)
+}
+
+func TestTraceMaterial_WithoutZoneWhenLocationServiceFails(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	m.materialRepo.On("GetByBarcode", ctx, "MAT000124").Return(&entities.Material{ID: "mat-2", Barcode: "MAT000124"}, nil)
+	m.transitionRepo.On("GetByMaterialID", ctx, "mat-2").Return([]*entities.OperationTransition{}, nil)
+	// operations recorded before transitions were kept have no timeline
+	m.operationRepo.On("Find", ctx, mock.Anything).Return([]*entities.Operation{
+		{ID: "op-old", Type: entities.OperationTypePlacement, Status: entities.OperationStatusCompleted, SlotID: "slot-3", ShelfID: "SHELF003"},
+	}, nil).Once()
+	m.slotRepo.On("GetByMaterialID", ctx, "mat-2").Return(&entities.Slot{ID: "slot-3", ShelfID: "SHELF003"}, nil)
+	m.locationClient.On("GetShelfZone", ctx, "SHELF003").Return("", errors.New("unavailable"))
+
+	trace, err := inventoryService.TraceMaterial(ctx, "MAT000124")
+
+	assert.NoError(t, err)
+	assert.Empty(t, trace.Current.ZoneID)
+	assert.Nil(t, trace.History[0].RequestedAt)
+}
+
+func TestTraceMaterial_MaterialNotOnAShelf(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	m.materialRepo.On("GetByBarcode", ctx, "MAT000125").Return(&entities.Material{ID: "mat-3", Barcode: "MAT000125"}, nil)
+	m.transitionRepo.On("GetByMaterialID", ctx, "mat-3").Return([]*entities.OperationTransition{}, nil)
+	m.operationRepo.On("Find", ctx, mock.Anything).Return([]*entities.Operation{}, nil).Once()
+	m.slotRepo.On("GetByMaterialID", ctx, "mat-3").Return(nil, nil)
+
+	trace, err := inventoryService.TraceMaterial(ctx, "MAT000125")
+
+	assert.NoError(t, err)
+	assert.Nil(t, trace.Current)
+	assert.Empty(t, trace.History)
+	m.locationClient.AssertNotCalled(t, "GetShelfZone", mock.Anything, mock.Anything)
+
+	m.materialRepo.On("GetByBarcode", ctx, "UNKNOWN").Return(nil, gorm.ErrRecordNotFound)
+	_, err = inventoryService.TraceMaterial(ctx, "UNKNOWN")
+	assert.Error(t, err)
+}
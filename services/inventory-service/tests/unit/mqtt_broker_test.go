@@ -0,0 +1,333 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	appcommands "WMS/services/inventory-service/internal/application/commands"
+	"WMS/services/inventory-service/internal/config"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/infrastructure/messaging"
+	"WMS/services/inventory-service/internal/infrastructure/mqttbroker"
+	"WMS/services/inventory-service/internal/interfaces/mqtt"
+	"WMS/services/inventory-service/internal/simulator"
+)
+
+// MockShelfStateRepository is a mock type for the ShelfStateRepository
+type MockShelfStateRepository struct {
+	mock.Mock
+}
+
+func (m *MockShelfStateRepository) GetByShelfID(ctx context.Context, shelfID string) (*entities.ShelfState, error) {
+	args := m.Called(ctx, shelfID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ShelfState), args.Error(1)
+}
+
+func (m *MockShelfStateRepository) Save(ctx context.Context, state *entities.ShelfState) error {
+	return m.Called(ctx, state).Error(0)
+}
+
+func (m *MockShelfStateRepository) TouchHeartbeat(ctx context.Context, shelfID string, at time.Time) error {
+	return m.Called(ctx, shelfID, at).Error(0)
+}
+
+func (m *MockShelfStateRepository) ListStale(ctx context.Context, lastHeartbeatBefore time.Time) ([]*entities.ShelfState, error) {
+	args := m.Called(ctx, lastHeartbeatBefore)
+	return args.Get(0).([]*entities.ShelfState), args.Error(1)
+}
+
+// fakeTx stands in for a database transaction, the mocked repositories ignore it
+type fakeTx struct {
+	gorm.ConnPool
+	committed  chan struct{}
+	rolledBack chan struct{}
+}
+
+func (tx *fakeTx) Commit() error {
+	close(tx.committed)
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	close(tx.rolledBack)
+	return nil
+}
+
+func newFakeTx() (*gorm.DB, *fakeTx) {
+	tx := &fakeTx{committed: make(chan struct{}), rolledBack: make(chan struct{})}
+	return &gorm.DB{Config: &gorm.Config{}, Statement: &gorm.Statement{ConnPool: tx}}, tx
+}
+
+type brokerMocks struct {
+	materialRepo     *MockMaterialRepository
+	slotRepo         *MockSlotRepository
+	operationRepo    *MockOperationRepository
+	transitionRepo   *MockOperationTransitionRepository
+	shelfStateRepo   *MockShelfStateRepository
+	shelfCommandRepo *MockShelfCommandRepository
+	bus              *messaging.MemoryBus
+}
+
+// startTestBroker starts the embedded broker on a free port, so the tests need no external broker
+func startTestBroker(t *testing.T) (config.MQTTConfig, mqtt.Topics) {
+	cfg := config.MQTTConfig{
+		EmbeddedBroker:          true,
+		EmbeddedBrokerAddress:   "127.0.0.1:0",
+		QoS:                     1,
+		TopicPrefix:             "test/shelf",
+		SharedSubscriptionGroup: "inventory",
+	}
+	broker, err := mqttbroker.Start(cfg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { broker.Close() })
+	cfg.BrokerURL = broker.URL()
+
+	topics, err := mqtt.NewTopics(cfg)
+	assert.NoError(t, err)
+	return cfg, topics
+}
+
+func connectTestClient(t *testing.T, cfg config.MQTTConfig, clientID string) paho.Client {
+	cfg.ClientID = clientID
+	client, err := mqtt.NewClient(cfg)
+	assert.NoError(t, err)
+
+	token := client.Connect()
+	assert.True(t, token.WaitTimeout(5*time.Second))
+	assert.NoError(t, token.Error())
+	t.Cleanup(func() { client.Disconnect(100) })
+	return client
+}
+
+// startTestSimulator runs one simulated shelf, SHELF001, against the broker until the test ends
+func startTestSimulator(t *testing.T, cfg config.MQTTConfig, topics mqtt.Topics) *simulator.Simulator {
+	shelfClient := connectTestClient(t, cfg, "test-shelf")
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	sim := simulator.New(simulator.Config{ShelfCount: 1, HeartbeatInterval: time.Hour}, simulator.NewMQTTTransport(shelfClient, cfg.QoS), topics)
+	go sim.Run(ctx)
+	// give the simulated shelf time to subscribe to its command topic
+	time.Sleep(200 * time.Millisecond)
+	return sim
+}
+
+// startTestHandler connects an MQTTHandler backed by mocked repositories to the broker
+func startTestHandler(t *testing.T, cfg config.MQTTConfig, topics mqtt.Topics) (*services.ShelfCommandService, *brokerMocks) {
+	m := &brokerMocks{
+		materialRepo:     new(MockMaterialRepository),
+		slotRepo:         new(MockSlotRepository),
+		operationRepo:    new(MockOperationRepository),
+		transitionRepo:   new(MockOperationTransitionRepository),
+		shelfStateRepo:   new(MockShelfStateRepository),
+		shelfCommandRepo: new(MockShelfCommandRepository),
+		bus:              messaging.NewMemoryBus(),
+	}
+	// every message from a shelf counts as a heartbeat
+	m.shelfStateRepo.On("GetByShelfID", mock.Anything, "SHELF001").Return(nil, nil)
+	m.shelfStateRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	cfg.ClientID = "test-inventory"
+	serviceClient, err := mqtt.NewClient(cfg)
+	assert.NoError(t, err)
+	shelfCommandService := services.NewShelfCommandService(m.shelfCommandRepo, mqtt.NewShelfCommandPublisher(serviceClient, topics, cfg.QoS))
+
+	// nothing listens there, so shelf events are processed unguarded
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	retryService := services.NewRetryService(0, time.Millisecond)
+	inventoryService := services.NewInventoryService(
+		m.materialRepo, m.slotRepo, m.operationRepo, m.transitionRepo, nil,
+		services.NewLockService(redisClient),
+		services.NewEventService(m.bus, "inventory_events", nil),
+		nil, nil, nil,
+		retryService,
+		nil, nil, nil,
+		m.shelfStateRepo,
+		shelfCommandService,
+		nil, nil,
+	)
+
+	handler := mqtt.NewMQTTHandler(
+		serviceClient,
+		topics,
+		cfg.QoS,
+		appcommands.NewPlaceMaterialCommandHandler(inventoryService),
+		appcommands.NewRemoveMaterialCommandHandler(inventoryService),
+		appcommands.NewHandleSlotErrorCommandHandler(inventoryService),
+		appcommands.NewUpdateShelfStatusCommandHandler(inventoryService),
+		inventoryService,
+		nil,
+		services.NewEventGuardService(redisClient, m.slotRepo, time.Minute, 2*time.Second),
+		shelfCommandService,
+		retryService,
+	)
+	if !assert.NoError(t, handler.Connect()) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { serviceClient.Disconnect(100) })
+	return shelfCommandService, m
+}
+
+// recordShelfCommands stores created shelf commands and reports every update of them
+func recordShelfCommands(repo *MockShelfCommandRepository) <-chan entities.ShelfCommand {
+	updates := make(chan entities.ShelfCommand, 10)
+	repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		// the acknowledgement works on a copy, like it would on a row read back from the database
+		stored := *args.Get(1).(*entities.ShelfCommand)
+		repo.On("GetByID", mock.Anything, stored.ID).Return(&stored, nil)
+	}).Return(nil)
+	repo.On("MarkSent", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	repo.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		updates <- *args.Get(1).(*entities.ShelfCommand)
+	}).Return(nil)
+	return updates
+}
+
+func awaitShelfCommandStatus(t *testing.T, updates <-chan entities.ShelfCommand, commandID string, status entities.ShelfCommandStatus) {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case command := <-updates:
+			if command.ID == commandID && command.Status == status {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("shelf command %s did not become %s", commandID, status)
+		}
+	}
+}
+
+func awaitPublishedEvent(t *testing.T, bus *messaging.MemoryBus, eventType string) {
+	assert.Eventually(t, func() bool {
+		for _, published := range publishedEventTypes(bus) {
+			if published == eventType {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 20*time.Millisecond, "no %s event published", eventType)
+}
+
+func TestEmbeddedBroker_ShelfCommandRoundTrip(t *testing.T) {
+	cfg, topics := startTestBroker(t)
+	shelfCommandService, m := startTestHandler(t, cfg, topics)
+	updates := recordShelfCommands(m.shelfCommandRepo)
+	startTestSimulator(t, cfg, topics)
+
+	command, err := shelfCommandService.SendCommand(context.Background(), services.SendShelfCommandParams{
+		ShelfID: "SHELF001",
+		SlotID:  "SHELF001-R01-C01",
+		Type:    entities.ShelfCommandLightUpSlot,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, entities.ShelfCommandStatusSent, command.Status)
+
+	// the simulated shelf acknowledges, and the handler records it
+	awaitShelfCommandStatus(t, updates, command.ID, entities.ShelfCommandStatusAcknowledged)
+}
+
+func TestEmbeddedBroker_ConfirmsPhysicalPlacement(t *testing.T) {
+	cfg, topics := startTestBroker(t)
+	_, m := startTestHandler(t, cfg, topics)
+	sim := startTestSimulator(t, cfg, topics)
+
+	operation := &entities.Operation{
+		ID:         "op-place",
+		Type:       entities.OperationTypePlacement,
+		Status:     entities.OperationStatusPendingPhysicalConfirmation,
+		MaterialID: "mat-1",
+		SlotID:     "SHELF001-R01-C02",
+		ShelfID:    "SHELF001",
+	}
+	tx, fake := newFakeTx()
+	m.operationRepo.On("GetPendingPhysicalConfirmationsBySlotID", mock.Anything, operation.SlotID).Return([]*entities.Operation{operation}, nil).Once()
+	m.materialRepo.On("GetByBarcode", mock.Anything, "MAT000001").Return(&entities.Material{ID: "mat-1", Barcode: "MAT000001"}, nil)
+	m.operationRepo.On("GetByID", mock.Anything, operation.ID).Return(operation, nil)
+	m.operationRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+	m.operationRepo.On("TransitionStatusWithTx", mock.Anything, tx, operation.ID, entities.OperationStatusPendingPhysicalConfirmation, entities.OperationStatusCompleted).Return(true, nil)
+	m.transitionRepo.On("CreateWithTx", mock.Anything, tx, mock.MatchedBy(func(transition *entities.OperationTransition) bool {
+		return transition.OperationID == operation.ID && transition.ToStatus == entities.OperationStatusCompleted
+	})).Return(nil)
+
+	// the event the service publishes once the material may be put into the slot
+	sim.HandleInventoryEvent(context.Background(), []byte(`{
+		"event_type": "physical.placement.requested",
+		"shelf_id": "SHELF001",
+		"slot_id": "SHELF001-R01-C02",
+		"material_barcode": "MAT000001"
+	}`))
+
+	// the shelf detects the material and the handler confirms the operation
+	select {
+	case <-fake.committed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("physical placement was not confirmed")
+	}
+	awaitPublishedEvent(t, m.bus, services.EventTypePhysicalPlacementConfirmed)
+	m.transitionRepo.AssertExpectations(t)
+}
+
+func TestEmbeddedBroker_ConfirmsPhysicalRemoval(t *testing.T) {
+	cfg, topics := startTestBroker(t)
+	shelfCommandService, m := startTestHandler(t, cfg, topics)
+	updates := recordShelfCommands(m.shelfCommandRepo)
+	startTestSimulator(t, cfg, topics)
+
+	materialID := "mat-2"
+	operation := &entities.Operation{
+		ID:         "op-remove",
+		Type:       entities.OperationTypeRemoval,
+		Status:     entities.OperationStatusPendingRemovalConfirmation,
+		MaterialID: materialID,
+		SlotID:     "SHELF001-R01-C03",
+		ShelfID:    "SHELF001",
+	}
+	slot := &entities.Slot{ID: operation.SlotID, ShelfID: "SHELF001", Status: entities.SlotStatusOccupied, MaterialID: &materialID}
+	tx, fake := newFakeTx()
+	m.operationRepo.On("GetPendingRemovalConfirmationsBySlotID", mock.Anything, operation.SlotID).Return([]*entities.Operation{operation}, nil).Once()
+	m.operationRepo.On("GetByID", mock.Anything, operation.ID).Return(operation, nil)
+	m.operationRepo.On("BeginTx", mock.Anything).Return(tx, nil)
+	m.slotRepo.On("GetByID", mock.Anything, slot.ID).Return(slot, nil)
+	m.slotRepo.On("UpdateWithTx", mock.Anything, tx, mock.MatchedBy(func(updated *entities.Slot) bool {
+		return updated.Status == entities.SlotStatusEmpty && updated.MaterialID == nil
+	})).Return(nil)
+	m.materialRepo.On("GetByID", mock.Anything, materialID).Return(&entities.Material{ID: materialID, Status: entities.MaterialStatusInUse}, nil)
+	m.materialRepo.On("UpdateWithTx", mock.Anything, tx, mock.Anything).Return(nil)
+	m.operationRepo.On("TransitionStatusWithTx", mock.Anything, tx, operation.ID, entities.OperationStatusPendingRemovalConfirmation, entities.OperationStatusCompleted).Return(true, nil)
+	m.transitionRepo.On("CreateWithTx", mock.Anything, tx, mock.Anything).Return(nil)
+
+	// the worker is guided to the slot, then the shelf reports the material gone
+	command, err := shelfCommandService.SendCommand(context.Background(), services.SendShelfCommandParams{
+		ShelfID:     "SHELF001",
+		SlotID:      operation.SlotID,
+		Type:        entities.ShelfCommandLightUpSlot,
+		Params:      map[string]interface{}{"purpose": string(entities.OperationTypeRemoval)},
+		OperationID: operation.ID,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	awaitShelfCommandStatus(t, updates, command.ID, entities.ShelfCommandStatusAcknowledged)
+
+	select {
+	case <-fake.committed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("physical removal was not confirmed")
+	}
+	awaitPublishedEvent(t, m.bus, services.EventTypePhysicalRemovalConfirmed)
+	m.slotRepo.AssertExpectations(t)
+}
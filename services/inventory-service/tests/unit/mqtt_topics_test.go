@@ -0,0 +1,44 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/config"
+	"WMS/services/inventory-service/internal/interfaces/mqtt"
+)
+
+func TestNewTopics_Defaults(t *testing.T) {
+	topics, err := mqtt.NewTopics(config.MQTTConfig{TopicPrefix: "warehouse/shelf/"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "warehouse/shelf/{shelf_id}/events", topics.Event)
+	assert.Equal(t, "warehouse/shelf/+/events", topics.Subscription(topics.Event))
+	assert.Equal(t, "warehouse/shelf/A1/commands", topics.ForShelf(topics.Command, "A1"))
+	assert.Equal(t, "warehouse/shelf/+/commands/ack", topics.Subscription(topics.CommandAck))
+}
+
+func TestNewTopics_SharedSubscriptionAndCustomLayout(t *testing.T) {
+	topics, err := mqtt.NewTopics(config.MQTTConfig{
+		TopicPrefix:             "site-1",
+		EventTopic:              "{prefix}/events/{shelf_id}",
+		SharedSubscriptionGroup: "inventory",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "$share/inventory/site-1/events/+", topics.Subscription(topics.Event))
+	assert.Equal(t, "$share/inventory/site-1/+/status", topics.Subscription(topics.Status))
+	// publishing never goes through the shared group
+	assert.Equal(t, "site-1/B2/commands", topics.ForShelf(topics.Command, "B2"))
+}
+
+func TestNewTopics_InvalidTemplates(t *testing.T) {
+	_, err := mqtt.NewTopics(config.MQTTConfig{EventTopic: "shelves/events"})
+	assert.Error(t, err)
+
+	_, err = mqtt.NewTopics(config.MQTTConfig{StatusTopic: "shelves/+/{shelf_id}/status"})
+	assert.Error(t, err)
+
+	_, err = mqtt.NewTopics(config.MQTTConfig{SharedSubscriptionGroup: "a/b"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+// MockOperationTransitionRepository is a mock type for the OperationTransitionRepository
+type MockOperationTransitionRepository struct {
+	mock.Mock
+}
+
+func (m *MockOperationTransitionRepository) CreateWithTx(ctx context.Context, tx *gorm.DB, transition *entities.OperationTransition) error {
+	return m.Called(ctx, tx, transition).Error(0)
+}
+
+func (m *MockOperationTransitionRepository) GetByOperationID(ctx context.Context, operationID string) ([]*entities.OperationTransition, error) {
+	args := m.Called(ctx, operationID)
+	return args.Get(0).([]*entities.OperationTransition), args.Error(1)
+}
+
+func (m *MockOperationTransitionRepository) GetByMaterialID(ctx context.Context, materialID string) ([]*entities.OperationTransition, error) {
+	args := m.Called(ctx, materialID)
+	return args.Get(0).([]*entities.OperationTransition), args.Error(1)
+}
+
+func (m *MockOperationTransitionRepository) ListAfter(ctx context.Context, afterID int64, limit int) ([]*entities.OperationTransition, error) {
+	args := m.Called(ctx, afterID, limit)
+	return args.Get(0).([]*entities.OperationTransition), args.Error(1)
+}
+
+// placementLog is the log of MAT-1 placed into slot-1 and confirmed, MAT-2 placed into slot-2 and timed out,
+// and MAT-1 moved on to slot-3 and then removed from there
+func placementLog() []*entities.OperationTransition {
+	return []*entities.OperationTransition{
+		{ID: 1, OperationID: "op-1", OperationType: entities.OperationTypePlacement, ToStatus: entities.OperationStatusRequested, MaterialID: "MAT-1", SlotID: "slot-1"},
+		{ID: 2, OperationID: "op-1", OperationType: entities.OperationTypePlacement, FromStatus: entities.OperationStatusRequested, ToStatus: entities.OperationStatusPendingPhysicalConfirmation, MaterialID: "MAT-1", SlotID: "slot-1"},
+		{ID: 3, OperationID: "op-2", OperationType: entities.OperationTypePlacement, ToStatus: entities.OperationStatusRequested, MaterialID: "MAT-2", SlotID: "slot-2"},
+		{ID: 4, OperationID: "op-2", OperationType: entities.OperationTypePlacement, FromStatus: entities.OperationStatusRequested, ToStatus: entities.OperationStatusPendingPhysicalConfirmation, MaterialID: "MAT-2", SlotID: "slot-2"},
+		{ID: 5, OperationID: "op-1", OperationType: entities.OperationTypePlacement, FromStatus: entities.OperationStatusPendingPhysicalConfirmation, ToStatus: entities.OperationStatusCompleted, MaterialID: "MAT-1", SlotID: "slot-1"},
+		{ID: 6, OperationID: "op-2", OperationType: entities.OperationTypePlacement, FromStatus: entities.OperationStatusPendingPhysicalConfirmation, ToStatus: entities.OperationStatusFailed, MaterialID: "MAT-2", SlotID: "slot-2"},
+		{ID: 7, OperationID: "op-3", OperationType: entities.OperationTypeMove, ToStatus: entities.OperationStatusRequested, MaterialID: "MAT-1", SlotID: "slot-3", FromSlotID: "slot-1"},
+		{ID: 8, OperationID: "op-3", OperationType: entities.OperationTypeMove, FromStatus: entities.OperationStatusRequested, ToStatus: entities.OperationStatusCompleted, MaterialID: "MAT-1", SlotID: "slot-3", FromSlotID: "slot-1"},
+		{ID: 9, OperationID: "op-4", OperationType: entities.OperationTypeRemoval, ToStatus: entities.OperationStatusRequested, MaterialID: "MAT-1", SlotID: "slot-3"},
+		{ID: 10, OperationID: "op-4", OperationType: entities.OperationTypeRemoval, FromStatus: entities.OperationStatusRequested, ToStatus: entities.OperationStatusPendingRemovalConfirmation, MaterialID: "MAT-1", SlotID: "slot-3"},
+	}
+}
+
+func TestOccupancyProjection_ReplaysTransitions(t *testing.T) {
+	projection := services.NewOccupancyProjection()
+	for _, transition := range placementLog() {
+		projection.Apply(transition)
+	}
+
+	assert.Equal(t, entities.SlotStatusEmpty, projection.Slot("slot-1").Status)
+	assert.Empty(t, projection.Slot("slot-1").MaterialID)
+	assert.Equal(t, entities.SlotStatusEmpty, projection.Slot("slot-2").Status)
+	assert.Equal(t, entities.SlotStatusRemovalPending, projection.Slot("slot-3").Status)
+	assert.Equal(t, "MAT-1", projection.Slot("slot-3").MaterialID)
+	assert.Equal(t, int64(10), projection.Slot("slot-3").LastTransitionID)
+
+	// slots without operations are empty
+	assert.Equal(t, entities.SlotStatusEmpty, projection.Slot("slot-9").Status)
+	assert.Equal(t, 3, len(projection.Slots()))
+
+	// the removal is confirmed by the shelf
+	projection.Apply(&entities.OperationTransition{ID: 11, OperationID: "op-4", OperationType: entities.OperationTypeRemoval, FromStatus: entities.OperationStatusPendingRemovalConfirmation, ToStatus: entities.OperationStatusCompleted, MaterialID: "MAT-1", SlotID: "slot-3"})
+	assert.Equal(t, entities.SlotStatusEmpty, projection.Slot("slot-3").Status)
+}
+
+func TestRebuildSlotOccupancy_ReadsTheLogInBatches(t *testing.T) {
+	log := placementLog()
+	transitionRepo := new(MockOperationTransitionRepository)
+	transitionRepo.On("ListAfter", mock.Anything, int64(0), 4).Return(log[0:4], nil).Once()
+	transitionRepo.On("ListAfter", mock.Anything, int64(4), 4).Return(log[4:8], nil).Once()
+	transitionRepo.On("ListAfter", mock.Anything, int64(8), 4).Return(log[8:], nil).Once()
+
+	projection, err := services.RebuildSlotOccupancy(context.Background(), transitionRepo, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "MAT-1", projection.Slot("slot-3").MaterialID)
+	transitionRepo.AssertExpectations(t)
+
+	_, err = services.RebuildSlotOccupancy(context.Background(), transitionRepo, 0)
+	assert.Error(t, err)
+}
+
+func TestCompareSlotOccupancy_ReportsDrift(t *testing.T) {
+	projection := services.NewOccupancyProjection()
+	for _, transition := range placementLog() {
+		projection.Apply(transition)
+	}
+
+	material1, material2 := "MAT-1", "MAT-2"
+	slots := []*entities.Slot{
+		{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty},
+		// still holds the material of the timed out placement
+		{ID: "slot-2", ShelfID: "shelf-1", Status: entities.SlotStatusOccupied, MaterialID: &material2},
+		{ID: "slot-3", ShelfID: "shelf-1", Status: entities.SlotStatusRemovalPending, MaterialID: &material1},
+		// maintenance is not driven by operations, only the material is compared
+		{ID: "slot-4", ShelfID: "shelf-2", Status: entities.SlotStatusMaintenance},
+	}
+
+	drift := services.CompareSlotOccupancy(projection, slots)
+	if assert.Equal(t, 1, len(drift)) {
+		assert.Equal(t, "slot-2", drift[0].SlotID)
+		assert.Equal(t, entities.SlotStatusOccupied, drift[0].StoredStatus)
+		assert.Equal(t, "MAT-2", drift[0].StoredMaterialID)
+		assert.Equal(t, entities.SlotStatusEmpty, drift[0].RebuiltStatus)
+		assert.Empty(t, drift[0].RebuiltMaterialID)
+	}
+}
@@ -0,0 +1,33 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+	apperrors "WMS/services/inventory-service/pkg/errors"
+)
+
+func TestConfirmPhysicalPlacement_LosesTheRaceAgainstTheTimeout(t *testing.T) {
+	inventoryService, m := newTraceService()
+	ctx := context.Background()
+
+	operation := &entities.Operation{ID: "op-1", Type: entities.OperationTypePlacement, Status: entities.OperationStatusPendingPhysicalConfirmation, MaterialID: "mat-1", SlotID: "slot-1", ShelfID: "shelf-1"}
+	tx, fake := newFakeTx()
+	m.operationRepo.On("GetByID", ctx, operation.ID).Return(operation, nil)
+	m.operationRepo.On("BeginTx", ctx).Return(tx, nil)
+	// the timeout failed the operation after it was read
+	m.operationRepo.On("TransitionStatusWithTx", ctx, tx, operation.ID, entities.OperationStatusPendingPhysicalConfirmation, entities.OperationStatusCompleted).Return(false, nil)
+
+	err := inventoryService.ConfirmPhysicalPlacement(ctx, operation.ID)
+
+	var conflict *apperrors.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+	select {
+	case <-fake.rolledBack:
+	default:
+		t.Fatal("transaction was not rolled back")
+	}
+	m.transitionRepo.AssertNotCalled(t, "CreateWithTx")
+}
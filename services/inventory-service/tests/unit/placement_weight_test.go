@@ -0,0 +1,224 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"WMS/shared/events"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/infrastructure/messaging"
+)
+
+// MockAlertRepository is a mock type for the AlertRepository
+type MockAlertRepository struct {
+	mock.Mock
+}
+
+func (m *MockAlertRepository) Create(ctx context.Context, alert *entities.Alert) error {
+	return m.Called(ctx, alert).Error(0)
+}
+
+func (m *MockAlertRepository) GetByID(ctx context.Context, id string) (*entities.Alert, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.Alert), args.Error(1)
+}
+
+func (m *MockAlertRepository) GetActiveAlerts(ctx context.Context, limit, offset int) ([]*entities.Alert, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*entities.Alert), args.Error(1)
+}
+
+func (m *MockAlertRepository) GetByShelfID(ctx context.Context, shelfID string, limit, offset int) ([]*entities.Alert, error) {
+	args := m.Called(ctx, shelfID, limit, offset)
+	return args.Get(0).([]*entities.Alert), args.Error(1)
+}
+
+func (m *MockAlertRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	return m.Called(ctx, id, status).Error(0)
+}
+
+func (m *MockAlertRepository) MarkAsResolved(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockAlertRepository) List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entities.Alert, error) {
+	args := m.Called(ctx, filters, limit, offset)
+	return args.Get(0).([]*entities.Alert), args.Error(1)
+}
+
+func (m *MockAlertRepository) GetActiveByShelfID(ctx context.Context, shelfID string, alertType entities.AlertType) ([]*entities.Alert, error) {
+	args := m.Called(ctx, shelfID, alertType)
+	return args.Get(0).([]*entities.Alert), args.Error(1)
+}
+
+type weightMocks struct {
+	*traceMocks
+	sensorReadingRepo *MockSensorReadingRepository
+	commandRepo       *MockShelfCommandRepository
+	commandPublisher  *MockShelfCommandPublisher
+	bus               *messaging.MemoryBus
+}
+
+// newWeightService returns an inventory service that verifies detections against the readings of sensorReadingRepo
+func newWeightService() (*services.InventoryService, *weightMocks) {
+	m := &weightMocks{
+		traceMocks: &traceMocks{
+			materialRepo:   new(MockMaterialRepository),
+			slotRepo:       new(MockSlotRepository),
+			operationRepo:  new(MockOperationRepository),
+			transitionRepo: new(MockOperationTransitionRepository),
+			alertRepo:      new(MockAlertRepository),
+		},
+		sensorReadingRepo: new(MockSensorReadingRepository),
+		commandRepo:       new(MockShelfCommandRepository),
+		commandPublisher:  new(MockShelfCommandPublisher),
+		bus:               messaging.NewMemoryBus(),
+	}
+	inventoryService := services.NewInventoryService(
+		m.materialRepo, m.slotRepo, m.operationRepo, m.transitionRepo, m.alertRepo,
+		nil,
+		services.NewEventService(m.bus, "inventory_events", nil),
+		nil, nil, nil, nil, nil,
+		services.NewTelemetryService(m.sensorReadingRepo),
+		nil, nil,
+		services.NewShelfCommandService(m.commandRepo, m.commandPublisher),
+		nil,
+		nil,
+	)
+	return inventoryService, m
+}
+
+func TestHandleMaterialDetectedEvent_VerifiesPlacementWeight(t *testing.T) {
+	detectedAt := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	weight := func(w float64) *float64 { return &w }
+
+	tests := []struct {
+		name         string
+		material     entities.Material
+		baseline     *float64 // last reading before the detection, nil if the slot has none
+		measured     *float64
+		wantMismatch bool
+	}{
+		{name: "added weight matches", material: entities.Material{UnitWeight: 50}, baseline: weight(100), measured: weight(151)},
+		{name: "added weight is off", material: entities.Material{UnitWeight: 50}, baseline: weight(100), measured: weight(180), wantMismatch: true},
+		{name: "explicit tolerance", material: entities.Material{UnitWeight: 50, WeightTolerance: 2}, baseline: weight(100), measured: weight(153), wantMismatch: true},
+		{name: "default tolerance is a share of the unit weight", material: entities.Material{UnitWeight: 100}, baseline: weight(0), measured: weight(104)},
+		{name: "a slot without readings is assumed empty", material: entities.Material{UnitWeight: 50}, measured: weight(50)},
+		{name: "a slot without readings still catches a wrong material", material: entities.Material{UnitWeight: 50}, measured: weight(500), wantMismatch: true},
+		{name: "events without a weight are not verified", material: entities.Material{UnitWeight: 50}, baseline: weight(100)},
+		{name: "materials without a unit weight are not verified", material: entities.Material{}, baseline: weight(100), measured: weight(900)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inventoryService, m := newWeightService()
+			ctx := context.Background()
+
+			material := tt.material
+			material.ID = "mat-1"
+			material.Barcode = "MAT000123"
+			operation := &entities.Operation{ID: "op-1", Type: entities.OperationTypePlacement, Status: entities.OperationStatusPendingPhysicalConfirmation, MaterialID: "mat-1", SlotID: "slot-1", ShelfID: "shelf-1"}
+			m.operationRepo.On("GetPendingPhysicalConfirmationsBySlotID", ctx, "slot-1").Return([]*entities.Operation{operation}, nil)
+			m.materialRepo.On("GetByBarcode", ctx, "MAT000123").Return(&material, nil)
+			if tt.baseline != nil {
+				m.sensorReadingRepo.On("GetLatestWeightBySlotID", ctx, "slot-1", detectedAt).Return(&entities.SensorReading{Weight: tt.baseline}, nil)
+			} else {
+				m.sensorReadingRepo.On("GetLatestWeightBySlotID", ctx, "slot-1", detectedAt).Return(nil, gorm.ErrRecordNotFound)
+			}
+			// the confirmation is stopped at its transaction, which needs a database
+			m.operationRepo.On("GetByID", ctx, "op-1").Return(operation, nil)
+			m.operationRepo.On("BeginTx", ctx).Return((*gorm.DB)(nil), errors.New("no database"))
+			m.slotRepo.On("GetByID", ctx, "slot-1").Return(&entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusReserved}, nil)
+			m.alertRepo.On("Create", ctx, mock.Anything).Return(nil)
+			m.commandRepo.On("Create", ctx, mock.Anything).Return(nil)
+			m.commandRepo.On("MarkSent", ctx, mock.Anything, mock.Anything).Return(true, nil)
+			m.commandPublisher.On("PublishCommand", ctx, mock.Anything).Return(nil)
+
+			err := inventoryService.HandleMaterialDetectedEvent(ctx, "slot-1", "MAT000123", tt.measured, detectedAt)
+
+			if !tt.wantMismatch {
+				assert.Error(t, err)
+				m.operationRepo.AssertCalled(t, "BeginTx", ctx)
+				m.alertRepo.AssertNotCalled(t, "Create", ctx, mock.Anything)
+				assert.Empty(t, m.bus.Published())
+				return
+			}
+
+			assert.NoError(t, err)
+			m.operationRepo.AssertNotCalled(t, "BeginTx", ctx)
+			m.alertRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(alert *entities.Alert) bool {
+				return alert.SlotID == "slot-1" && alert.Message == "Slot error: weight_mismatch" && alert.Metadata["operation_id"] == "op-1"
+			}))
+			m.commandPublisher.AssertCalled(t, "PublishCommand", ctx, mock.MatchedBy(func(command *entities.ShelfCommand) bool {
+				return command.Type == entities.ShelfCommandBlinkError && command.SlotID == "slot-1"
+			}))
+
+			published := m.bus.Published()
+			if assert.NotEmpty(t, published) {
+				assert.Equal(t, services.EventTypeSlotError, published[0].Headers[events.HeaderEventType])
+				var payload map[string]interface{}
+				assert.NoError(t, json.Unmarshal(published[0].Payload, &payload))
+				assert.Equal(t, "weight_mismatch", payload["error_type"])
+			}
+		})
+	}
+}
+
+func TestHandleMaterialDetectedEvent_RetriesWhenTheBaselineCannotBeRead(t *testing.T) {
+	inventoryService, m := newWeightService()
+	ctx := context.Background()
+	detectedAt := time.Now()
+
+	material := &entities.Material{ID: "mat-1", Barcode: "MAT000123", UnitWeight: 50}
+	operation := &entities.Operation{ID: "op-1", Type: entities.OperationTypePlacement, Status: entities.OperationStatusPendingPhysicalConfirmation, MaterialID: "mat-1", SlotID: "slot-1", ShelfID: "shelf-1"}
+	m.operationRepo.On("GetPendingPhysicalConfirmationsBySlotID", ctx, "slot-1").Return([]*entities.Operation{operation}, nil)
+	m.materialRepo.On("GetByBarcode", ctx, "MAT000123").Return(material, nil)
+	m.sensorReadingRepo.On("GetLatestWeightBySlotID", ctx, "slot-1", detectedAt).Return(nil, errors.New("connection reset"))
+
+	measured := 500.0
+	err := inventoryService.HandleMaterialDetectedEvent(ctx, "slot-1", "MAT000123", &measured, detectedAt)
+
+	assert.Error(t, err)
+	m.alertRepo.AssertNotCalled(t, "Create", ctx, mock.Anything)
+	m.operationRepo.AssertNotCalled(t, "BeginTx", ctx)
+	assert.Empty(t, m.bus.Published())
+}
+
+func TestHandleMaterialDetectedEvent_UnknownBarcodeIsAnUnplannedPlacement(t *testing.T) {
+	inventoryService, m := newWeightService()
+	ctx := context.Background()
+
+	m.operationRepo.On("GetPendingPhysicalConfirmationsBySlotID", ctx, "slot-1").Return([]*entities.Operation{}, nil)
+	m.materialRepo.On("GetByBarcode", ctx, "MAT999999").Return(nil, gorm.ErrRecordNotFound)
+	m.slotRepo.On("GetByID", ctx, "slot-1").Return(&entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty}, nil)
+
+	err := inventoryService.HandleMaterialDetectedEvent(ctx, "slot-1", "MAT999999", nil, time.Now())
+
+	assert.NoError(t, err)
+	published := m.bus.Published()
+	if assert.Len(t, published, 1) {
+		assert.Equal(t, services.EventTypeUnplannedPlacement, published[0].Headers[events.HeaderEventType])
+	}
+}
+
+func TestHandleMaterialDetectedEvent_ReturnsMaterialLookupFailures(t *testing.T) {
+	inventoryService, m := newWeightService()
+	ctx := context.Background()
+
+	operation := &entities.Operation{ID: "op-1", Status: entities.OperationStatusPendingPhysicalConfirmation, MaterialID: "mat-1", SlotID: "slot-1"}
+	m.operationRepo.On("GetPendingPhysicalConfirmationsBySlotID", ctx, "slot-1").Return([]*entities.Operation{operation}, nil)
+	m.materialRepo.On("GetByBarcode", ctx, "MAT000123").Return(nil, errors.New("connection refused"))
+
+	err := inventoryService.HandleMaterialDetectedEvent(ctx, "slot-1", "MAT000123", nil, time.Now())
+
+	// retried instead of reporting the pending placement as unplanned
+	assert.Error(t, err)
+	assert.Empty(t, m.bus.Published())
+}
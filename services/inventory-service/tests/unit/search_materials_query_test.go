@@ -5,48 +5,76 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"WMS/services/inventory-service/internal/application/queries"
 	"WMS/services/inventory-service/internal/domain/entities"
-	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/domain/repositories"
+	"WMS/services/inventory-service/pkg/errors"
 )
 
-// MockInventoryService is a mock type for the InventoryService
-// (Assuming this is defined once in a common test file or similar)
-// type MockInventoryService struct {
-// 	mock.Mock
-// }
-
-// func (m *MockInventoryService) SearchMaterials(ctx context.Context, query string, limit, offset int) ([]*entities.Material, error) {
-// 	args := m.Called(ctx, query, limit, offset)
-// 	return args.Get(0).([]*entities.Material), args.Error(1)
-// }
-
 func TestSearchMaterialsQueryHandler_Handle(t *testing.T) {
 	// Arrange
-	mockService := new(MockInventoryService)
-	handler := queries.NewSearchMaterialsQueryHandler(mockService)
+	mockRepo := new(MockMaterialRepository)
+	handler := queries.NewSearchMaterialsQueryHandler(mockRepo)
 
 	ctx := context.Background()
 	query := queries.SearchMaterialsQuery{
 		Query:  "test",
-		Limit:  10,
+		Type:   "CPU",
+		Status: entities.MaterialStatusAvailable,
 		Offset: 0,
 	}
+	filter := repositories.MaterialSearchFilter{Query: "test", Type: "CPU", Status: entities.MaterialStatusAvailable}
 
 	expectedMaterials := []*entities.Material{
 		{ID: "mat1", Name: "Test Material 1"},
 		{ID: "mat2", Name: "Test Material 2"},
 	}
+	expectedFacets := &repositories.MaterialFacets{
+		Types:    []repositories.MaterialFacetCount{{Value: "CPU", Count: 2}, {Value: "RAM", Count: 1}},
+		Statuses: []repositories.MaterialFacetCount{{Value: "available", Count: 2}},
+	}
 
-	// Expect the SearchMaterials method to be called once with the specified arguments
-	mockService.On("SearchMaterials", ctx, query.Query, query.Limit, query.Offset).Return(expectedMaterials, nil).Once()
+	// The limit defaults to 20
+	mockRepo.On("Search", ctx, filter, 20, 0).Return(expectedMaterials, nil).Once()
+	mockRepo.On("CountSearch", ctx, filter).Return(int64(2), nil).Once()
+	mockRepo.On("SearchFacets", ctx, filter).Return(expectedFacets, nil).Once()
 
 	// Act
-	materials, err := handler.Handle(ctx, query)
+	page, err := handler.Handle(ctx, query)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, expectedMaterials, materials)
-	mockService.AssertExpectations(t)
+	assert.Equal(t, expectedMaterials, page.Materials)
+	assert.Equal(t, int64(2), page.TotalCount)
+	assert.Equal(t, expectedFacets, page.Facets)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchMaterialsQueryHandler_NoMatchesReturnsEmptyPage(t *testing.T) {
+	mockRepo := new(MockMaterialRepository)
+	handler := queries.NewSearchMaterialsQueryHandler(mockRepo)
+	ctx := context.Background()
+	filter := repositories.MaterialSearchFilter{Query: "nothing"}
+
+	mockRepo.On("Search", ctx, filter, 100, 0).Return(nil, nil).Once()
+	mockRepo.On("CountSearch", ctx, filter).Return(int64(0), nil).Once()
+	mockRepo.On("SearchFacets", ctx, filter).Return(&repositories.MaterialFacets{}, nil).Once()
+
+	page, err := handler.Handle(ctx, queries.SearchMaterialsQuery{Query: "nothing", Limit: 1000})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, page.Materials)
+	assert.Empty(t, page.Materials)
+	assert.Equal(t, int64(0), page.TotalCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchMaterialsQueryHandler_RejectsUnknownStatus(t *testing.T) {
+	mockRepo := new(MockMaterialRepository)
+	handler := queries.NewSearchMaterialsQueryHandler(mockRepo)
+
+	_, err := handler.Handle(context.Background(), queries.SearchMaterialsQuery{Status: "lost"})
+
+	assert.IsType(t, &errors.ValidationError{}, err)
+	mockRepo.AssertNotCalled(t, "Search")
 }
@@ -0,0 +1,43 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"WMS/services/inventory-service/internal/domain/services"
+)
+
+func TestEnsureSensorReadingPartitions_CreatesTheComingMonths(t *testing.T) {
+	repo := new(MockSensorReadingRepository)
+	ctx := context.Background()
+
+	// runs across the end of the year
+	for _, month := range []time.Time{
+		time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		repo.On("CreateMonthlyPartition", ctx, month).Return(nil).Once()
+	}
+
+	err := services.NewTelemetryService(repo).EnsureSensorReadingPartitions(ctx, time.Date(2025, 12, 31, 23, 30, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestEnsureSensorReadingPartitions_StopsOnFailure(t *testing.T) {
+	repo := new(MockSensorReadingRepository)
+	ctx := context.Background()
+
+	repo.On("CreateMonthlyPartition", ctx, mock.Anything).Return(errors.New("permission denied for schema public"))
+
+	err := services.NewTelemetryService(repo).EnsureSensorReadingPartitions(ctx, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC))
+
+	assert.ErrorContains(t, err, "2025-03")
+	repo.AssertNumberOfCalls(t, "CreateMonthlyPartition", 1)
+}
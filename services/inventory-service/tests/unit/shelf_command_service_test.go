@@ -0,0 +1,175 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	apperrors "WMS/services/inventory-service/pkg/errors"
+)
+
+// MockShelfCommandRepository is a mock type for the ShelfCommandRepository
+type MockShelfCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockShelfCommandRepository) Create(ctx context.Context, command *entities.ShelfCommand) error {
+	args := m.Called(ctx, command)
+	return args.Error(0)
+}
+
+func (m *MockShelfCommandRepository) GetByID(ctx context.Context, id string) (*entities.ShelfCommand, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ShelfCommand), args.Error(1)
+}
+
+func (m *MockShelfCommandRepository) Update(ctx context.Context, command *entities.ShelfCommand) error {
+	args := m.Called(ctx, command)
+	return args.Error(0)
+}
+
+func (m *MockShelfCommandRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	args := m.Called(ctx, id, sentAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockShelfCommandRepository) ListAwaitingAck(ctx context.Context, sentBefore time.Time) ([]*entities.ShelfCommand, error) {
+	args := m.Called(ctx, sentBefore)
+	return args.Get(0).([]*entities.ShelfCommand), args.Error(1)
+}
+
+// MockShelfCommandPublisher is a mock type for the ShelfCommandPublisher
+type MockShelfCommandPublisher struct {
+	mock.Mock
+}
+
+func (m *MockShelfCommandPublisher) PublishCommand(ctx context.Context, command *entities.ShelfCommand) error {
+	args := m.Called(ctx, command)
+	return args.Error(0)
+}
+
+func TestShelfCommandService_SendCommand(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockShelfCommandRepository)
+	mockPublisher := new(MockShelfCommandPublisher)
+	service := services.NewShelfCommandService(mockRepo, mockPublisher)
+
+	ctx := context.Background()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*entities.ShelfCommand")).Return(nil).Once()
+	mockPublisher.On("PublishCommand", ctx, mock.AnythingOfType("*entities.ShelfCommand")).Return(nil).Once()
+	mockRepo.On("MarkSent", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+
+	// Act
+	command, err := service.SendCommand(ctx, services.SendShelfCommandParams{
+		ShelfID: "shelf-1",
+		SlotID:  "slot-1",
+		Type:    entities.ShelfCommandLightUpSlot,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, command.ID)
+	assert.Equal(t, entities.ShelfCommandStatusSent, command.Status)
+	assert.NotNil(t, command.SentAt)
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestShelfCommandService_SendCommand_KeepsEarlyAcknowledgement(t *testing.T) {
+	mockRepo := new(MockShelfCommandRepository)
+	mockPublisher := new(MockShelfCommandPublisher)
+	service := services.NewShelfCommandService(mockRepo, mockPublisher)
+
+	ctx := context.Background()
+	acknowledgedAt := time.Now()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*entities.ShelfCommand")).Return(nil).Once()
+	mockPublisher.On("PublishCommand", ctx, mock.AnythingOfType("*entities.ShelfCommand")).Return(nil).Once()
+	// the shelf acknowledged before the command was marked sent
+	mockRepo.On("MarkSent", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+	mockRepo.On("GetByID", ctx, mock.AnythingOfType("string")).Return(&entities.ShelfCommand{
+		ID:             "command-1",
+		ShelfID:        "shelf-1",
+		Status:         entities.ShelfCommandStatusAcknowledged,
+		AcknowledgedAt: &acknowledgedAt,
+	}, nil).Once()
+
+	command, err := service.SendCommand(ctx, services.SendShelfCommandParams{ShelfID: "shelf-1", Type: entities.ShelfCommandRescan})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ShelfCommandStatusAcknowledged, command.Status)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestShelfCommandService_SendCommand_PublishFailure(t *testing.T) {
+	mockRepo := new(MockShelfCommandRepository)
+	mockPublisher := new(MockShelfCommandPublisher)
+	service := services.NewShelfCommandService(mockRepo, mockPublisher)
+
+	ctx := context.Background()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*entities.ShelfCommand")).Return(nil).Once()
+	mockPublisher.On("PublishCommand", ctx, mock.AnythingOfType("*entities.ShelfCommand")).Return(errors.New("not connected")).Once()
+	mockRepo.On("Update", ctx, mock.MatchedBy(func(command *entities.ShelfCommand) bool {
+		return command.Status == entities.ShelfCommandStatusFailed && command.Error == "not connected"
+	})).Return(nil).Once()
+
+	command, err := service.SendCommand(ctx, services.SendShelfCommandParams{ShelfID: "shelf-1", Type: entities.ShelfCommandRescan})
+
+	assert.Error(t, err)
+	assert.Equal(t, entities.ShelfCommandStatusFailed, command.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestShelfCommandService_SendCommand_RequiresSlot(t *testing.T) {
+	mockRepo := new(MockShelfCommandRepository)
+	mockPublisher := new(MockShelfCommandPublisher)
+	service := services.NewShelfCommandService(mockRepo, mockPublisher)
+
+	_, err := service.SendCommand(context.Background(), services.SendShelfCommandParams{ShelfID: "shelf-1", Type: entities.ShelfCommandLockSlot})
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockPublisher.AssertNotCalled(t, "PublishCommand", mock.Anything, mock.Anything)
+}
+
+func TestShelfCommandService_HandleAcknowledgement(t *testing.T) {
+	mockRepo := new(MockShelfCommandRepository)
+	service := services.NewShelfCommandService(mockRepo, new(MockShelfCommandPublisher))
+
+	ctx := context.Background()
+	sentAt := time.Now().Add(-time.Second)
+	command := &entities.ShelfCommand{ID: "command-1", ShelfID: "shelf-1", Status: entities.ShelfCommandStatusSent, SentAt: &sentAt}
+	mockRepo.On("GetByID", ctx, "command-1").Return(command, nil).Once()
+	mockRepo.On("Update", ctx, command).Return(nil).Once()
+
+	err := service.HandleAcknowledgement(ctx, services.ShelfCommandAck{CommandID: "command-1", ShelfID: "shelf-1", Success: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ShelfCommandStatusAcknowledged, command.Status)
+	assert.NotNil(t, command.AcknowledgedAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestShelfCommandService_HandleAcknowledgement_RejectsOtherShelves(t *testing.T) {
+	mockRepo := new(MockShelfCommandRepository)
+	service := services.NewShelfCommandService(mockRepo, new(MockShelfCommandPublisher))
+
+	ctx := context.Background()
+	command := &entities.ShelfCommand{ID: "command-1", ShelfID: "shelf-1", Status: entities.ShelfCommandStatusSent}
+	mockRepo.On("GetByID", ctx, "command-1").Return(command, nil).Once()
+
+	err := service.HandleAcknowledgement(ctx, services.ShelfCommandAck{CommandID: "command-1", ShelfID: "shelf-2", Success: true})
+
+	var validationErr *apperrors.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, entities.ShelfCommandStatusSent, command.Status)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
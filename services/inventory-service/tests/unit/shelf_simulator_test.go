@@ -0,0 +1,122 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/config"
+	"WMS/services/inventory-service/internal/interfaces/mqtt"
+	"WMS/services/inventory-service/internal/simulator"
+)
+
+type publishedMessage struct {
+	topic   string
+	payload []byte
+}
+
+// fakeTransport records what the simulated shelves publish
+type fakeTransport struct {
+	mu        sync.Mutex
+	published []publishedMessage
+}
+
+func (t *fakeTransport) Publish(topic string, payload []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.published = append(t.published, publishedMessage{topic: topic, payload: payload})
+	return nil
+}
+
+func (t *fakeTransport) Subscribe(topic string, handler func(topic string, payload []byte)) error {
+	return nil
+}
+
+func (t *fakeTransport) messages(topic string) []publishedMessage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var messages []publishedMessage
+	for _, msg := range t.published {
+		if msg.topic == topic {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+func newTestSimulator(t *testing.T) (*simulator.Simulator, *fakeTransport) {
+	topics, err := mqtt.NewTopics(config.MQTTConfig{TopicPrefix: "test/shelf"})
+	assert.NoError(t, err)
+
+	transport := &fakeTransport{}
+	sim := simulator.New(simulator.Config{ShelfCount: 2}, transport, topics)
+	return sim, transport
+}
+
+func TestShelfSimulator_PlacementRequestedEmitsMaterialDetected(t *testing.T) {
+	sim, transport := newTestSimulator(t)
+	assert.Equal(t, []string{"SHELF001", "SHELF002"}, sim.ShelfIDs())
+
+	sim.HandleInventoryEvent(context.Background(), []byte(`{
+		"event_type": "physical.placement.requested",
+		"shelf_id": "SHELF002",
+		"slot_id": "SHELF002-R01-C05",
+		"material_barcode": "MAT000042"
+	}`))
+
+	assert.Eventually(t, func() bool {
+		return len(transport.messages("test/shelf/SHELF002/events")) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	var event mqtt.ShelfEvent
+	assert.NoError(t, json.Unmarshal(transport.messages("test/shelf/SHELF002/events")[0].payload, &event))
+	assert.Equal(t, "material.detected", event.EventType)
+	assert.Equal(t, "SHELF002-R01-C05", event.SlotID)
+	assert.Equal(t, "MAT000042", event.MaterialBarcode)
+	assert.NotEmpty(t, event.EventID)
+	assert.Equal(t, int64(1), event.Sequence)
+}
+
+func TestShelfSimulator_IgnoresOtherShelvesAndEvents(t *testing.T) {
+	sim, transport := newTestSimulator(t)
+
+	sim.HandleInventoryEvent(context.Background(), []byte(`{"event_type": "physical.placement.requested", "shelf_id": "SHELF099", "slot_id": "SHELF099-R01-C01"}`))
+	sim.HandleInventoryEvent(context.Background(), []byte(`{"event_type": "material.placed", "shelf_id": "SHELF001", "slot_id": "SHELF001-R01-C01"}`))
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, transport.messages("test/shelf/SHELF099/events"))
+	assert.Empty(t, transport.messages("test/shelf/SHELF001/events"))
+}
+
+func TestShelfSimulator_AcknowledgesCommandsAndPerformsRemovals(t *testing.T) {
+	sim, transport := newTestSimulator(t)
+
+	sim.HandleCommand(context.Background(), []byte(`{
+		"command_id": "cmd-1",
+		"command_type": "light_up_slot",
+		"shelf_id": "SHELF001",
+		"slot_id": "SHELF001-R02-C03",
+		"params": {"purpose": "removal"}
+	}`))
+
+	acks := transport.messages("test/shelf/SHELF001/commands/ack")
+	if assert.Len(t, acks, 1) {
+		var ack mqtt.ShelfCommandAck
+		assert.NoError(t, json.Unmarshal(acks[0].payload, &ack))
+		assert.Equal(t, "cmd-1", ack.CommandID)
+		assert.True(t, ack.Success)
+	}
+
+	assert.Eventually(t, func() bool {
+		events := transport.messages("test/shelf/SHELF001/events")
+		if len(events) != 1 {
+			return false
+		}
+		var event mqtt.ShelfEvent
+		return json.Unmarshal(events[0].payload, &event) == nil &&
+			event.EventType == "material.removed" && event.SlotID == "SHELF001-R02-C03"
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,26 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+)
+
+func TestShelfStateStatus_CanTransitionTo(t *testing.T) {
+	assert.True(t, entities.ShelfStateOnline.CanTransitionTo(entities.ShelfStateOffline))
+	assert.True(t, entities.ShelfStateOffline.CanTransitionTo(entities.ShelfStateOnline))
+	assert.True(t, entities.ShelfStateDegraded.CanTransitionTo(entities.ShelfStateMaintenance))
+	assert.True(t, entities.ShelfStateMaintenance.CanTransitionTo(entities.ShelfStateOnline))
+
+	assert.False(t, entities.ShelfStateMaintenance.CanTransitionTo(entities.ShelfStateDegraded))
+	assert.False(t, entities.ShelfStateOnline.CanTransitionTo(entities.ShelfStateOnline))
+	assert.False(t, entities.ShelfStateStatus("rebooting").IsValid())
+}
+
+func TestShelfStateStatus_AcceptsPlacements(t *testing.T) {
+	assert.True(t, entities.ShelfStateOnline.AcceptsPlacements())
+	assert.True(t, entities.ShelfStateDegraded.AcceptsPlacements())
+	assert.False(t, entities.ShelfStateOffline.AcceptsPlacements())
+	assert.False(t, entities.ShelfStateMaintenance.AcceptsPlacements())
+}
@@ -0,0 +1,37 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+)
+
+func TestParseSlotErrorType(t *testing.T) {
+	assert.Equal(t, entities.SlotErrorTypeJammed, entities.ParseSlotErrorType("jammed"))
+	assert.Equal(t, entities.SlotErrorTypeRFIDReadFailure, entities.ParseSlotErrorType(" RFID_READ_FAILURE "))
+	assert.Equal(t, entities.SlotErrorTypeSensorFault, entities.ParseSlotErrorType("sensor_error")) // legacy name
+	assert.Equal(t, entities.SlotErrorTypeUnknown, entities.ParseSlotErrorType("smoke"))
+}
+
+func TestParseSlotErrorRemediations(t *testing.T) {
+	remediations, err := entities.ParseSlotErrorRemediations("jammed=investigation, door_open=maintenance")
+	assert.NoError(t, err)
+	assert.Equal(t, map[entities.SlotErrorType]entities.SlotErrorRemediation{
+		entities.SlotErrorTypeJammed:   entities.SlotErrorRemediationInvestigation,
+		entities.SlotErrorTypeDoorOpen: entities.SlotErrorRemediationMaintenance,
+	}, remediations)
+
+	remediations, err = entities.ParseSlotErrorRemediations("")
+	assert.NoError(t, err)
+	assert.Empty(t, remediations)
+
+	_, err = entities.ParseSlotErrorRemediations("jammed")
+	assert.Error(t, err)
+
+	_, err = entities.ParseSlotErrorRemediations("smoke=maintenance")
+	assert.Error(t, err)
+
+	_, err = entities.ParseSlotErrorRemediations("jammed=ignore")
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	apperrors "WMS/services/inventory-service/pkg/errors"
+)
+
+type maintenanceMocks struct {
+	slotRepo   *MockSlotRepository
+	ticketRepo *MockMaintenanceTicketRepository
+}
+
+// newMaintenanceService returns an inventory service whose shelf locks always fail, like they do while
+// another instance holds the shelf
+func newMaintenanceService() (*services.InventoryService, *maintenanceMocks) {
+	m := &maintenanceMocks{
+		slotRepo:   new(MockSlotRepository),
+		ticketRepo: new(MockMaintenanceTicketRepository),
+	}
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	inventoryService := services.NewInventoryService(
+		nil, m.slotRepo, nil, nil, nil,
+		services.NewLockService(redisClient),
+		nil, nil, nil, nil, nil, nil, nil,
+		m.ticketRepo,
+		nil, nil, nil, nil,
+	)
+	return inventoryService, m
+}
+
+func TestStartSlotMaintenance_Validation(t *testing.T) {
+	inventoryService, _ := newMaintenanceService()
+	ctx := context.Background()
+
+	for _, params := range []services.StartSlotMaintenanceParams{
+		{Assignee: "tech-1"},
+		{SlotID: "slot-1"},
+	} {
+		_, err := inventoryService.StartSlotMaintenance(ctx, params)
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	}
+}
+
+func TestStartSlotMaintenance_StartsAnOpenTicket(t *testing.T) {
+	inventoryService, m := newMaintenanceService()
+	ctx := context.Background()
+
+	ticket := &entities.MaintenanceTicket{ID: "ticket-1", SlotID: "slot-1", ShelfID: "shelf-1", Status: entities.MaintenanceTicketStatusOpen}
+	m.ticketRepo.On("GetActiveBySlotID", ctx, "slot-1").Return(ticket, nil)
+	m.ticketRepo.On("Update", ctx, ticket).Return(nil)
+
+	started, err := inventoryService.StartSlotMaintenance(ctx, services.StartSlotMaintenanceParams{SlotID: "slot-1", Assignee: "tech-1", Notes: "replacing the door sensor"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.MaintenanceTicketStatusInProgress, started.Status)
+	assert.Equal(t, "tech-1", started.Assignee)
+	assert.NotNil(t, started.StartedAt)
+	assert.Contains(t, started.Notes, "tech-1: replacing the door sensor")
+	// the slot is already out of service
+	m.slotRepo.AssertNotCalled(t, "GetByID", ctx, "slot-1")
+}
+
+func TestStartSlotMaintenance_RejectsAnotherAssignee(t *testing.T) {
+	inventoryService, m := newMaintenanceService()
+	ctx := context.Background()
+
+	startedAt := time.Now().Add(-time.Hour)
+	m.ticketRepo.On("GetActiveBySlotID", ctx, "slot-1").Return(&entities.MaintenanceTicket{
+		ID: "ticket-1", SlotID: "slot-1", Status: entities.MaintenanceTicketStatusInProgress, Assignee: "tech-1", StartedAt: &startedAt,
+	}, nil)
+
+	_, err := inventoryService.StartSlotMaintenance(ctx, services.StartSlotMaintenanceParams{SlotID: "slot-1", Assignee: "tech-2"})
+
+	var conflict *apperrors.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+	m.ticketRepo.AssertNotCalled(t, "Update", ctx, mock.Anything)
+}
+
+func TestStartSlotMaintenance_NeedsTheShelfLockToTakeASlotOutOfService(t *testing.T) {
+	inventoryService, m := newMaintenanceService()
+	ctx := context.Background()
+
+	m.ticketRepo.On("GetActiveBySlotID", ctx, "slot-1").Return(nil, nil)
+	m.slotRepo.On("GetByID", ctx, "slot-1").Return(&entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty}, nil)
+
+	_, err := inventoryService.StartSlotMaintenance(ctx, services.StartSlotMaintenanceParams{SlotID: "slot-1", Assignee: "tech-1"})
+
+	var conflict *apperrors.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+	m.slotRepo.AssertNotCalled(t, "BeginTx", ctx)
+	m.ticketRepo.AssertNotCalled(t, "CreateWithTx", ctx, mock.Anything, mock.Anything)
+}
+
+func TestCompleteSlotMaintenance_Validation(t *testing.T) {
+	inventoryService, _ := newMaintenanceService()
+
+	_, err := inventoryService.CompleteSlotMaintenance(context.Background(), services.CompleteSlotMaintenanceParams{OperatorID: "tech-1"})
+
+	var validationErr *apperrors.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestCompleteSlotMaintenance_NeedsAnActiveTicket(t *testing.T) {
+	inventoryService, m := newMaintenanceService()
+	ctx := context.Background()
+
+	m.slotRepo.On("GetByID", ctx, "slot-1").Return(&entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty}, nil)
+	m.ticketRepo.On("GetActiveBySlotID", ctx, "slot-1").Return(nil, assert.AnError)
+
+	_, err := inventoryService.CompleteSlotMaintenance(ctx, services.CompleteSlotMaintenanceParams{SlotID: "slot-1", OperatorID: "tech-1"})
+
+	var notFound *apperrors.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func TestCompleteSlotMaintenance_NeedsTheShelfLock(t *testing.T) {
+	inventoryService, m := newMaintenanceService()
+	ctx := context.Background()
+
+	slot := &entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusMaintenance}
+	m.slotRepo.On("GetByID", ctx, "slot-1").Return(slot, nil)
+	m.ticketRepo.On("GetActiveBySlotID", ctx, "slot-1").Return(&entities.MaintenanceTicket{ID: "ticket-1", SlotID: "slot-1", Status: entities.MaintenanceTicketStatusInProgress}, nil)
+
+	_, err := inventoryService.CompleteSlotMaintenance(ctx, services.CompleteSlotMaintenanceParams{SlotID: "slot-1", OperatorID: "tech-1"})
+
+	var conflict *apperrors.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, entities.SlotStatusMaintenance, slot.Status)
+	m.slotRepo.AssertNotCalled(t, "BeginTx", ctx)
+}
+
+func TestMaintenanceTicket_ReturnStatus(t *testing.T) {
+	materialID := "mat-1"
+	tests := []struct {
+		name           string
+		previousStatus entities.SlotStatus
+		materialID     *string
+		want           entities.SlotStatus
+	}{
+		{"reservation is kept", entities.SlotStatusReserved, nil, entities.SlotStatusReserved},
+		{"pending removal is kept", entities.SlotStatusRemovalPending, &materialID, entities.SlotStatusRemovalPending},
+		{"occupied slot stays occupied", entities.SlotStatusOccupied, &materialID, entities.SlotStatusOccupied},
+		{"material taken out during maintenance", entities.SlotStatusOccupied, nil, entities.SlotStatusEmpty},
+		{"material put in during maintenance", entities.SlotStatusEmpty, &materialID, entities.SlotStatusOccupied},
+		{"ticket from before the previous status was kept", "", nil, entities.SlotStatusEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket := &entities.MaintenanceTicket{PreviousSlotStatus: tt.previousStatus}
+			assert.Equal(t, tt.want, ticket.ReturnStatus(tt.materialID))
+		})
+	}
+}
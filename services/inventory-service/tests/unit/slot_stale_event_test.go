@@ -0,0 +1,24 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"WMS/services/inventory-service/internal/domain/entities"
+)
+
+func TestSlot_IsStaleEvent(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	slot := &entities.Slot{ID: "slot-1", Version: 5, UpdatedAt: updatedAt}
+	skew := 2 * time.Second
+
+	// versioned events are compared by version only
+	assert.True(t, slot.IsStaleEvent(updatedAt.Add(time.Minute), 4, skew))
+	assert.False(t, slot.IsStaleEvent(updatedAt.Add(-time.Minute), 5, skew))
+
+	// unversioned events fall back to the timestamp, allowing for clock skew
+	assert.True(t, slot.IsStaleEvent(updatedAt.Add(-3*time.Second), 0, skew))
+	assert.False(t, slot.IsStaleEvent(updatedAt.Add(-time.Second), 0, skew))
+	assert.False(t, slot.IsStaleEvent(updatedAt.Add(time.Second), 0, skew))
+}
@@ -0,0 +1,105 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"WMS/shared/events"
+	"WMS/services/inventory-service/internal/domain/entities"
+	"WMS/services/inventory-service/internal/domain/services"
+	"WMS/services/inventory-service/internal/infrastructure/messaging"
+)
+
+// newSensorService returns an inventory service that publishes its events to an in-memory bus
+func newSensorService() (*services.InventoryService, *traceMocks, *messaging.MemoryBus) {
+	m := &traceMocks{
+		materialRepo:   new(MockMaterialRepository),
+		slotRepo:       new(MockSlotRepository),
+		operationRepo:  new(MockOperationRepository),
+		transitionRepo: new(MockOperationTransitionRepository),
+		alertRepo:      new(MockAlertRepository),
+		locationClient: new(MockLocationClient),
+	}
+	bus := messaging.NewMemoryBus()
+	inventoryService := services.NewInventoryService(
+		m.materialRepo, m.slotRepo, m.operationRepo, m.transitionRepo, m.alertRepo,
+		nil,
+		services.NewEventService(bus, "inventory_events", nil),
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		m.locationClient,
+		nil,
+	)
+	return inventoryService, m, bus
+}
+
+func publishedEventTypes(bus *messaging.MemoryBus) []string {
+	eventTypes := make([]string, 0)
+	for _, message := range bus.Published() {
+		eventTypes = append(eventTypes, message.Headers[events.HeaderEventType])
+	}
+	return eventTypes
+}
+
+func TestHandleMaterialDetectedEvent_SyncsUnplannedPlacementWithLocation(t *testing.T) {
+	inventoryService, m, bus := newSensorService()
+	ctx := context.Background()
+
+	slot := &entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusEmpty}
+	m.operationRepo.On("GetPendingPhysicalConfirmationsBySlotID", ctx, "slot-1").Return([]*entities.Operation{}, nil)
+	m.materialRepo.On("GetByBarcode", ctx, "MAT000123").Return(&entities.Material{ID: "mat-1", Barcode: "MAT000123"}, nil)
+	m.slotRepo.On("GetByID", ctx, "slot-1").Return(slot, nil)
+	m.alertRepo.On("Create", ctx, mock.MatchedBy(func(alert *entities.Alert) bool {
+		return alert.Type == entities.AlertTypeUnplannedChange && alert.SlotID == "slot-1" && alert.ShelfID == "shelf-1" && alert.Metadata["material_id"] == "mat-1"
+	})).Return(nil)
+	m.locationClient.On("UpdateSlotStatus", ctx, mock.MatchedBy(func(synced *entities.Slot) bool {
+		return synced.ID == "slot-1" && synced.Status == entities.SlotStatusOccupied && synced.MaterialID != nil && *synced.MaterialID == "mat-1"
+	})).Return(nil)
+
+	err := inventoryService.HandleMaterialDetectedEvent(ctx, "slot-1", "MAT000123", nil, slot.UpdatedAt)
+
+	assert.NoError(t, err)
+	m.locationClient.AssertExpectations(t)
+	m.alertRepo.AssertExpectations(t)
+	assert.Equal(t, []string{services.EventTypeUnplannedPlacement}, publishedEventTypes(bus))
+	// inventory keeps its state until the unplanned placement has been investigated
+	assert.Equal(t, entities.SlotStatusEmpty, slot.Status)
+	assert.Nil(t, slot.MaterialID)
+}
+
+func TestHandleMaterialRemovedEvent_SyncsUnplannedRemovalWithLocation(t *testing.T) {
+	inventoryService, m, bus := newSensorService()
+	ctx := context.Background()
+
+	materialID := "mat-1"
+	slot := &entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusOccupied, MaterialID: &materialID}
+	m.operationRepo.On("GetPendingRemovalConfirmationsBySlotID", ctx, "slot-1").Return([]*entities.Operation{}, nil)
+	m.slotRepo.On("GetByID", ctx, "slot-1").Return(slot, nil)
+	m.alertRepo.On("Create", ctx, mock.MatchedBy(func(alert *entities.Alert) bool {
+		return alert.Type == entities.AlertTypeUnplannedChange && alert.SlotID == "slot-1"
+	})).Return(nil)
+	m.locationClient.On("UpdateSlotStatus", ctx, mock.MatchedBy(func(synced *entities.Slot) bool {
+		return synced.ID == "slot-1" && synced.Status == entities.SlotStatusEmpty && synced.MaterialID == nil
+	})).Return(nil)
+
+	err := inventoryService.HandleMaterialRemovedEvent(ctx, "slot-1", "MAT000123")
+
+	assert.NoError(t, err)
+	m.locationClient.AssertExpectations(t)
+	assert.Equal(t, []string{services.EventTypeUnplannedRemoval}, publishedEventTypes(bus))
+	assert.Equal(t, entities.SlotStatusOccupied, slot.Status)
+}
+
+func TestHandleMaterialRemovedEvent_UnplannedRemovalSurvivesLocationFailure(t *testing.T) {
+	inventoryService, m, _ := newSensorService()
+	ctx := context.Background()
+
+	m.operationRepo.On("GetPendingRemovalConfirmationsBySlotID", ctx, "slot-1").Return([]*entities.Operation{}, nil)
+	m.slotRepo.On("GetByID", ctx, "slot-1").Return(&entities.Slot{ID: "slot-1", ShelfID: "shelf-1", Status: entities.SlotStatusOccupied}, nil)
+	m.alertRepo.On("Create", ctx, mock.Anything).Return(nil)
+	m.locationClient.On("UpdateSlotStatus", ctx, mock.Anything).Return(errors.New("location-service circuit breaker is open"))
+
+	assert.NoError(t, inventoryService.HandleMaterialRemovedEvent(ctx, "slot-1", "MAT000123"))
+}
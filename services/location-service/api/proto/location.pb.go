@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.36.6
 // 	protoc        v5.29.3
 // source: api/proto/location.proto
 
@@ -11,6 +11,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -21,22 +22,19 @@ const (
 )
 
 type Point struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z             int32                  `protobuf:"varint,3,opt,name=z,proto3" json:"z,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	X int32 `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
-	Y int32 `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
-	Z int32 `protobuf:"varint,3,opt,name=z,proto3" json:"z,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Point) Reset() {
 	*x = Point{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_location_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_api_proto_location_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Point) String() string {
@@ -47,7 +45,7 @@ func (*Point) ProtoMessage() {}
 
 func (x *Point) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_location_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -84,23 +82,26 @@ func (x *Point) GetZ() int32 {
 }
 
 type Zone struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	// A polygon defining the zone's boundaries
 	BoundaryPoints []*Point `protobuf:"bytes,3,rep,name=boundary_points,json=boundaryPoints,proto3" json:"boundary_points,omitempty"`
+	// Storage policy
+	StorageClass string `protobuf:"bytes,4,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"` // "GENERAL" (default), "HIGH_VALUE", "ESD", "COLD", "QUARANTINE"
+	// Material types kept to this zone and other zones listing them, empty accepts every type no zone lists
+	AllowedMaterialTypes []string `protobuf:"bytes,5,rep,name=allowed_material_types,json=allowedMaterialTypes,proto3" json:"allowed_material_types,omitempty"`
+	Capacity             int32    `protobuf:"varint,6,opt,name=capacity,proto3" json:"capacity,omitempty"`                         // Maximum number of occupied or reserved slots, 0 means no limit
+	AccessRoles          []string `protobuf:"bytes,7,rep,name=access_roles,json=accessRoles,proto3" json:"access_roles,omitempty"` // Roles allowed to place material in the zone, empty means everyone
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *Zone) Reset() {
 	*x = Zone{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_location_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_api_proto_location_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Zone) String() string {
@@ -111,7 +112,7 @@ func (*Zone) ProtoMessage() {}
 
 func (x *Zone) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_location_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -147,24 +148,52 @@ func (x *Zone) GetBoundaryPoints() []*Point {
 	return nil
 }
 
+func (x *Zone) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *Zone) GetAllowedMaterialTypes() []string {
+	if x != nil {
+		return x.AllowedMaterialTypes
+	}
+	return nil
+}
+
+func (x *Zone) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Zone) GetAccessRoles() []string {
+	if x != nil {
+		return x.AccessRoles
+	}
+	return nil
+}
+
 type Slot struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // e.g., "A-01-01"
+	Position   *Point                 `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	Status     string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                           // "EMPTY", "OCCUPIED", "RESERVED"
+	MaterialId string                 `protobuf:"bytes,4,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"` // Foreign key to material in inventory-service
+	// Set while the slot is held by an AllocateSlot caller
+	ReservedBy    string `protobuf:"bytes,5,opt,name=reserved_by,json=reservedBy,proto3" json:"reserved_by,omitempty"`
+	ReservedUntil int64  `protobuf:"varint,6,opt,name=reserved_until,json=reservedUntil,proto3" json:"reserved_until,omitempty"` // Unix seconds
 	unknownFields protoimpl.UnknownFields
-
-	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // e.g., "A-01-01"
-	Position   *Point `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
-	Status     string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                           // "EMPTY", "OCCUPIED", "RESERVED"
-	MaterialId string `protobuf:"bytes,4,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"` // Foreign key to material in inventory-service
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Slot) Reset() {
 	*x = Slot{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_location_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_api_proto_location_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Slot) String() string {
@@ -175,7 +204,7 @@ func (*Slot) ProtoMessage() {}
 
 func (x *Slot) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_location_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -218,26 +247,37 @@ func (x *Slot) GetMaterialId() string {
 	return ""
 }
 
+func (x *Slot) GetReservedBy() string {
+	if x != nil {
+		return x.ReservedBy
+	}
+	return ""
+}
+
+func (x *Slot) GetReservedUntil() int64 {
+	if x != nil {
+		return x.ReservedUntil
+	}
+	return 0
+}
+
 type Shelf struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ZoneId        string                 `protobuf:"bytes,2,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	Position      *Point                 `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	Rows          int32                  `protobuf:"varint,4,opt,name=rows,proto3" json:"rows,omitempty"`
+	Columns       int32                  `protobuf:"varint,5,opt,name=columns,proto3" json:"columns,omitempty"`
+	Slots         []*Slot                `protobuf:"bytes,6,rep,name=slots,proto3" json:"slots,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Id       string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ZoneId   string  `protobuf:"bytes,2,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
-	Position *Point  `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
-	Rows     int32   `protobuf:"varint,4,opt,name=rows,proto3" json:"rows,omitempty"`
-	Columns  int32   `protobuf:"varint,5,opt,name=columns,proto3" json:"columns,omitempty"`
-	Slots    []*Slot `protobuf:"bytes,6,rep,name=slots,proto3" json:"slots,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Shelf) Reset() {
 	*x = Shelf{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_location_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_api_proto_location_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Shelf) String() string {
@@ -248,7 +288,7 @@ func (*Shelf) ProtoMessage() {}
 
 func (x *Shelf) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_location_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -306,20 +346,17 @@ func (x *Shelf) GetSlots() []*Slot {
 }
 
 type GetShelfLayoutRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId       string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	ShelfId string `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetShelfLayoutRequest) Reset() {
 	*x = GetShelfLayoutRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_location_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_api_proto_location_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetShelfLayoutRequest) String() string {
@@ -330,7 +367,7 @@ func (*GetShelfLayoutRequest) ProtoMessage() {}
 
 func (x *GetShelfLayoutRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_location_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -353,20 +390,17 @@ func (x *GetShelfLayoutRequest) GetShelfId() string {
 }
 
 type ShelfLayoutResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shelf         *Shelf                 `protobuf:"bytes,1,opt,name=shelf,proto3" json:"shelf,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Shelf *Shelf `protobuf:"bytes,1,opt,name=shelf,proto3" json:"shelf,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ShelfLayoutResponse) Reset() {
 	*x = ShelfLayoutResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_location_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_api_proto_location_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ShelfLayoutResponse) String() string {
@@ -377,7 +411,7 @@ func (*ShelfLayoutResponse) ProtoMessage() {}
 
 func (x *ShelfLayoutResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_location_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -400,21 +434,18 @@ func (x *ShelfLayoutResponse) GetShelf() *Shelf {
 }
 
 type FindOptimalPathRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartPoint    *Point                 `protobuf:"bytes,1,opt,name=start_point,json=startPoint,proto3" json:"start_point,omitempty"`
+	EndPoint      *Point                 `protobuf:"bytes,2,opt,name=end_point,json=endPoint,proto3" json:"end_point,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	StartPoint *Point `protobuf:"bytes,1,opt,name=start_point,json=startPoint,proto3" json:"start_point,omitempty"`
-	EndPoint   *Point `protobuf:"bytes,2,opt,name=end_point,json=endPoint,proto3" json:"end_point,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FindOptimalPathRequest) Reset() {
 	*x = FindOptimalPathRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_location_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_api_proto_location_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *FindOptimalPathRequest) String() string {
@@ -425,7 +456,7 @@ func (*FindOptimalPathRequest) ProtoMessage() {}
 
 func (x *FindOptimalPathRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_location_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -455,21 +486,18 @@ func (x *FindOptimalPathRequest) GetEndPoint() *Point {
 }
 
 type FindOptimalPathResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          []*Point               `protobuf:"bytes,1,rep,name=path,proto3" json:"path,omitempty"`
+	Distance      float64                `protobuf:"fixed64,2,opt,name=distance,proto3" json:"distance,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Path     []*Point `protobuf:"bytes,1,rep,name=path,proto3" json:"path,omitempty"`
-	Distance float64  `protobuf:"fixed64,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FindOptimalPathResponse) Reset() {
 	*x = FindOptimalPathResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_location_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_api_proto_location_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *FindOptimalPathResponse) String() string {
@@ -480,7 +508,7 @@ func (*FindOptimalPathResponse) ProtoMessage() {}
 
 func (x *FindOptimalPathResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_location_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -510,21 +538,22 @@ func (x *FindOptimalPathResponse) GetDistance() float64 {
 }
 
 type SuggestPlacementRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaterialType  string                 `protobuf:"bytes,1,opt,name=material_type,json=materialType,proto3" json:"material_type,omitempty"` // e.g., "CPU", "Memory"
+	ZoneId        string                 `protobuf:"bytes,2,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`                   // Optional: suggest placement within a specific zone
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                  // Optional: number of candidates to return, 1 when unset
+	StorageClass  string                 `protobuf:"bytes,4,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"` // Optional: only zones of this storage class, needed to place into quarantine
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`                                     // Role of the requester, zones with access roles are only suggested to those roles
+	ShelfId       string                 `protobuf:"bytes,6,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`                // Optional: only slots on this shelf
 	unknownFields protoimpl.UnknownFields
-
-	MaterialType string `protobuf:"bytes,1,opt,name=material_type,json=materialType,proto3" json:"material_type,omitempty"` // e.g., "CPU", "Memory"
-	ZoneId       string `protobuf:"bytes,2,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`                   // Optional: suggest placement within a specific zone
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SuggestPlacementRequest) Reset() {
 	*x = SuggestPlacementRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_location_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_api_proto_location_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *SuggestPlacementRequest) String() string {
@@ -535,7 +564,7 @@ func (*SuggestPlacementRequest) ProtoMessage() {}
 
 func (x *SuggestPlacementRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_location_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -564,22 +593,109 @@ func (x *SuggestPlacementRequest) GetZoneId() string {
 	return ""
 }
 
-type SuggestPlacementResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+func (x *SuggestPlacementRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SuggestPlacementRequest) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *SuggestPlacementRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *SuggestPlacementRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+type PlacementCandidate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId       string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	SlotId        string                 `protobuf:"bytes,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	Distance      int32                  `protobuf:"varint,3,opt,name=distance,proto3" json:"distance,omitempty"` // Manhattan distance from the warehouse origin
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-	ShelfId string `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
-	SlotId  string `protobuf:"bytes,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+func (x *PlacementCandidate) Reset() {
+	*x = PlacementCandidate{}
+	mi := &file_api_proto_location_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *SuggestPlacementResponse) Reset() {
-	*x = SuggestPlacementResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_location_proto_msgTypes[9]
+func (x *PlacementCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlacementCandidate) ProtoMessage() {}
+
+func (x *PlacementCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[9]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlacementCandidate.ProtoReflect.Descriptor instead.
+func (*PlacementCandidate) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PlacementCandidate) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *PlacementCandidate) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *PlacementCandidate) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
 	}
+	return 0
+}
+
+type SuggestPlacementResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The best candidate, same as candidates[0]
+	ShelfId       string                `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	SlotId        string                `protobuf:"bytes,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	Candidates    []*PlacementCandidate `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestPlacementResponse) Reset() {
+	*x = SuggestPlacementResponse{}
+	mi := &file_api_proto_location_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *SuggestPlacementResponse) String() string {
@@ -589,8 +705,8 @@ func (x *SuggestPlacementResponse) String() string {
 func (*SuggestPlacementResponse) ProtoMessage() {}
 
 func (x *SuggestPlacementResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_location_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_api_proto_location_proto_msgTypes[10]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -602,7 +718,7 @@ func (x *SuggestPlacementResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SuggestPlacementResponse.ProtoReflect.Descriptor instead.
 func (*SuggestPlacementResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_location_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_location_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SuggestPlacementResponse) GetShelfId() string {
@@ -619,288 +735,835 @@ func (x *SuggestPlacementResponse) GetSlotId() string {
 	return ""
 }
 
-var File_api_proto_location_proto protoreflect.FileDescriptor
+func (x *SuggestPlacementResponse) GetCandidates() []*PlacementCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
 
-var file_api_proto_location_proto_rawDesc = []byte{
-	0x0a, 0x18, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x6c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x31, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x0c, 0x0a,
-	0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x7a, 0x22, 0x64, 0x0a, 0x04, 0x5a, 0x6f, 0x6e, 0x65, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x5f,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0e, 0x62,
-	0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x7c, 0x0a,
-	0x04, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61,
-	0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x6d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x49, 0x64, 0x22, 0xb1, 0x01, 0x0a, 0x05,
-	0x53, 0x68, 0x65, 0x6c, 0x66, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x2b,
-	0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x69, 0x6e,
-	0x74, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72,
-	0x6f, 0x77, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12,
-	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x6c, 0x6f,
-	0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x22,
-	0x32, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x4c, 0x61, 0x79, 0x6f, 0x75,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x68, 0x65, 0x6c,
-	0x66, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x68, 0x65, 0x6c,
-	0x66, 0x49, 0x64, 0x22, 0x3c, 0x0a, 0x13, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x4c, 0x61, 0x79, 0x6f,
-	0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x73, 0x68,
-	0x65, 0x6c, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x52, 0x05, 0x73, 0x68, 0x65, 0x6c,
-	0x66, 0x22, 0x78, 0x0a, 0x16, 0x46, 0x69, 0x6e, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c,
-	0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x0b, 0x73,
-	0x74, 0x61, 0x72, 0x74, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x69, 0x6e,
-	0x74, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2c, 0x0a,
-	0x09, 0x65, 0x6e, 0x64, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x69, 0x6e,
-	0x74, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x5a, 0x0a, 0x17, 0x46,
-	0x69, 0x6e, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x64,
-	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64,
-	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x57, 0x0a, 0x17, 0x53, 0x75, 0x67, 0x67, 0x65,
-	0x73, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x74, 0x65, 0x72,
-	0x69, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f, 0x6e, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x49, 0x64,
-	0x22, 0x4e, 0x0a, 0x18, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08,
-	0x73, 0x68, 0x65, 0x6c, 0x66, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x73, 0x68, 0x65, 0x6c, 0x66, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6c, 0x6f, 0x74, 0x5f,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6c, 0x6f, 0x74, 0x49, 0x64,
-	0x32, 0xf5, 0x02, 0x0a, 0x0f, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x68, 0x65, 0x6c, 0x66,
-	0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x1f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x64, 0x4f, 0x70,
-	0x74, 0x69, 0x6d, 0x61, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c,
-	0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6d,
-	0x61, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59,
-	0x0a, 0x10, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65,
-	0x6e, 0x74, 0x12, 0x21, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x75,
-	0x67, 0x67, 0x65, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x0a, 0x55, 0x70, 0x73,
-	0x65, 0x72, 0x74, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x0e, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x5a, 0x6f, 0x6e, 0x65, 0x1a, 0x0e, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x2f, 0x0a, 0x0b, 0x55, 0x70, 0x73, 0x65, 0x72,
-	0x74, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x12, 0x0f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x1a, 0x0f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x42, 0x5b, 0x5a, 0x59, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x31, 0x69, 0x33, 0x6b, 0x30, 0x65, 0x37, 0x2f,
-	0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x2d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2f, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x2f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2d, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+type AllocateSlotRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	MaterialType string                 `protobuf:"bytes,1,opt,name=material_type,json=materialType,proto3" json:"material_type,omitempty"`
+	ZoneId       string                 `protobuf:"bytes,2,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"` // Optional: allocate within a specific zone
+	MaterialId   string                 `protobuf:"bytes,3,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	Requester    string                 `protobuf:"bytes,4,opt,name=requester,proto3" json:"requester,omitempty"`                      // e.g., the service or operator allocating the slot, needed to release it
+	TtlSeconds   int32                  `protobuf:"varint,5,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // Optional: how long the slot stays reserved, 15 minutes when unset
+	// Optional: allocate this slot, e.g. one returned by SuggestPlacement, instead of the best available one
+	ShelfId       string `protobuf:"bytes,6,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	SlotId        string `protobuf:"bytes,7,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	StorageClass  string `protobuf:"bytes,8,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"` // Optional: only zones of this storage class
+	Role          string `protobuf:"bytes,9,opt,name=role,proto3" json:"role,omitempty"`                                     // Role of the requester
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-var (
-	file_api_proto_location_proto_rawDescOnce sync.Once
-	file_api_proto_location_proto_rawDescData = file_api_proto_location_proto_rawDesc
-)
+func (x *AllocateSlotRequest) Reset() {
+	*x = AllocateSlotRequest{}
+	mi := &file_api_proto_location_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func file_api_proto_location_proto_rawDescGZIP() []byte {
-	file_api_proto_location_proto_rawDescOnce.Do(func() {
-		file_api_proto_location_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proto_location_proto_rawDescData)
-	})
-	return file_api_proto_location_proto_rawDescData
+func (x *AllocateSlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_api_proto_location_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
-var file_api_proto_location_proto_goTypes = []interface{}{
-	(*Point)(nil),                    // 0: location.Point
-	(*Zone)(nil),                     // 1: location.Zone
-	(*Slot)(nil),                     // 2: location.Slot
-	(*Shelf)(nil),                    // 3: location.Shelf
-	(*GetShelfLayoutRequest)(nil),    // 4: location.GetShelfLayoutRequest
-	(*ShelfLayoutResponse)(nil),      // 5: location.ShelfLayoutResponse
-	(*FindOptimalPathRequest)(nil),   // 6: location.FindOptimalPathRequest
-	(*FindOptimalPathResponse)(nil),  // 7: location.FindOptimalPathResponse
-	(*SuggestPlacementRequest)(nil),  // 8: location.SuggestPlacementRequest
-	(*SuggestPlacementResponse)(nil), // 9: location.SuggestPlacementResponse
+func (*AllocateSlotRequest) ProtoMessage() {}
+
+func (x *AllocateSlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_api_proto_location_proto_depIdxs = []int32{
-	0,  // 0: location.Zone.boundary_points:type_name -> location.Point
-	0,  // 1: location.Slot.position:type_name -> location.Point
-	0,  // 2: location.Shelf.position:type_name -> location.Point
-	2,  // 3: location.Shelf.slots:type_name -> location.Slot
-	3,  // 4: location.ShelfLayoutResponse.shelf:type_name -> location.Shelf
-	0,  // 5: location.FindOptimalPathRequest.start_point:type_name -> location.Point
-	0,  // 6: location.FindOptimalPathRequest.end_point:type_name -> location.Point
-	0,  // 7: location.FindOptimalPathResponse.path:type_name -> location.Point
-	4,  // 8: location.LocationService.GetShelfLayout:input_type -> location.GetShelfLayoutRequest
-	6,  // 9: location.LocationService.FindOptimalPath:input_type -> location.FindOptimalPathRequest
-	8,  // 10: location.LocationService.SuggestPlacement:input_type -> location.SuggestPlacementRequest
-	1,  // 11: location.LocationService.UpsertZone:input_type -> location.Zone
-	3,  // 12: location.LocationService.UpsertShelf:input_type -> location.Shelf
-	5,  // 13: location.LocationService.GetShelfLayout:output_type -> location.ShelfLayoutResponse
-	7,  // 14: location.LocationService.FindOptimalPath:output_type -> location.FindOptimalPathResponse
-	9,  // 15: location.LocationService.SuggestPlacement:output_type -> location.SuggestPlacementResponse
-	1,  // 16: location.LocationService.UpsertZone:output_type -> location.Zone
-	3,  // 17: location.LocationService.UpsertShelf:output_type -> location.Shelf
-	13, // [13:18] is the sub-list for method output_type
-	8,  // [8:13] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+
+// Deprecated: Use AllocateSlotRequest.ProtoReflect.Descriptor instead.
+func (*AllocateSlotRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{11}
 }
 
-func init() { file_api_proto_location_proto_init() }
-func file_api_proto_location_proto_init() {
-	if File_api_proto_location_proto != nil {
-		return
+func (x *AllocateSlotRequest) GetMaterialType() string {
+	if x != nil {
+		return x.MaterialType
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_api_proto_location_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Point); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_proto_location_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Zone); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_proto_location_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Slot); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_proto_location_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Shelf); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_proto_location_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetShelfLayoutRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_proto_location_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShelfLayoutResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_proto_location_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FindOptimalPathRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_proto_location_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FindOptimalPathResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_proto_location_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SuggestPlacementRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_proto_location_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SuggestPlacementResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
+	return ""
+}
+
+func (x *AllocateSlotRequest) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+func (x *AllocateSlotRequest) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *AllocateSlotRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *AllocateSlotRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *AllocateSlotRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *AllocateSlotRequest) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *AllocateSlotRequest) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *AllocateSlotRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type AllocateSlotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId       string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	SlotId        string                 `protobuf:"bytes,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocateSlotResponse) Reset() {
+	*x = AllocateSlotResponse{}
+	mi := &file_api_proto_location_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocateSlotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateSlotResponse) ProtoMessage() {}
+
+func (x *AllocateSlotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateSlotResponse.ProtoReflect.Descriptor instead.
+func (*AllocateSlotResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AllocateSlotResponse) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *AllocateSlotResponse) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *AllocateSlotResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type ReleaseSlotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId       string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	SlotId        string                 `protobuf:"bytes,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	Requester     string                 `protobuf:"bytes,3,opt,name=requester,proto3" json:"requester,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseSlotRequest) Reset() {
+	*x = ReleaseSlotRequest{}
+	mi := &file_api_proto_location_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseSlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseSlotRequest) ProtoMessage() {}
+
+func (x *ReleaseSlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseSlotRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseSlotRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReleaseSlotRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *ReleaseSlotRequest) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *ReleaseSlotRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+type ReleaseSlotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseSlotResponse) Reset() {
+	*x = ReleaseSlotResponse{}
+	mi := &file_api_proto_location_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseSlotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseSlotResponse) ProtoMessage() {}
+
+func (x *ReleaseSlotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseSlotResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseSlotResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{14}
+}
+
+type UpdateSlotStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId       string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	SlotId        string                 `protobuf:"bytes,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                           // "EMPTY", "OCCUPIED", "RESERVED", "DISABLED"
+	MaterialId    string                 `protobuf:"bytes,4,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"` // Empty when the slot holds no material
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSlotStatusRequest) Reset() {
+	*x = UpdateSlotStatusRequest{}
+	mi := &file_api_proto_location_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSlotStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSlotStatusRequest) ProtoMessage() {}
+
+func (x *UpdateSlotStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSlotStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSlotStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateSlotStatusRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *UpdateSlotStatusRequest) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *UpdateSlotStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateSlotStatusRequest) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+type UpdateSlotStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSlotStatusResponse) Reset() {
+	*x = UpdateSlotStatusResponse{}
+	mi := &file_api_proto_location_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSlotStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSlotStatusResponse) ProtoMessage() {}
+
+func (x *UpdateSlotStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSlotStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSlotStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{16}
+}
+
+type ListZonesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListZonesRequest) Reset() {
+	*x = ListZonesRequest{}
+	mi := &file_api_proto_location_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListZonesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListZonesRequest) ProtoMessage() {}
+
+func (x *ListZonesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListZonesRequest.ProtoReflect.Descriptor instead.
+func (*ListZonesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{17}
+}
+
+type ListZonesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Zones         []*Zone                `protobuf:"bytes,1,rep,name=zones,proto3" json:"zones,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListZonesResponse) Reset() {
+	*x = ListZonesResponse{}
+	mi := &file_api_proto_location_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListZonesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListZonesResponse) ProtoMessage() {}
+
+func (x *ListZonesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListZonesResponse.ProtoReflect.Descriptor instead.
+func (*ListZonesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListZonesResponse) GetZones() []*Zone {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+type ListShelvesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZoneId        string                 `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"` // Optional: only shelves in this zone
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShelvesRequest) Reset() {
+	*x = ListShelvesRequest{}
+	mi := &file_api_proto_location_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShelvesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShelvesRequest) ProtoMessage() {}
+
+func (x *ListShelvesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShelvesRequest.ProtoReflect.Descriptor instead.
+func (*ListShelvesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListShelvesRequest) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+type ListShelvesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shelves       []*Shelf               `protobuf:"bytes,1,rep,name=shelves,proto3" json:"shelves,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShelvesResponse) Reset() {
+	*x = ListShelvesResponse{}
+	mi := &file_api_proto_location_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShelvesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShelvesResponse) ProtoMessage() {}
+
+func (x *ListShelvesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShelvesResponse.ProtoReflect.Descriptor instead.
+func (*ListShelvesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListShelvesResponse) GetShelves() []*Shelf {
+	if x != nil {
+		return x.Shelves
+	}
+	return nil
+}
+
+type DeleteShelfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId       string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShelfRequest) Reset() {
+	*x = DeleteShelfRequest{}
+	mi := &file_api_proto_location_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShelfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShelfRequest) ProtoMessage() {}
+
+func (x *DeleteShelfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShelfRequest.ProtoReflect.Descriptor instead.
+func (*DeleteShelfRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteShelfRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+type DeleteShelfResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShelfResponse) Reset() {
+	*x = DeleteShelfResponse{}
+	mi := &file_api_proto_location_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShelfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShelfResponse) ProtoMessage() {}
+
+func (x *DeleteShelfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_location_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShelfResponse.ProtoReflect.Descriptor instead.
+func (*DeleteShelfResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_location_proto_rawDescGZIP(), []int{22}
+}
+
+var File_api_proto_location_proto protoreflect.FileDescriptor
+
+const file_api_proto_location_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/proto/location.proto\x12\blocation\"1\n" +
+	"\x05Point\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\x12\f\n" +
+	"\x01z\x18\x03 \x01(\x05R\x01z\"\xfe\x01\n" +
+	"\x04Zone\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x128\n" +
+	"\x0fboundary_points\x18\x03 \x03(\v2\x0f.location.PointR\x0eboundaryPoints\x12#\n" +
+	"\rstorage_class\x18\x04 \x01(\tR\fstorageClass\x124\n" +
+	"\x16allowed_material_types\x18\x05 \x03(\tR\x14allowedMaterialTypes\x12\x1a\n" +
+	"\bcapacity\x18\x06 \x01(\x05R\bcapacity\x12!\n" +
+	"\faccess_roles\x18\a \x03(\tR\vaccessRoles\"\xc4\x01\n" +
+	"\x04Slot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
+	"\bposition\x18\x02 \x01(\v2\x0f.location.PointR\bposition\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vmaterial_id\x18\x04 \x01(\tR\n" +
+	"materialId\x12\x1f\n" +
+	"\vreserved_by\x18\x05 \x01(\tR\n" +
+	"reservedBy\x12%\n" +
+	"\x0ereserved_until\x18\x06 \x01(\x03R\rreservedUntil\"\xb1\x01\n" +
+	"\x05Shelf\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\azone_id\x18\x02 \x01(\tR\x06zoneId\x12+\n" +
+	"\bposition\x18\x03 \x01(\v2\x0f.location.PointR\bposition\x12\x12\n" +
+	"\x04rows\x18\x04 \x01(\x05R\x04rows\x12\x18\n" +
+	"\acolumns\x18\x05 \x01(\x05R\acolumns\x12$\n" +
+	"\x05slots\x18\x06 \x03(\v2\x0e.location.SlotR\x05slots\"2\n" +
+	"\x15GetShelfLayoutRequest\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\"<\n" +
+	"\x13ShelfLayoutResponse\x12%\n" +
+	"\x05shelf\x18\x01 \x01(\v2\x0f.location.ShelfR\x05shelf\"x\n" +
+	"\x16FindOptimalPathRequest\x120\n" +
+	"\vstart_point\x18\x01 \x01(\v2\x0f.location.PointR\n" +
+	"startPoint\x12,\n" +
+	"\tend_point\x18\x02 \x01(\v2\x0f.location.PointR\bendPoint\"Z\n" +
+	"\x17FindOptimalPathResponse\x12#\n" +
+	"\x04path\x18\x01 \x03(\v2\x0f.location.PointR\x04path\x12\x1a\n" +
+	"\bdistance\x18\x02 \x01(\x01R\bdistance\"\xc1\x01\n" +
+	"\x17SuggestPlacementRequest\x12#\n" +
+	"\rmaterial_type\x18\x01 \x01(\tR\fmaterialType\x12\x17\n" +
+	"\azone_id\x18\x02 \x01(\tR\x06zoneId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12#\n" +
+	"\rstorage_class\x18\x04 \x01(\tR\fstorageClass\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x19\n" +
+	"\bshelf_id\x18\x06 \x01(\tR\ashelfId\"d\n" +
+	"\x12PlacementCandidate\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\x12\x17\n" +
+	"\aslot_id\x18\x02 \x01(\tR\x06slotId\x12\x1a\n" +
+	"\bdistance\x18\x03 \x01(\x05R\bdistance\"\x8c\x01\n" +
+	"\x18SuggestPlacementResponse\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\x12\x17\n" +
+	"\aslot_id\x18\x02 \x01(\tR\x06slotId\x12<\n" +
+	"\n" +
+	"candidates\x18\x03 \x03(\v2\x1c.location.PlacementCandidateR\n" +
+	"candidates\"\xa0\x02\n" +
+	"\x13AllocateSlotRequest\x12#\n" +
+	"\rmaterial_type\x18\x01 \x01(\tR\fmaterialType\x12\x17\n" +
+	"\azone_id\x18\x02 \x01(\tR\x06zoneId\x12\x1f\n" +
+	"\vmaterial_id\x18\x03 \x01(\tR\n" +
+	"materialId\x12\x1c\n" +
+	"\trequester\x18\x04 \x01(\tR\trequester\x12\x1f\n" +
+	"\vttl_seconds\x18\x05 \x01(\x05R\n" +
+	"ttlSeconds\x12\x19\n" +
+	"\bshelf_id\x18\x06 \x01(\tR\ashelfId\x12\x17\n" +
+	"\aslot_id\x18\a \x01(\tR\x06slotId\x12#\n" +
+	"\rstorage_class\x18\b \x01(\tR\fstorageClass\x12\x12\n" +
+	"\x04role\x18\t \x01(\tR\x04role\"i\n" +
+	"\x14AllocateSlotResponse\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\x12\x17\n" +
+	"\aslot_id\x18\x02 \x01(\tR\x06slotId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\"f\n" +
+	"\x12ReleaseSlotRequest\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\x12\x17\n" +
+	"\aslot_id\x18\x02 \x01(\tR\x06slotId\x12\x1c\n" +
+	"\trequester\x18\x03 \x01(\tR\trequester\"\x15\n" +
+	"\x13ReleaseSlotResponse\"\x86\x01\n" +
+	"\x17UpdateSlotStatusRequest\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\x12\x17\n" +
+	"\aslot_id\x18\x02 \x01(\tR\x06slotId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vmaterial_id\x18\x04 \x01(\tR\n" +
+	"materialId\"\x1a\n" +
+	"\x18UpdateSlotStatusResponse\"\x12\n" +
+	"\x10ListZonesRequest\"9\n" +
+	"\x11ListZonesResponse\x12$\n" +
+	"\x05zones\x18\x01 \x03(\v2\x0e.location.ZoneR\x05zones\"-\n" +
+	"\x12ListShelvesRequest\x12\x17\n" +
+	"\azone_id\x18\x01 \x01(\tR\x06zoneId\"@\n" +
+	"\x13ListShelvesResponse\x12)\n" +
+	"\ashelves\x18\x01 \x03(\v2\x0f.location.ShelfR\ashelves\"/\n" +
+	"\x12DeleteShelfRequest\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\"\x15\n" +
+	"\x13DeleteShelfResponse2\xc9\x06\n" +
+	"\x0fLocationService\x12P\n" +
+	"\x0eGetShelfLayout\x12\x1f.location.GetShelfLayoutRequest\x1a\x1d.location.ShelfLayoutResponse\x12V\n" +
+	"\x0fFindOptimalPath\x12 .location.FindOptimalPathRequest\x1a!.location.FindOptimalPathResponse\x12Y\n" +
+	"\x10SuggestPlacement\x12!.location.SuggestPlacementRequest\x1a\".location.SuggestPlacementResponse\x12M\n" +
+	"\fAllocateSlot\x12\x1d.location.AllocateSlotRequest\x1a\x1e.location.AllocateSlotResponse\x12J\n" +
+	"\vReleaseSlot\x12\x1c.location.ReleaseSlotRequest\x1a\x1d.location.ReleaseSlotResponse\x12Y\n" +
+	"\x10UpdateSlotStatus\x12!.location.UpdateSlotStatusRequest\x1a\".location.UpdateSlotStatusResponse\x12,\n" +
+	"\n" +
+	"UpsertZone\x12\x0e.location.Zone\x1a\x0e.location.Zone\x12/\n" +
+	"\vUpsertShelf\x12\x0f.location.Shelf\x1a\x0f.location.Shelf\x12D\n" +
+	"\tListZones\x12\x1a.location.ListZonesRequest\x1a\x1b.location.ListZonesResponse\x12J\n" +
+	"\vListShelves\x12\x1c.location.ListShelvesRequest\x1a\x1d.location.ListShelvesResponse\x12J\n" +
+	"\vDeleteShelf\x12\x1c.location.DeleteShelfRequest\x1a\x1d.location.DeleteShelfResponseB[ZYgithub.com/m1i3k0e7/warehouse-management-system/services/location-service/api/proto;protob\x06proto3"
+
+var (
+	file_api_proto_location_proto_rawDescOnce sync.Once
+	file_api_proto_location_proto_rawDescData []byte
+)
+
+func file_api_proto_location_proto_rawDescGZIP() []byte {
+	file_api_proto_location_proto_rawDescOnce.Do(func() {
+		file_api_proto_location_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_location_proto_rawDesc), len(file_api_proto_location_proto_rawDesc)))
+	})
+	return file_api_proto_location_proto_rawDescData
+}
+
+var file_api_proto_location_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_api_proto_location_proto_goTypes = []any{
+	(*Point)(nil),                    // 0: location.Point
+	(*Zone)(nil),                     // 1: location.Zone
+	(*Slot)(nil),                     // 2: location.Slot
+	(*Shelf)(nil),                    // 3: location.Shelf
+	(*GetShelfLayoutRequest)(nil),    // 4: location.GetShelfLayoutRequest
+	(*ShelfLayoutResponse)(nil),      // 5: location.ShelfLayoutResponse
+	(*FindOptimalPathRequest)(nil),   // 6: location.FindOptimalPathRequest
+	(*FindOptimalPathResponse)(nil),  // 7: location.FindOptimalPathResponse
+	(*SuggestPlacementRequest)(nil),  // 8: location.SuggestPlacementRequest
+	(*PlacementCandidate)(nil),       // 9: location.PlacementCandidate
+	(*SuggestPlacementResponse)(nil), // 10: location.SuggestPlacementResponse
+	(*AllocateSlotRequest)(nil),      // 11: location.AllocateSlotRequest
+	(*AllocateSlotResponse)(nil),     // 12: location.AllocateSlotResponse
+	(*ReleaseSlotRequest)(nil),       // 13: location.ReleaseSlotRequest
+	(*ReleaseSlotResponse)(nil),      // 14: location.ReleaseSlotResponse
+	(*UpdateSlotStatusRequest)(nil),  // 15: location.UpdateSlotStatusRequest
+	(*UpdateSlotStatusResponse)(nil), // 16: location.UpdateSlotStatusResponse
+	(*ListZonesRequest)(nil),         // 17: location.ListZonesRequest
+	(*ListZonesResponse)(nil),        // 18: location.ListZonesResponse
+	(*ListShelvesRequest)(nil),       // 19: location.ListShelvesRequest
+	(*ListShelvesResponse)(nil),      // 20: location.ListShelvesResponse
+	(*DeleteShelfRequest)(nil),       // 21: location.DeleteShelfRequest
+	(*DeleteShelfResponse)(nil),      // 22: location.DeleteShelfResponse
+}
+var file_api_proto_location_proto_depIdxs = []int32{
+	0,  // 0: location.Zone.boundary_points:type_name -> location.Point
+	0,  // 1: location.Slot.position:type_name -> location.Point
+	0,  // 2: location.Shelf.position:type_name -> location.Point
+	2,  // 3: location.Shelf.slots:type_name -> location.Slot
+	3,  // 4: location.ShelfLayoutResponse.shelf:type_name -> location.Shelf
+	0,  // 5: location.FindOptimalPathRequest.start_point:type_name -> location.Point
+	0,  // 6: location.FindOptimalPathRequest.end_point:type_name -> location.Point
+	0,  // 7: location.FindOptimalPathResponse.path:type_name -> location.Point
+	9,  // 8: location.SuggestPlacementResponse.candidates:type_name -> location.PlacementCandidate
+	1,  // 9: location.ListZonesResponse.zones:type_name -> location.Zone
+	3,  // 10: location.ListShelvesResponse.shelves:type_name -> location.Shelf
+	4,  // 11: location.LocationService.GetShelfLayout:input_type -> location.GetShelfLayoutRequest
+	6,  // 12: location.LocationService.FindOptimalPath:input_type -> location.FindOptimalPathRequest
+	8,  // 13: location.LocationService.SuggestPlacement:input_type -> location.SuggestPlacementRequest
+	11, // 14: location.LocationService.AllocateSlot:input_type -> location.AllocateSlotRequest
+	13, // 15: location.LocationService.ReleaseSlot:input_type -> location.ReleaseSlotRequest
+	15, // 16: location.LocationService.UpdateSlotStatus:input_type -> location.UpdateSlotStatusRequest
+	1,  // 17: location.LocationService.UpsertZone:input_type -> location.Zone
+	3,  // 18: location.LocationService.UpsertShelf:input_type -> location.Shelf
+	17, // 19: location.LocationService.ListZones:input_type -> location.ListZonesRequest
+	19, // 20: location.LocationService.ListShelves:input_type -> location.ListShelvesRequest
+	21, // 21: location.LocationService.DeleteShelf:input_type -> location.DeleteShelfRequest
+	5,  // 22: location.LocationService.GetShelfLayout:output_type -> location.ShelfLayoutResponse
+	7,  // 23: location.LocationService.FindOptimalPath:output_type -> location.FindOptimalPathResponse
+	10, // 24: location.LocationService.SuggestPlacement:output_type -> location.SuggestPlacementResponse
+	12, // 25: location.LocationService.AllocateSlot:output_type -> location.AllocateSlotResponse
+	14, // 26: location.LocationService.ReleaseSlot:output_type -> location.ReleaseSlotResponse
+	16, // 27: location.LocationService.UpdateSlotStatus:output_type -> location.UpdateSlotStatusResponse
+	1,  // 28: location.LocationService.UpsertZone:output_type -> location.Zone
+	3,  // 29: location.LocationService.UpsertShelf:output_type -> location.Shelf
+	18, // 30: location.LocationService.ListZones:output_type -> location.ListZonesResponse
+	20, // 31: location.LocationService.ListShelves:output_type -> location.ListShelvesResponse
+	22, // 32: location.LocationService.DeleteShelf:output_type -> location.DeleteShelfResponse
+	22, // [22:33] is the sub-list for method output_type
+	11, // [11:22] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_location_proto_init() }
+func file_api_proto_location_proto_init() {
+	if File_api_proto_location_proto != nil {
+		return
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_api_proto_location_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_location_proto_rawDesc), len(file_api_proto_location_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -909,7 +1572,6 @@ func file_api_proto_location_proto_init() {
 		MessageInfos:      file_api_proto_location_proto_msgTypes,
 	}.Build()
 	File_api_proto_location_proto = out.File
-	file_api_proto_location_proto_rawDesc = nil
 	file_api_proto_location_proto_goTypes = nil
 	file_api_proto_location_proto_depIdxs = nil
 }
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.2.0
+// - protoc-gen-go-grpc v1.5.1
 // - protoc             v5.29.3
 // source: api/proto/location.proto
 
@@ -15,24 +15,52 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.32.0 or later.
-const _ = grpc.SupportPackageIsVersion7
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LocationService_GetShelfLayout_FullMethodName   = "/location.LocationService/GetShelfLayout"
+	LocationService_FindOptimalPath_FullMethodName  = "/location.LocationService/FindOptimalPath"
+	LocationService_SuggestPlacement_FullMethodName = "/location.LocationService/SuggestPlacement"
+	LocationService_AllocateSlot_FullMethodName     = "/location.LocationService/AllocateSlot"
+	LocationService_ReleaseSlot_FullMethodName      = "/location.LocationService/ReleaseSlot"
+	LocationService_UpdateSlotStatus_FullMethodName = "/location.LocationService/UpdateSlotStatus"
+	LocationService_UpsertZone_FullMethodName       = "/location.LocationService/UpsertZone"
+	LocationService_UpsertShelf_FullMethodName      = "/location.LocationService/UpsertShelf"
+	LocationService_ListZones_FullMethodName        = "/location.LocationService/ListZones"
+	LocationService_ListShelves_FullMethodName      = "/location.LocationService/ListShelves"
+	LocationService_DeleteShelf_FullMethodName      = "/location.LocationService/DeleteShelf"
+)
 
 // LocationServiceClient is the client API for LocationService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LocationService provides functionalities for warehouse layout and pathfinding.
 type LocationServiceClient interface {
 	// Get the layout of a specific shelf
 	GetShelfLayout(ctx context.Context, in *GetShelfLayoutRequest, opts ...grpc.CallOption) (*ShelfLayoutResponse, error)
 	// Find the optimal path between two points in the warehouse
 	FindOptimalPath(ctx context.Context, in *FindOptimalPathRequest, opts ...grpc.CallOption) (*FindOptimalPathResponse, error)
-	// Suggest a suitable slot for a new material
+	// Suggest suitable slots for a new material, ranked best first. Nothing is reserved.
 	SuggestPlacement(ctx context.Context, in *SuggestPlacementRequest, opts ...grpc.CallOption) (*SuggestPlacementResponse, error)
+	// Reserve a slot for a material until the allocation runs out or is released
+	AllocateSlot(ctx context.Context, in *AllocateSlotRequest, opts ...grpc.CallOption) (*AllocateSlotResponse, error)
+	// Release a slot reserved through AllocateSlot
+	ReleaseSlot(ctx context.Context, in *ReleaseSlotRequest, opts ...grpc.CallOption) (*ReleaseSlotResponse, error)
+	// Mirror a slot status change made by inventory-service, which owns the slot state
+	UpdateSlotStatus(ctx context.Context, in *UpdateSlotStatusRequest, opts ...grpc.CallOption) (*UpdateSlotStatusResponse, error)
 	// --- Admin Endpoints ---
 	// Create or Update a Zone
 	UpsertZone(ctx context.Context, in *Zone, opts ...grpc.CallOption) (*Zone, error)
 	// Create or Update a Shelf
 	UpsertShelf(ctx context.Context, in *Shelf, opts ...grpc.CallOption) (*Shelf, error)
+	// List all zones
+	ListZones(ctx context.Context, in *ListZonesRequest, opts ...grpc.CallOption) (*ListZonesResponse, error)
+	// List the shelves of a zone, or of the whole warehouse
+	ListShelves(ctx context.Context, in *ListShelvesRequest, opts ...grpc.CallOption) (*ListShelvesResponse, error)
+	// Delete a shelf that holds no material
+	DeleteShelf(ctx context.Context, in *DeleteShelfRequest, opts ...grpc.CallOption) (*DeleteShelfResponse, error)
 }
 
 type locationServiceClient struct {
@@ -44,8 +72,9 @@ func NewLocationServiceClient(cc grpc.ClientConnInterface) LocationServiceClient
 }
 
 func (c *locationServiceClient) GetShelfLayout(ctx context.Context, in *GetShelfLayoutRequest, opts ...grpc.CallOption) (*ShelfLayoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ShelfLayoutResponse)
-	err := c.cc.Invoke(ctx, "/location.LocationService/GetShelfLayout", in, out, opts...)
+	err := c.cc.Invoke(ctx, LocationService_GetShelfLayout_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -53,8 +82,9 @@ func (c *locationServiceClient) GetShelfLayout(ctx context.Context, in *GetShelf
 }
 
 func (c *locationServiceClient) FindOptimalPath(ctx context.Context, in *FindOptimalPathRequest, opts ...grpc.CallOption) (*FindOptimalPathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FindOptimalPathResponse)
-	err := c.cc.Invoke(ctx, "/location.LocationService/FindOptimalPath", in, out, opts...)
+	err := c.cc.Invoke(ctx, LocationService_FindOptimalPath_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -62,8 +92,39 @@ func (c *locationServiceClient) FindOptimalPath(ctx context.Context, in *FindOpt
 }
 
 func (c *locationServiceClient) SuggestPlacement(ctx context.Context, in *SuggestPlacementRequest, opts ...grpc.CallOption) (*SuggestPlacementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SuggestPlacementResponse)
-	err := c.cc.Invoke(ctx, "/location.LocationService/SuggestPlacement", in, out, opts...)
+	err := c.cc.Invoke(ctx, LocationService_SuggestPlacement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) AllocateSlot(ctx context.Context, in *AllocateSlotRequest, opts ...grpc.CallOption) (*AllocateSlotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllocateSlotResponse)
+	err := c.cc.Invoke(ctx, LocationService_AllocateSlot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) ReleaseSlot(ctx context.Context, in *ReleaseSlotRequest, opts ...grpc.CallOption) (*ReleaseSlotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseSlotResponse)
+	err := c.cc.Invoke(ctx, LocationService_ReleaseSlot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) UpdateSlotStatus(ctx context.Context, in *UpdateSlotStatusRequest, opts ...grpc.CallOption) (*UpdateSlotStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSlotStatusResponse)
+	err := c.cc.Invoke(ctx, LocationService_UpdateSlotStatus_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -71,8 +132,9 @@ func (c *locationServiceClient) SuggestPlacement(ctx context.Context, in *Sugges
 }
 
 func (c *locationServiceClient) UpsertZone(ctx context.Context, in *Zone, opts ...grpc.CallOption) (*Zone, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Zone)
-	err := c.cc.Invoke(ctx, "/location.LocationService/UpsertZone", in, out, opts...)
+	err := c.cc.Invoke(ctx, LocationService_UpsertZone_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -80,8 +142,39 @@ func (c *locationServiceClient) UpsertZone(ctx context.Context, in *Zone, opts .
 }
 
 func (c *locationServiceClient) UpsertShelf(ctx context.Context, in *Shelf, opts ...grpc.CallOption) (*Shelf, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Shelf)
-	err := c.cc.Invoke(ctx, "/location.LocationService/UpsertShelf", in, out, opts...)
+	err := c.cc.Invoke(ctx, LocationService_UpsertShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) ListZones(ctx context.Context, in *ListZonesRequest, opts ...grpc.CallOption) (*ListZonesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListZonesResponse)
+	err := c.cc.Invoke(ctx, LocationService_ListZones_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) ListShelves(ctx context.Context, in *ListShelvesRequest, opts ...grpc.CallOption) (*ListShelvesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShelvesResponse)
+	err := c.cc.Invoke(ctx, LocationService_ListShelves_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationServiceClient) DeleteShelf(ctx context.Context, in *DeleteShelfRequest, opts ...grpc.CallOption) (*DeleteShelfResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteShelfResponse)
+	err := c.cc.Invoke(ctx, LocationService_DeleteShelf_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -90,25 +183,42 @@ func (c *locationServiceClient) UpsertShelf(ctx context.Context, in *Shelf, opts
 
 // LocationServiceServer is the server API for LocationService service.
 // All implementations must embed UnimplementedLocationServiceServer
-// for forward compatibility
+// for forward compatibility.
+//
+// LocationService provides functionalities for warehouse layout and pathfinding.
 type LocationServiceServer interface {
 	// Get the layout of a specific shelf
 	GetShelfLayout(context.Context, *GetShelfLayoutRequest) (*ShelfLayoutResponse, error)
 	// Find the optimal path between two points in the warehouse
 	FindOptimalPath(context.Context, *FindOptimalPathRequest) (*FindOptimalPathResponse, error)
-	// Suggest a suitable slot for a new material
+	// Suggest suitable slots for a new material, ranked best first. Nothing is reserved.
 	SuggestPlacement(context.Context, *SuggestPlacementRequest) (*SuggestPlacementResponse, error)
+	// Reserve a slot for a material until the allocation runs out or is released
+	AllocateSlot(context.Context, *AllocateSlotRequest) (*AllocateSlotResponse, error)
+	// Release a slot reserved through AllocateSlot
+	ReleaseSlot(context.Context, *ReleaseSlotRequest) (*ReleaseSlotResponse, error)
+	// Mirror a slot status change made by inventory-service, which owns the slot state
+	UpdateSlotStatus(context.Context, *UpdateSlotStatusRequest) (*UpdateSlotStatusResponse, error)
 	// --- Admin Endpoints ---
 	// Create or Update a Zone
 	UpsertZone(context.Context, *Zone) (*Zone, error)
 	// Create or Update a Shelf
 	UpsertShelf(context.Context, *Shelf) (*Shelf, error)
+	// List all zones
+	ListZones(context.Context, *ListZonesRequest) (*ListZonesResponse, error)
+	// List the shelves of a zone, or of the whole warehouse
+	ListShelves(context.Context, *ListShelvesRequest) (*ListShelvesResponse, error)
+	// Delete a shelf that holds no material
+	DeleteShelf(context.Context, *DeleteShelfRequest) (*DeleteShelfResponse, error)
 	mustEmbedUnimplementedLocationServiceServer()
 }
 
-// UnimplementedLocationServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedLocationServiceServer struct {
-}
+// UnimplementedLocationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLocationServiceServer struct{}
 
 func (UnimplementedLocationServiceServer) GetShelfLayout(context.Context, *GetShelfLayoutRequest) (*ShelfLayoutResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetShelfLayout not implemented")
@@ -119,13 +229,32 @@ func (UnimplementedLocationServiceServer) FindOptimalPath(context.Context, *Find
 func (UnimplementedLocationServiceServer) SuggestPlacement(context.Context, *SuggestPlacementRequest) (*SuggestPlacementResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SuggestPlacement not implemented")
 }
+func (UnimplementedLocationServiceServer) AllocateSlot(context.Context, *AllocateSlotRequest) (*AllocateSlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllocateSlot not implemented")
+}
+func (UnimplementedLocationServiceServer) ReleaseSlot(context.Context, *ReleaseSlotRequest) (*ReleaseSlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseSlot not implemented")
+}
+func (UnimplementedLocationServiceServer) UpdateSlotStatus(context.Context, *UpdateSlotStatusRequest) (*UpdateSlotStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSlotStatus not implemented")
+}
 func (UnimplementedLocationServiceServer) UpsertZone(context.Context, *Zone) (*Zone, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpsertZone not implemented")
 }
 func (UnimplementedLocationServiceServer) UpsertShelf(context.Context, *Shelf) (*Shelf, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpsertShelf not implemented")
 }
+func (UnimplementedLocationServiceServer) ListZones(context.Context, *ListZonesRequest) (*ListZonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListZones not implemented")
+}
+func (UnimplementedLocationServiceServer) ListShelves(context.Context, *ListShelvesRequest) (*ListShelvesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShelves not implemented")
+}
+func (UnimplementedLocationServiceServer) DeleteShelf(context.Context, *DeleteShelfRequest) (*DeleteShelfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShelf not implemented")
+}
 func (UnimplementedLocationServiceServer) mustEmbedUnimplementedLocationServiceServer() {}
+func (UnimplementedLocationServiceServer) testEmbeddedByValue()                         {}
 
 // UnsafeLocationServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to LocationServiceServer will
@@ -135,6 +264,13 @@ type UnsafeLocationServiceServer interface {
 }
 
 func RegisterLocationServiceServer(s grpc.ServiceRegistrar, srv LocationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLocationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
 	s.RegisterService(&LocationService_ServiceDesc, srv)
 }
 
@@ -148,7 +284,7 @@ func _LocationService_GetShelfLayout_Handler(srv interface{}, ctx context.Contex
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/location.LocationService/GetShelfLayout",
+		FullMethod: LocationService_GetShelfLayout_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(LocationServiceServer).GetShelfLayout(ctx, req.(*GetShelfLayoutRequest))
@@ -166,7 +302,7 @@ func _LocationService_FindOptimalPath_Handler(srv interface{}, ctx context.Conte
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/location.LocationService/FindOptimalPath",
+		FullMethod: LocationService_FindOptimalPath_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(LocationServiceServer).FindOptimalPath(ctx, req.(*FindOptimalPathRequest))
@@ -184,7 +320,7 @@ func _LocationService_SuggestPlacement_Handler(srv interface{}, ctx context.Cont
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/location.LocationService/SuggestPlacement",
+		FullMethod: LocationService_SuggestPlacement_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(LocationServiceServer).SuggestPlacement(ctx, req.(*SuggestPlacementRequest))
@@ -192,6 +328,60 @@ func _LocationService_SuggestPlacement_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LocationService_AllocateSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocateSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).AllocateSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LocationService_AllocateSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).AllocateSlot(ctx, req.(*AllocateSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_ReleaseSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).ReleaseSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LocationService_ReleaseSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).ReleaseSlot(ctx, req.(*ReleaseSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_UpdateSlotStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSlotStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).UpdateSlotStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LocationService_UpdateSlotStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).UpdateSlotStatus(ctx, req.(*UpdateSlotStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LocationService_UpsertZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Zone)
 	if err := dec(in); err != nil {
@@ -202,7 +392,7 @@ func _LocationService_UpsertZone_Handler(srv interface{}, ctx context.Context, d
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/location.LocationService/UpsertZone",
+		FullMethod: LocationService_UpsertZone_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(LocationServiceServer).UpsertZone(ctx, req.(*Zone))
@@ -220,7 +410,7 @@ func _LocationService_UpsertShelf_Handler(srv interface{}, ctx context.Context,
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/location.LocationService/UpsertShelf",
+		FullMethod: LocationService_UpsertShelf_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(LocationServiceServer).UpsertShelf(ctx, req.(*Shelf))
@@ -228,6 +418,60 @@ func _LocationService_UpsertShelf_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LocationService_ListZones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListZonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).ListZones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LocationService_ListZones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).ListZones(ctx, req.(*ListZonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_ListShelves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShelvesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).ListShelves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LocationService_ListShelves_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).ListShelves(ctx, req.(*ListShelvesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocationService_DeleteShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServiceServer).DeleteShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LocationService_DeleteShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServiceServer).DeleteShelf(ctx, req.(*DeleteShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LocationService_ServiceDesc is the grpc.ServiceDesc for LocationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -247,6 +491,18 @@ var LocationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SuggestPlacement",
 			Handler:    _LocationService_SuggestPlacement_Handler,
 		},
+		{
+			MethodName: "AllocateSlot",
+			Handler:    _LocationService_AllocateSlot_Handler,
+		},
+		{
+			MethodName: "ReleaseSlot",
+			Handler:    _LocationService_ReleaseSlot_Handler,
+		},
+		{
+			MethodName: "UpdateSlotStatus",
+			Handler:    _LocationService_UpdateSlotStatus_Handler,
+		},
 		{
 			MethodName: "UpsertZone",
 			Handler:    _LocationService_UpsertZone_Handler,
@@ -255,6 +511,18 @@ var LocationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpsertShelf",
 			Handler:    _LocationService_UpsertShelf_Handler,
 		},
+		{
+			MethodName: "ListZones",
+			Handler:    _LocationService_ListZones_Handler,
+		},
+		{
+			MethodName: "ListShelves",
+			Handler:    _LocationService_ListShelves_Handler,
+		},
+		{
+			MethodName: "DeleteShelf",
+			Handler:    _LocationService_DeleteShelf_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/location.proto",
@@ -5,9 +5,14 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/application/commands"
 	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/infrastructure/database"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/infrastructure/messaging"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/interfaces/consumer"
 	grpc_server "github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/interfaces/grpc"
 	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/interfaces/grpc/handlers"
 )
@@ -17,6 +22,9 @@ func main() {
 	port := getEnv("PORT", ":50052")
 	mongoURI := getEnv("MONGO_URI", "mongodb://localhost:27017")
 	dbName := getEnv("DB_NAME", "location_service")
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inventoryEventsTopic := getEnv("INVENTORY_EVENTS_TOPIC", "inventory_events") // consuming is disabled when empty
+	consumerGroup := getEnv("KAFKA_CONSUMER_GROUP", "location-service")
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -32,6 +40,35 @@ func main() {
 		}
 	}()
 
+	// Keep slot occupancy in line with inventory-service
+	if inventoryEventsTopic != "" {
+		applyHandler := commands.NewApplyInventoryEventCommandHandler(repo, repo)
+		subscriber := messaging.NewKafkaSubscriber(strings.Split(kafkaBrokers, ","))
+		eventConsumer := consumer.NewInventoryEventConsumer(subscriber, inventoryEventsTopic, consumerGroup, applyHandler)
+		go func() {
+			if err := eventConsumer.Start(ctx); err != nil {
+				log.Printf("inventory event consumer stopped: %v", err)
+			}
+		}()
+	}
+
+	// Free slots whose allocation ran out, suggestions already skip them but layout queries still show them reserved
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if _, err := repo.ReleaseExpiredAllocations(ctx, now); err != nil {
+					log.Printf("failed to release expired slot allocations: %v", err)
+				}
+			}
+		}
+	}()
+
 	// Create the location server
 	locationServer := handlers.NewLocationServer(repo, repo) // repo implements both interfaces
 
@@ -2,12 +2,36 @@ package commands
 
 import (
 	"context"
+	"errors"
+	"time"
 
-	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
 	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/repositories"
 	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/services"
 )
 
+// allocationCandidates is how many suggested slots are tried before giving up, each one may be taken
+// by a concurrent caller in the meantime.
+const allocationCandidates = 10
+
+// ErrSlotUnavailable is returned when the requested slot is not available anymore, or not for this material.
+var ErrSlotUnavailable = errors.New("slot is not available")
+
+// AllocateSlotCommand reserves a slot for a material. Without a slot ID the best suggested slot is taken.
+type AllocateSlotCommand struct {
+	Placement  services.PlacementRequest
+	SlotID     string // Optional: requires Placement.ShelfID
+	MaterialID string
+	Requester  string
+	TTL        time.Duration
+}
+
+// SlotAllocation is a slot reserved by AllocateSlot until ExpiresAt.
+type SlotAllocation struct {
+	ShelfID   string
+	SlotID    string
+	ExpiresAt time.Time
+}
+
 // AllocateSlotCommandHandler handles the AllocateSlot command.
 type AllocateSlotCommandHandler struct {
 	allocationService *services.AllocationService
@@ -22,23 +46,72 @@ func NewAllocateSlotCommandHandler(allocationService *services.AllocationService
 	}
 }
 
-// Handle executes the command.
-func (h *AllocateSlotCommandHandler) Handle(ctx context.Context, materialType, zoneID, materialID string) (*entities.Shelf, *entities.Slot, error) {
-	shelf, slot, err := h.allocationService.SuggestSlot(ctx, materialType, zoneID)
+// Handle executes the command. It returns nil when no slot is available.
+func (h *AllocateSlotCommandHandler) Handle(ctx context.Context, cmd AllocateSlotCommand) (*SlotAllocation, error) {
+	now := time.Now()
+	until := now.Add(cmd.TTL)
+
+	if cmd.SlotID != "" {
+		// the slot has to pass the storage policy of its zone like any suggested slot
+		candidates, err := h.allocationService.SuggestSlots(ctx, cmd.Placement, 0)
+		if err != nil {
+			return nil, err
+		}
+		candidate := findCandidate(candidates, cmd.SlotID)
+		if candidate == nil {
+			return nil, ErrSlotUnavailable
+		}
+
+		allocated, err := h.allocate(ctx, cmd, *candidate, until, now)
+		if err != nil {
+			return nil, err
+		}
+		if !allocated {
+			return nil, ErrSlotUnavailable
+		}
+		return &SlotAllocation{ShelfID: candidate.Shelf.ID, SlotID: cmd.SlotID, ExpiresAt: until}, nil
+	}
+
+	candidates, err := h.allocationService.SuggestSlots(ctx, cmd.Placement, allocationCandidates)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	if shelf == nil || slot == nil {
-		return nil, nil, nil // No slot found
+	for _, candidate := range candidates {
+		allocated, err := h.allocate(ctx, cmd, candidate, until, now)
+		if err != nil {
+			return nil, err
+		}
+		if allocated {
+			return &SlotAllocation{ShelfID: candidate.Shelf.ID, SlotID: candidate.Slot.ID, ExpiresAt: until}, nil
+		}
 	}
 
-	err = h.shelfRepo.UpdateSlotStatus(ctx, shelf.ID, slot.ID, entities.StatusReserved, materialID)
-	if err != nil {
-		return nil, nil, err
+	return nil, nil // No slot found
+}
+
+// allocate reserves the candidate slot and gives it back if the reservation took its zone over capacity.
+// Two callers racing for the last free slot of a zone may then both give up, the zone is never overfilled.
+func (h *AllocateSlotCommandHandler) allocate(ctx context.Context, cmd AllocateSlotCommand, candidate services.PlacementCandidate, until, now time.Time) (bool, error) {
+	allocated, err := h.shelfRepo.AllocateSlot(ctx, candidate.Shelf.ID, candidate.Slot.ID, cmd.MaterialID, cmd.Requester, until, now)
+	if err != nil || !allocated {
+		return false, err
 	}
 
-	slot.Status = entities.StatusReserved
-	slot.MaterialID = materialID
+	withinCapacity, err := h.allocationService.WithinCapacity(ctx, candidate.Shelf.ZoneID)
+	if err == nil && withinCapacity {
+		return true, nil
+	}
+	if _, releaseErr := h.shelfRepo.ReleaseSlot(ctx, candidate.Shelf.ID, candidate.Slot.ID, cmd.Requester); releaseErr != nil && err == nil {
+		err = releaseErr
+	}
+	return false, err
+}
 
-	return shelf, slot, nil
-}
\ No newline at end of file
+func findCandidate(candidates []services.PlacementCandidate, slotID string) *services.PlacementCandidate {
+	for i := range candidates {
+		if candidates[i].Slot.ID == slotID {
+			return &candidates[i]
+		}
+	}
+	return nil
+}
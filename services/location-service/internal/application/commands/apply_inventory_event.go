@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"WMS/shared/events"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/repositories"
+)
+
+// Inventory events that change the occupancy of a slot. inventory-service marks a slot occupied as soon as a
+// placement is requested, so the physical placement events are applied like material.placed. A removal is
+// published as material.removed when it is requested and the material stays in the slot until the shelf
+// confirms it, so only physical.removal.confirmed empties the slot. Unplanned placements and removals follow
+// what the shelf detected, a slot under maintenance is disabled until it is returned to service.
+const (
+	eventTypeMaterialPlaced             = "material.placed"
+	eventTypeMaterialRemoved            = "material.removed"
+	eventTypeMaterialMoved              = "material.moved"
+	eventTypeSlotsReserved              = "slots.reserved"
+	eventTypeSlotTakenOutOfService      = "slot.taken_out_of_service"
+	eventTypeSlotReturnedToService      = "slot.returned_to_service"
+	eventTypeUnplannedPlacement         = "unplanned.placement"
+	eventTypeUnplannedRemoval           = "unplanned.removal"
+	eventTypePhysicalPlacementRequested = "physical.placement.requested"
+	eventTypePhysicalPlacementConfirmed = "physical.placement.confirmed"
+	eventTypePhysicalPlacementFailed    = "physical.placement.failed"
+	eventTypePhysicalRemovalConfirmed   = "physical.removal.confirmed"
+	eventTypePhysicalRemovalFailed      = "physical.removal.failed"
+)
+
+// inventory-service reports the status of a slot returned to service in its own, lower case, terms
+const inventorySlotStatusOccupied = "occupied"
+
+// ErrInvalidEvent is returned for events that can never be applied, delivering them again does not help.
+var ErrInvalidEvent = errors.New("invalid inventory event")
+
+// ApplyInventoryEventCommand carries an event published by inventory-service.
+type ApplyInventoryEventCommand struct {
+	EventID   string
+	EventType string
+	Payload   []byte
+}
+
+// ApplyInventoryEventCommandHandler keeps the slots of the digital twin in line with inventory-service.
+// Events are applied idempotently: an event ID is only applied once and an update that is already in
+// place is skipped.
+type ApplyInventoryEventCommandHandler struct {
+	shelfRepo          repositories.ShelfRepository
+	processedEventRepo repositories.ProcessedEventRepository
+}
+
+// NewApplyInventoryEventCommandHandler creates a new ApplyInventoryEventCommandHandler.
+func NewApplyInventoryEventCommandHandler(shelfRepo repositories.ShelfRepository, processedEventRepo repositories.ProcessedEventRepository) *ApplyInventoryEventCommandHandler {
+	return &ApplyInventoryEventCommandHandler{
+		shelfRepo:          shelfRepo,
+		processedEventRepo: processedEventRepo,
+	}
+}
+
+// Handle executes the command. Event types that do not affect slots are ignored.
+func (h *ApplyInventoryEventCommandHandler) Handle(ctx context.Context, cmd ApplyInventoryEventCommand) error {
+	if cmd.EventID != "" {
+		processed, err := h.processedEventRepo.IsEventProcessed(ctx, cmd.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to check event %s: %w", cmd.EventID, err)
+		}
+		if processed {
+			return nil
+		}
+	}
+
+	applied, err := h.apply(ctx, cmd)
+	if err != nil || !applied || cmd.EventID == "" {
+		return err
+	}
+
+	// a crash before this point redelivers the event, applying it again leaves the slots unchanged
+	if err := h.processedEventRepo.MarkEventProcessed(ctx, cmd.EventID, cmd.EventType); err != nil {
+		return fmt.Errorf("failed to record event %s: %w", cmd.EventID, err)
+	}
+	return nil
+}
+
+func (h *ApplyInventoryEventCommandHandler) apply(ctx context.Context, cmd ApplyInventoryEventCommand) (bool, error) {
+	switch cmd.EventType {
+	case eventTypeMaterialPlaced:
+		var event events.MaterialPlacedEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		return true, h.updateSlot(ctx, event.ShelfID, event.SlotID, entities.StatusOccupied, event.MaterialID, "")
+
+	case eventTypePhysicalPlacementRequested, eventTypePhysicalPlacementConfirmed:
+		var event events.PhysicalPlacementEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		return true, h.updateSlot(ctx, event.ShelfID, event.SlotID, entities.StatusOccupied, event.MaterialID, "")
+
+	case eventTypePhysicalPlacementFailed:
+		var event events.PhysicalPlacementEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		return true, h.updateSlot(ctx, event.ShelfID, event.SlotID, entities.StatusEmpty, "", event.MaterialID)
+
+	case eventTypeMaterialRemoved:
+		// the removal is pending, the material is still in the slot
+		var event events.MaterialRemovedEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		return true, h.updateSlot(ctx, event.ShelfID, event.SlotID, entities.StatusOccupied, event.MaterialID, "")
+
+	case eventTypePhysicalRemovalConfirmed:
+		var event events.PhysicalPlacementEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		return true, h.updateSlot(ctx, event.ShelfID, event.SlotID, entities.StatusEmpty, "", event.MaterialID)
+
+	case eventTypePhysicalRemovalFailed:
+		var event events.PhysicalPlacementEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		return true, h.updateSlot(ctx, event.ShelfID, event.SlotID, entities.StatusOccupied, event.MaterialID, "")
+
+	case eventTypeMaterialMoved:
+		var event events.MaterialMovedEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		// the source slot may stand on another shelf than the one the event is keyed by
+		if err := h.updateSlot(ctx, "", event.FromSlotID, entities.StatusEmpty, "", event.MaterialID); err != nil {
+			return false, err
+		}
+		return true, h.updateSlot(ctx, event.ShelfID, event.ToSlotID, entities.StatusOccupied, event.MaterialID, "")
+
+	case eventTypeSlotsReserved:
+		var event events.SlotsReservedEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		for _, slotID := range event.SlotIDs {
+			if err := h.updateSlot(ctx, event.AggregateID, slotID, entities.StatusReserved, "", ""); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case eventTypeSlotTakenOutOfService:
+		var event events.SlotTakenOutOfServiceEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		return true, h.updateSlot(ctx, event.ShelfID, event.SlotID, entities.StatusDisabled, event.MaterialID, "")
+
+	case eventTypeSlotReturnedToService:
+		var event events.SlotReturnedToServiceEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		if event.Status == inventorySlotStatusOccupied {
+			return true, h.setSlotStatus(ctx, event.ShelfID, event.SlotID, entities.StatusOccupied)
+		}
+		return true, h.updateSlot(ctx, event.ShelfID, event.SlotID, entities.StatusEmpty, "", "")
+
+	case eventTypeUnplannedPlacement:
+		// the shelf only knows the barcode, the material of the slot stays as it is
+		var event events.UnplannedSlotEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		return true, h.setSlotStatus(ctx, event.ShelfID, event.SlotID, entities.StatusOccupied)
+
+	case eventTypeUnplannedRemoval:
+		var event events.UnplannedSlotEvent
+		if err := decodeEvent(cmd, &event); err != nil {
+			return false, err
+		}
+		return true, h.updateSlot(ctx, event.ShelfID, event.SlotID, entities.StatusEmpty, "", "")
+
+	default:
+		return false, nil
+	}
+}
+
+// updateSlot sets the status and material of a slot, looking the shelf up by the slot when shelfID is empty.
+// With releasedMaterialID set, a slot that holds another material by now is left alone, so a late release
+// does not clear a slot that was used again since. Slots location-service does not know are skipped.
+func (h *ApplyInventoryEventCommandHandler) updateSlot(ctx context.Context, shelfID, slotID string, status entities.SlotStatus, materialID, releasedMaterialID string) error {
+	shelf, slot, err := h.findSlot(ctx, shelfID, slotID)
+	if err != nil || slot == nil {
+		return err
+	}
+
+	if releasedMaterialID != "" && slot.MaterialID != "" && slot.MaterialID != releasedMaterialID {
+		return nil
+	}
+	return h.writeSlot(ctx, shelf, slot, status, materialID)
+}
+
+// setSlotStatus sets the status of a slot and keeps its material, for events that do not name one.
+func (h *ApplyInventoryEventCommandHandler) setSlotStatus(ctx context.Context, shelfID, slotID string, status entities.SlotStatus) error {
+	shelf, slot, err := h.findSlot(ctx, shelfID, slotID)
+	if err != nil || slot == nil {
+		return err
+	}
+	return h.writeSlot(ctx, shelf, slot, status, slot.MaterialID)
+}
+
+// writeSlot stores the status and material of the slot, unless they are already in place.
+func (h *ApplyInventoryEventCommandHandler) writeSlot(ctx context.Context, shelf *entities.Shelf, slot *entities.Slot, status entities.SlotStatus, materialID string) error {
+	if slot.Status == status && slot.MaterialID == materialID {
+		return nil
+	}
+	if err := h.shelfRepo.UpdateSlotStatus(ctx, shelf.ID, slot.ID, status, materialID); err != nil {
+		return fmt.Errorf("failed to update slot %s: %w", slot.ID, err)
+	}
+	return nil
+}
+
+// findSlot returns the slot and its shelf, or a nil slot if location-service does not know it.
+func (h *ApplyInventoryEventCommandHandler) findSlot(ctx context.Context, shelfID, slotID string) (*entities.Shelf, *entities.Slot, error) {
+	if slotID == "" {
+		return nil, nil, fmt.Errorf("%w: event has no slot ID", ErrInvalidEvent)
+	}
+
+	var shelf *entities.Shelf
+	var err error
+	if shelfID != "" {
+		shelf, err = h.shelfRepo.FindByID(ctx, shelfID)
+	} else {
+		shelf, err = h.shelfRepo.FindBySlotID(ctx, slotID)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find shelf of slot %s: %w", slotID, err)
+	}
+	if shelf == nil {
+		log.Printf("skipping update of slot %s, its shelf %s is not part of the layout", slotID, shelfID)
+		return nil, nil, nil
+	}
+
+	for i := range shelf.Slots {
+		if shelf.Slots[i].ID == slotID {
+			return shelf, &shelf.Slots[i], nil
+		}
+	}
+	log.Printf("skipping update of slot %s, shelf %s has no such slot", slotID, shelf.ID)
+	return shelf, nil, nil
+}
+
+func decodeEvent(cmd ApplyInventoryEventCommand, event interface{}) error {
+	if err := json.Unmarshal(cmd.Payload, event); err != nil {
+		return fmt.Errorf("%w: failed to decode %s event %s: %v", ErrInvalidEvent, cmd.EventType, cmd.EventID, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/repositories"
+)
+
+var (
+	// ErrShelfNotFound is returned when the shelf to delete does not exist.
+	ErrShelfNotFound = errors.New("shelf not found")
+	// ErrShelfInUse is returned when a slot of the shelf to delete still holds or awaits material.
+	ErrShelfInUse = errors.New("shelf still holds material")
+)
+
+// DeleteShelfCommandHandler handles the DeleteShelf command.
+type DeleteShelfCommandHandler struct {
+	shelfRepo repositories.ShelfRepository
+}
+
+// NewDeleteShelfCommandHandler creates a new DeleteShelfCommandHandler.
+func NewDeleteShelfCommandHandler(shelfRepo repositories.ShelfRepository) *DeleteShelfCommandHandler {
+	return &DeleteShelfCommandHandler{shelfRepo: shelfRepo}
+}
+
+// Handle executes the command. Only shelves whose slots are all empty or disabled can be deleted.
+func (h *DeleteShelfCommandHandler) Handle(ctx context.Context, shelfID string) error {
+	deleted, err := h.shelfRepo.DeleteIfUnused(ctx, shelfID)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		return nil
+	}
+
+	// tell a missing shelf from one that is still in use
+	shelf, err := h.shelfRepo.FindByID(ctx, shelfID)
+	if err != nil {
+		return err
+	}
+	if shelf == nil {
+		return ErrShelfNotFound
+	}
+	return ErrShelfInUse
+}
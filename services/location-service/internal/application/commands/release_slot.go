@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/repositories"
+)
+
+// ErrNotAllocated is returned when the requester holds no allocation of the slot.
+var ErrNotAllocated = errors.New("slot is not allocated to the requester")
+
+// ReleaseSlotCommandHandler handles the ReleaseSlot command.
+type ReleaseSlotCommandHandler struct {
+	shelfRepo repositories.ShelfRepository
+}
+
+// NewReleaseSlotCommandHandler creates a new ReleaseSlotCommandHandler.
+func NewReleaseSlotCommandHandler(shelfRepo repositories.ShelfRepository) *ReleaseSlotCommandHandler {
+	return &ReleaseSlotCommandHandler{shelfRepo: shelfRepo}
+}
+
+// Handle executes the command.
+func (h *ReleaseSlotCommandHandler) Handle(ctx context.Context, shelfID, slotID, requester string) error {
+	released, err := h.shelfRepo.ReleaseSlot(ctx, shelfID, slotID, requester)
+	if err != nil {
+		return err
+	}
+	if !released {
+		return ErrNotAllocated
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/repositories"
+)
+
+// UpsertShelfCommandHandler handles the UpsertShelf command.
+type UpsertShelfCommandHandler struct {
+	shelfRepo  repositories.ShelfRepository
+	layoutRepo repositories.LayoutRepository
+}
+
+// NewUpsertShelfCommandHandler creates a new UpsertShelfCommandHandler.
+func NewUpsertShelfCommandHandler(shelfRepo repositories.ShelfRepository, layoutRepo repositories.LayoutRepository) *UpsertShelfCommandHandler {
+	return &UpsertShelfCommandHandler{
+		shelfRepo:  shelfRepo,
+		layoutRepo: layoutRepo,
+	}
+}
+
+// ErrShelfChanged is returned when the slots of a shelf kept changing while its layout was being saved.
+var ErrShelfChanged = errors.New("shelf changed while saving its layout")
+
+// upsertShelfAttempts bounds how often a layout is merged again with slot state that changed in the meantime
+const upsertShelfAttempts = 3
+
+// Handle executes the command. Slots given without a status keep the state they have on the stored shelf,
+// which inventory-service keeps current, and start out empty on a new shelf. Slots can only be left out
+// of the stored shelf while they are empty or disabled. A slot that changes while the layout is merged,
+// e.g. is allocated, makes the handler merge again instead of overwriting the change.
+func (h *UpsertShelfCommandHandler) Handle(ctx context.Context, shelf *entities.Shelf) error {
+	if err := validateShelfGrid(shelf); err != nil {
+		return err
+	}
+
+	zone, err := h.layoutRepo.FindZoneByID(ctx, shelf.ZoneID)
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		return fmt.Errorf("%w: zone %s not found", ErrInvalidLayout, shelf.ZoneID)
+	}
+	if err := checkShelfInZone(zone, shelf); err != nil {
+		return err
+	}
+
+	requested := append([]entities.Slot(nil), shelf.Slots...)
+	for attempt := 0; attempt < upsertShelfAttempts; attempt++ {
+		shelf.Slots = append(shelf.Slots[:0], requested...)
+		saved, err := h.save(ctx, shelf)
+		if err != nil || saved {
+			return err
+		}
+	}
+	return fmt.Errorf("%w: shelf %s", ErrShelfChanged, shelf.ID)
+}
+
+// save merges the layout with the stored shelf and saves it, unless the stored shelf changed since it was read.
+func (h *UpsertShelfCommandHandler) save(ctx context.Context, shelf *entities.Shelf) (bool, error) {
+	existing, err := h.shelfRepo.FindByID(ctx, shelf.ID)
+	if err != nil {
+		return false, err
+	}
+	stored := make(map[string]entities.Slot)
+	shelf.Version = 0
+	if existing != nil {
+		for _, slot := range existing.Slots {
+			stored[slot.ID] = slot
+		}
+		shelf.Version = existing.Version
+	}
+
+	if err := checkRemovedSlots(stored, shelf); err != nil {
+		return false, err
+	}
+
+	for i := range shelf.Slots {
+		slot := &shelf.Slots[i]
+		if _, ok := stored[slot.ID]; !ok {
+			// slot IDs are unique across shelves, events of inventory-service only carry the slot ID
+			other, err := h.shelfRepo.FindBySlotID(ctx, slot.ID)
+			if err != nil {
+				return false, err
+			}
+			if other != nil && other.ID != shelf.ID {
+				return false, fmt.Errorf("%w: slot %s already belongs to shelf %s", ErrInvalidLayout, slot.ID, other.ID)
+			}
+		}
+
+		if slot.Status != "" {
+			continue
+		}
+		if previous, ok := stored[slot.ID]; ok {
+			slot.Status = previous.Status
+			slot.MaterialID = previous.MaterialID
+			slot.ReservedBy = previous.ReservedBy
+			slot.ReservedUntil = previous.ReservedUntil
+		} else {
+			slot.Status = entities.StatusEmpty
+		}
+	}
+
+	return h.shelfRepo.Save(ctx, shelf)
+}
+
+// checkRemovedSlots rejects dropping a stored slot that still holds or awaits material.
+func checkRemovedSlots(stored map[string]entities.Slot, shelf *entities.Shelf) error {
+	kept := make(map[string]bool, len(shelf.Slots))
+	for _, slot := range shelf.Slots {
+		kept[slot.ID] = true
+	}
+	now := time.Now()
+	for id, slot := range stored {
+		if kept[id] || slot.Status == entities.StatusDisabled || slot.IsAvailable(now) {
+			continue
+		}
+		return fmt.Errorf("%w: slot %s of shelf %s is %s and cannot be removed", ErrShelfInUse, id, shelf.ID, slot.Status)
+	}
+	return nil
+}
+
+// validateShelfGrid checks that the slots fill the rows × columns grid of the shelf, each with its own ID and position.
+func validateShelfGrid(shelf *entities.Shelf) error {
+	if shelf.ID == "" || shelf.ZoneID == "" {
+		return fmt.Errorf("%w: shelf id and zone id are required", ErrInvalidLayout)
+	}
+	if shelf.Rows <= 0 || shelf.Columns <= 0 {
+		return fmt.Errorf("%w: shelf %s needs at least one row and column", ErrInvalidLayout, shelf.ID)
+	}
+	if len(shelf.Slots) != shelf.Rows*shelf.Columns {
+		return fmt.Errorf("%w: shelf %s has %d slots, its %d × %d grid needs %d", ErrInvalidLayout, shelf.ID, len(shelf.Slots), shelf.Rows, shelf.Columns, shelf.Rows*shelf.Columns)
+	}
+
+	ids := make(map[string]bool, len(shelf.Slots))
+	positions := make(map[entities.Point]string, len(shelf.Slots))
+	for _, slot := range shelf.Slots {
+		if slot.ID == "" {
+			return fmt.Errorf("%w: every slot of shelf %s needs an id", ErrInvalidLayout, shelf.ID)
+		}
+		if ids[slot.ID] {
+			return fmt.Errorf("%w: slot %s appears twice on shelf %s", ErrInvalidLayout, slot.ID, shelf.ID)
+		}
+		ids[slot.ID] = true
+		if other, ok := positions[slot.Position]; ok {
+			return fmt.Errorf("%w: slots %s and %s share position %v", ErrInvalidLayout, other, slot.ID, slot.Position)
+		}
+		positions[slot.Position] = slot.ID
+
+		switch slot.Status {
+		case "", entities.StatusEmpty, entities.StatusOccupied, entities.StatusReserved, entities.StatusDisabled:
+		default:
+			return fmt.Errorf("%w: slot %s has unsupported status %q", ErrInvalidLayout, slot.ID, slot.Status)
+		}
+	}
+	return nil
+}
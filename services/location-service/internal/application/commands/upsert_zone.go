@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/repositories"
+)
+
+// ErrInvalidLayout is returned for zones and shelves that do not fit the warehouse layout.
+var ErrInvalidLayout = errors.New("invalid layout")
+
+// UpsertZoneCommandHandler handles the UpsertZone command.
+type UpsertZoneCommandHandler struct {
+	layoutRepo repositories.LayoutRepository
+}
+
+// NewUpsertZoneCommandHandler creates a new UpsertZoneCommandHandler.
+func NewUpsertZoneCommandHandler(layoutRepo repositories.LayoutRepository) *UpsertZoneCommandHandler {
+	return &UpsertZoneCommandHandler{layoutRepo: layoutRepo}
+}
+
+// Handle executes the command. A zone whose new boundary leaves out one of its shelves is rejected.
+func (h *UpsertZoneCommandHandler) Handle(ctx context.Context, zone *entities.Zone) error {
+	if zone.ID == "" {
+		return fmt.Errorf("%w: zone id is required", ErrInvalidLayout)
+	}
+	if len(zone.BoundaryPoints) < 3 {
+		return fmt.Errorf("%w: zone boundary needs at least 3 points", ErrInvalidLayout)
+	}
+	if !zone.StorageClass.IsValid() {
+		return fmt.Errorf("%w: unsupported storage class %q", ErrInvalidLayout, zone.StorageClass)
+	}
+	if zone.Capacity < 0 {
+		return fmt.Errorf("%w: zone capacity cannot be negative", ErrInvalidLayout)
+	}
+	for _, materialType := range zone.AllowedMaterialTypes {
+		if materialType == "" {
+			return fmt.Errorf("%w: allowed material types cannot be empty", ErrInvalidLayout)
+		}
+	}
+
+	shelves, err := h.layoutRepo.FindAllShelvesInZone(ctx, zone.ID)
+	if err != nil {
+		return err
+	}
+	for _, shelf := range shelves {
+		if err := checkShelfInZone(zone, shelf); err != nil {
+			return err
+		}
+	}
+
+	return h.layoutRepo.SaveZone(ctx, zone)
+}
+
+// checkShelfInZone checks that the shelf and every slot on it stand inside the zone boundary.
+func checkShelfInZone(zone *entities.Zone, shelf *entities.Shelf) error {
+	if !zone.Contains(shelf.Position) {
+		return fmt.Errorf("%w: shelf %s at %v is outside zone %s", ErrInvalidLayout, shelf.ID, shelf.Position, zone.ID)
+	}
+	for _, slot := range shelf.Slots {
+		if !zone.Contains(slot.Position) {
+			return fmt.Errorf("%w: slot %s at %v is outside zone %s", ErrInvalidLayout, slot.ID, slot.Position, zone.ID)
+		}
+	}
+	return nil
+}
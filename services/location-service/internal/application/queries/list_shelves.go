@@ -0,0 +1,26 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/repositories"
+)
+
+// ListShelvesQueryHandler handles the ListShelves query.
+type ListShelvesQueryHandler struct {
+	layoutRepo repositories.LayoutRepository
+}
+
+// NewListShelvesQueryHandler creates a new ListShelvesQueryHandler.
+func NewListShelvesQueryHandler(layoutRepo repositories.LayoutRepository) *ListShelvesQueryHandler {
+	return &ListShelvesQueryHandler{layoutRepo: layoutRepo}
+}
+
+// Handle executes the query. Without a zone every shelf is returned.
+func (h *ListShelvesQueryHandler) Handle(ctx context.Context, zoneID string) ([]*entities.Shelf, error) {
+	if zoneID == "" {
+		return h.layoutRepo.FindAllShelves(ctx)
+	}
+	return h.layoutRepo.FindAllShelvesInZone(ctx, zoneID)
+}
@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/repositories"
+)
+
+// ListZonesQueryHandler handles the ListZones query.
+type ListZonesQueryHandler struct {
+	layoutRepo repositories.LayoutRepository
+}
+
+// NewListZonesQueryHandler creates a new ListZonesQueryHandler.
+func NewListZonesQueryHandler(layoutRepo repositories.LayoutRepository) *ListZonesQueryHandler {
+	return &ListZonesQueryHandler{layoutRepo: layoutRepo}
+}
+
+// Handle executes the query.
+func (h *ListZonesQueryHandler) Handle(ctx context.Context) ([]*entities.Zone, error) {
+	return h.layoutRepo.FindAllZones(ctx)
+}
@@ -0,0 +1,22 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/services"
+)
+
+// SuggestPlacementQueryHandler handles the SuggestPlacement query.
+type SuggestPlacementQueryHandler struct {
+	allocationService *services.AllocationService
+}
+
+// NewSuggestPlacementQueryHandler creates a new SuggestPlacementQueryHandler.
+func NewSuggestPlacementQueryHandler(allocationService *services.AllocationService) *SuggestPlacementQueryHandler {
+	return &SuggestPlacementQueryHandler{allocationService: allocationService}
+}
+
+// Handle executes the query.
+func (h *SuggestPlacementQueryHandler) Handle(ctx context.Context, req services.PlacementRequest, limit int) ([]services.PlacementCandidate, error) {
+	return h.allocationService.SuggestSlots(ctx, req, limit)
+}
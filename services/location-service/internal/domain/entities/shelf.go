@@ -1,5 +1,7 @@
 package entities
 
+import "time"
+
 // Point represents a 3D coordinate in the warehouse.
 type Point struct {
 	X int
@@ -19,10 +21,21 @@ const (
 
 // Slot represents a single storage unit on a shelf.
 type Slot struct {
-	ID         string
-	Position   Point
-	Status     SlotStatus
-	MaterialID string // Foreign key to material in inventory-service
+	ID            string
+	Position      Point
+	Status        SlotStatus
+	MaterialID    string    // Foreign key to material in inventory-service
+	ReservedBy    string    // Requester holding the slot through AllocateSlot
+	ReservedUntil time.Time // When an allocation runs out, zero for reservations that do not expire
+}
+
+// IsAvailable reports whether the slot can take a new material. A reserved slot becomes available
+// again once its allocation has run out.
+func (s Slot) IsAvailable(now time.Time) bool {
+	if s.Status == StatusEmpty {
+		return true
+	}
+	return s.Status == StatusReserved && !s.ReservedUntil.IsZero() && now.After(s.ReservedUntil)
 }
 
 // Shelf represents a physical shelf in the warehouse.
@@ -33,4 +46,5 @@ type Shelf struct {
 	Rows     int
 	Columns  int
 	Slots    []Slot
+	Version  int // Counts the changes to the stored shelf, so a layout update does not overwrite slot changes it did not see
 }
\ No newline at end of file
@@ -1,8 +1,97 @@
 package entities
 
+// StorageClass describes the storage conditions a zone provides.
+type StorageClass string
+
+const (
+	StorageClassGeneral    StorageClass = "GENERAL"
+	StorageClassHighValue  StorageClass = "HIGH_VALUE"
+	StorageClassESD        StorageClass = "ESD"
+	StorageClassCold       StorageClass = "COLD"
+	StorageClassQuarantine StorageClass = "QUARANTINE" // only used when material is explicitly sent there
+)
+
+// IsValid reports whether the storage class is known, an empty class counts as general storage.
+func (c StorageClass) IsValid() bool {
+	switch c {
+	case "", StorageClassGeneral, StorageClassHighValue, StorageClassESD, StorageClassCold, StorageClassQuarantine:
+		return true
+	}
+	return false
+}
+
 // Zone represents a logical area in the warehouse, like "Receiving", "Packing", or "High-Value Storage".
 type Zone struct {
 	ID             string
 	Name           string
 	BoundaryPoints []Point // Defines the geographical area of the zone
-}
\ No newline at end of file
+
+	// Storage policy
+	StorageClass         StorageClass
+	AllowedMaterialTypes []string // Material types kept to this zone and others listing them, empty accepts unlisted types
+	Capacity             int      // Maximum number of occupied or reserved slots, 0 means no limit
+	AccessRoles          []string // Roles allowed to place material in the zone, empty means everyone
+}
+
+// Class returns the storage class of the zone, general storage when none is set.
+func (z *Zone) Class() StorageClass {
+	if z.StorageClass == "" {
+		return StorageClassGeneral
+	}
+	return z.StorageClass
+}
+
+// AllowsMaterialType reports whether the zone lists the material type.
+func (z *Zone) AllowsMaterialType(materialType string) bool {
+	for _, allowed := range z.AllowedMaterialTypes {
+		if allowed == materialType {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRole reports whether a requester with the given role may place material in the zone.
+func (z *Zone) AllowsRole(role string) bool {
+	if len(z.AccessRoles) == 0 {
+		return true
+	}
+	for _, allowed := range z.AccessRoles {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether a point lies inside the zone boundary or on its edge. The boundary is a polygon
+// on the warehouse floor, so the height of the point is ignored.
+func (z *Zone) Contains(p Point) bool {
+	n := len(z.BoundaryPoints)
+	if n < 3 {
+		return false
+	}
+
+	inside := false
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		a, b := z.BoundaryPoints[i], z.BoundaryPoints[j]
+		if onSegment(p, a, b) {
+			return true
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) {
+			// x where the edge crosses the horizontal line through p
+			x := float64(b.X-a.X)*float64(p.Y-a.Y)/float64(b.Y-a.Y) + float64(a.X)
+			if float64(p.X) < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+func onSegment(p, a, b Point) bool {
+	if (b.X-a.X)*(p.Y-a.Y) != (b.Y-a.Y)*(p.X-a.X) {
+		return false
+	}
+	return min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) && min(a.Y, b.Y) <= p.Y && p.Y <= max(a.Y, b.Y)
+}
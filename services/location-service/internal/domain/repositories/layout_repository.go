@@ -9,5 +9,7 @@ import (
 type LayoutRepository interface {
 	FindZoneByID(ctx context.Context, id string) (*entities.Zone, error)
 	SaveZone(ctx context.Context, zone *entities.Zone) error
+	FindAllZones(ctx context.Context) ([]*entities.Zone, error)
 	FindAllShelvesInZone(ctx context.Context, zoneID string) ([]*entities.Shelf, error)
+	FindAllShelves(ctx context.Context) ([]*entities.Shelf, error)
 }
\ No newline at end of file
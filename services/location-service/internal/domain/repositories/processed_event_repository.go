@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+)
+
+// ProcessedEventRepository remembers which events have already been applied, so redelivered events are skipped.
+type ProcessedEventRepository interface {
+	IsEventProcessed(ctx context.Context, eventID string) (bool, error)
+	MarkEventProcessed(ctx context.Context, eventID, eventType string) error
+}
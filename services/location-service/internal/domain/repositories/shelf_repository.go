@@ -2,12 +2,26 @@ package repositories
 
 import (
 	"context"
+	"time"
+
 	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
 )
 
 // ShelfRepository defines the interface for interacting with shelf storage.
 type ShelfRepository interface {
 	FindByID(ctx context.Context, id string) (*entities.Shelf, error)
-	Save(ctx context.Context, shelf *entities.Shelf) error
+	FindBySlotID(ctx context.Context, slotID string) (*entities.Shelf, error)
+	// Save stores the shelf if the stored shelf is still at shelf.Version, or does not exist yet, and bumps the version.
+	// It reports false when the shelf changed since it was read.
+	Save(ctx context.Context, shelf *entities.Shelf) (bool, error)
+	// DeleteIfUnused removes a shelf unless one of its slots is occupied or reserved, and reports whether it was removed.
+	DeleteIfUnused(ctx context.Context, id string) (bool, error)
 	UpdateSlotStatus(ctx context.Context, shelfID string, slotID string, status entities.SlotStatus, materialID string) error
+	// AllocateSlot reserves a slot for a material until the given time, as long as the slot is still available.
+	// It reports false when another caller got the slot first.
+	AllocateSlot(ctx context.Context, shelfID, slotID, materialID, requester string, until, now time.Time) (bool, error)
+	// ReleaseSlot frees a slot allocated by the requester. It reports false when the requester holds no allocation of it.
+	ReleaseSlot(ctx context.Context, shelfID, slotID, requester string) (bool, error)
+	// ReleaseExpiredAllocations frees every slot whose allocation ran out before now and returns the number of shelves changed.
+	ReleaseExpiredAllocations(ctx context.Context, now time.Time) (int64, error)
 }
\ No newline at end of file
@@ -2,13 +2,22 @@ package services
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
 	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/repositories"
 )
 
+// PlacementCandidate is an available slot suggested for a material.
+type PlacementCandidate struct {
+	Shelf    *entities.Shelf
+	Slot     entities.Slot
+	Distance int // Manhattan distance of the slot from the warehouse origin, where picking starts
+}
+
 // AllocationService provides logic for allocating slots for materials.
-	ype AllocationService struct {
+type AllocationService struct {
 	layoutRepo repositories.LayoutRepository
 }
 
@@ -17,23 +26,154 @@ func NewAllocationService(layoutRepo repositories.LayoutRepository) *AllocationS
 	return &AllocationService{layoutRepo: layoutRepo}
 }
 
-// SuggestSlot finds and suggests a suitable slot for a given material type.
-func (s *AllocationService) SuggestSlot(ctx context.Context, materialType, zoneID string) (*entities.Shelf, *entities.Slot, error) {
-	// This is a simple first-fit algorithm. More complex logic can be added later.
-	// e.g., based on material type, historical data, or proximity to other materials.
+// PlacementRequest describes the material to place and who places it.
+type PlacementRequest struct {
+	MaterialType string
+	ZoneID       string                // Optional: only slots in this zone
+	ShelfID      string                // Optional: only slots on this shelf
+	StorageClass entities.StorageClass // Optional: only zones of this class
+	Role         string                // Role of the requester, checked against the access roles of a zone
+}
+
+// SuggestSlots returns up to limit available slots for a material, ranked by how close they are to the
+// warehouse origin, or every available slot when limit is 0. Nothing is reserved, see AllocateSlot.
+//
+// Only zones whose storage policy takes the material are considered: a material type listed by any zone
+// is kept to the zones listing it, so e.g. CPUs only go to the high-value cage. Quarantine zones are only
+// used when asked for, zones with access roles only for those roles, and a zone at capacity takes nothing.
+// The capacity of a zone only decides whether it takes anything at all: every available slot of a zone
+// below capacity is suggested, WithinCapacity is checked again once a slot is reserved.
+func (s *AllocationService) SuggestSlots(ctx context.Context, req PlacementRequest, limit int) ([]PlacementCandidate, error) {
+	zones, err := s.layoutRepo.FindAllZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var shelves []*entities.Shelf
+	if req.ZoneID != "" {
+		shelves, err = s.layoutRepo.FindAllShelvesInZone(ctx, req.ZoneID)
+	} else {
+		shelves, err = s.layoutRepo.FindAllShelves(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	zonesByID := make(map[string]*entities.Zone, len(zones))
+	dedicated := false
+	for _, zone := range zones {
+		zonesByID[zone.ID] = zone
+		if zone.AllowsMaterialType(req.MaterialType) {
+			dedicated = true
+		}
+	}
+
+	now := time.Now()
+	used := usedSlots(shelves, now) // counted before shelves are filtered
+
+	byZone := make(map[string][]PlacementCandidate)
+	for _, shelf := range shelves {
+		if req.ShelfID != "" && shelf.ID != req.ShelfID {
+			continue
+		}
+		// shelves of a zone that was never set up follow the defaults of general storage
+		zone, ok := zonesByID[shelf.ZoneID]
+		if !ok {
+			zone = &entities.Zone{ID: shelf.ZoneID}
+		}
+		if !acceptsPlacement(zone, req, dedicated) {
+			continue
+		}
+		for _, slot := range shelf.Slots {
+			if slot.IsAvailable(now) {
+				byZone[zone.ID] = append(byZone[zone.ID], PlacementCandidate{Shelf: shelf, Slot: slot, Distance: distanceFromOrigin(slot.Position)})
+			}
+		}
+	}
 
+	var candidates []PlacementCandidate
+	for zoneID, zoneCandidates := range byZone {
+		if zone, ok := zonesByID[zoneID]; ok && zone.Capacity > 0 && zone.Capacity-used[zoneID] <= 0 {
+			continue
+		}
+		candidates = append(candidates, zoneCandidates...)
+	}
+
+	sortCandidates(candidates)
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates, nil
+}
+
+// WithinCapacity reports whether a zone holds no more occupied or reserved slots than its capacity allows.
+// Suggestions are made from a snapshot of the layout, so concurrent allocations can both see room for one
+// more slot; checking again after a slot is reserved, which counts the reservation itself, catches that.
+func (s *AllocationService) WithinCapacity(ctx context.Context, zoneID string) (bool, error) {
+	zone, err := s.layoutRepo.FindZoneByID(ctx, zoneID)
+	if err != nil {
+		return false, err
+	}
+	if zone == nil || zone.Capacity <= 0 {
+		return true, nil
+	}
 	shelves, err := s.layoutRepo.FindAllShelvesInZone(ctx, zoneID)
 	if err != nil {
-		return nil, nil, err
+		return false, err
 	}
+	return usedSlots(shelves, time.Now())[zoneID] <= zone.Capacity, nil
+}
 
+// usedSlots counts the occupied or reserved slots per zone, disabled slots do not count against capacity.
+func usedSlots(shelves []*entities.Shelf, now time.Time) map[string]int {
+	used := make(map[string]int)
 	for _, shelf := range shelves {
 		for _, slot := range shelf.Slots {
-			if slot.Status == entities.StatusEmpty {
-				return shelf, &slot, nil
+			if slot.Status != entities.StatusDisabled && !slot.IsAvailable(now) {
+				used[shelf.ZoneID]++
 			}
 		}
 	}
+	return used
+}
 
-	return nil, nil, nil // No available slot found
-}
\ No newline at end of file
+// acceptsPlacement applies the storage policy of a zone. dedicated tells whether some zone lists the material type.
+func acceptsPlacement(zone *entities.Zone, req PlacementRequest, dedicated bool) bool {
+	if req.StorageClass != "" {
+		if zone.Class() != req.StorageClass {
+			return false
+		}
+	} else if zone.Class() == entities.StorageClassQuarantine {
+		return false
+	}
+	if !zone.AllowsRole(req.Role) {
+		return false
+	}
+	if dedicated {
+		return zone.AllowsMaterialType(req.MaterialType)
+	}
+	return len(zone.AllowedMaterialTypes) == 0
+}
+
+func sortCandidates(candidates []PlacementCandidate) {
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if a.Shelf.ID != b.Shelf.ID {
+			return a.Shelf.ID < b.Shelf.ID
+		}
+		return a.Slot.ID < b.Slot.ID
+	})
+}
+
+func distanceFromOrigin(p entities.Point) int {
+	return abs(p.X) + abs(p.Y) + abs(p.Z)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
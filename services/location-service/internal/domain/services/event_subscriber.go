@@ -0,0 +1,21 @@
+package services
+
+import "context"
+
+// EventMessage is a message delivered by an EventSubscriber.
+type EventMessage struct {
+	Topic   string
+	Key     string            // partitioning key, the shelf ID for events published by inventory-service
+	Headers map[string]string // e.g. the event type, see shared/events
+	Payload []byte
+}
+
+// EventHandler processes a delivered message. Returning an error asks the subscriber to deliver it again.
+type EventHandler func(ctx context.Context, msg EventMessage) error
+
+// EventSubscriber is the message bus location-service consumes events of other services from, e.g. Kafka.
+type EventSubscriber interface {
+	// Subscribe delivers the messages of a topic to the handler until the context is cancelled.
+	// Each message is delivered to one subscriber of every group.
+	Subscribe(ctx context.Context, topic, group string, handler EventHandler) error
+}
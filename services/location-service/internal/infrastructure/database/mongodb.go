@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -29,10 +30,25 @@ func NewMongoRepository(ctx context.Context, uri, database string) (*MongoReposi
 		return nil, err
 	}
 
-	return &MongoRepository{
+	repo := &MongoRepository{
 		client:   client,
 		database: database,
-	}, nil
+	}
+	if err := repo.ensureIndexes(ctx); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// processedEventRetention is how long applied event IDs are remembered, far longer than any redelivery takes.
+const processedEventRetention = 7 * 24 * time.Hour
+
+func (r *MongoRepository) ensureIndexes(ctx context.Context) error {
+	_, err := r.processedEvents().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "processedat", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(processedEventRetention.Seconds())),
+	})
+	return err
 }
 
 // Disconnect disconnects the client from MongoDB.
@@ -58,22 +74,145 @@ func (r *MongoRepository) FindByID(ctx context.Context, id string) (*entities.Sh
 	return &shelf, nil
 }
 
-func (r *MongoRepository) Save(ctx context.Context, shelf *entities.Shelf) error {
-	opts := options.Replace().SetUpsert(true)
-	_, err := r.shelves().ReplaceOne(ctx, bson.M{"id": shelf.ID}, shelf, opts)
-	return err
+func (r *MongoRepository) FindBySlotID(ctx context.Context, slotID string) (*entities.Shelf, error) {
+	var shelf entities.Shelf
+	err := r.shelves().FindOne(ctx, bson.M{"slots.id": slotID}).Decode(&shelf)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &shelf, nil
+}
+
+func (r *MongoRepository) Save(ctx context.Context, shelf *entities.Shelf) (bool, error) {
+	stored := *shelf
+	stored.Version = shelf.Version + 1
+	// slot updates bump the version too, so a layout merged with stale slot state is not written.
+	// Shelves stored before versions were kept have none.
+	filter := bson.M{"id": shelf.ID, "version": shelf.Version}
+	if shelf.Version == 0 {
+		filter["version"] = bson.M{"$exists": false}
+	}
+	result, err := r.shelves().ReplaceOne(ctx, filter, &stored)
+	if err != nil {
+		return false, err
+	}
+	if result.MatchedCount == 0 && shelf.Version == 0 {
+		// a new shelf is only inserted if no one else created it in the meantime
+		opts := options.Update().SetUpsert(true)
+		upserted, err := r.shelves().UpdateOne(ctx, bson.M{"id": shelf.ID}, bson.M{"$setOnInsert": &stored}, opts)
+		if err != nil {
+			return false, err
+		}
+		result.MatchedCount = upserted.UpsertedCount
+	}
+	if result.MatchedCount == 0 {
+		return false, nil
+	}
+	shelf.Version = stored.Version
+	return true, nil
+}
+
+func (r *MongoRepository) DeleteIfUnused(ctx context.Context, id string) (bool, error) {
+	// the check is part of the delete, so a slot taken in the meantime keeps the shelf
+	filter := bson.M{"id": id, "slots.status": bson.M{"$nin": bson.A{entities.StatusOccupied, entities.StatusReserved}}}
+	result, err := r.shelves().DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount == 1, nil
 }
 
 func (r *MongoRepository) UpdateSlotStatus(ctx context.Context, shelfID string, slotID string, status entities.SlotStatus, materialID string) error {
+	// inventory-service owns the slot state, whatever it reports replaces an allocation made here
 	filter := bson.M{"id": shelfID, "slots.id": slotID}
-	update := bson.M{"$set": bson.M{
-		"slots.$.status":     status,
-		"slots.$.materialid": materialID,
-	}}
+	update := bson.M{
+		"$set": bson.M{
+			"slots.$.status":        status,
+			"slots.$.materialid":    materialID,
+			"slots.$.reservedby":    "",
+			"slots.$.reserveduntil": time.Time{},
+		},
+		"$inc": bson.M{"version": 1},
+	}
 	_, err := r.shelves().UpdateOne(ctx, filter, update)
 	return err
 }
 
+// availableSlot matches a slot that is empty or whose allocation ran out, see entities.Slot.IsAvailable
+func availableSlot(now time.Time) bson.A {
+	return bson.A{
+		bson.M{"status": entities.StatusEmpty},
+		bson.M{"status": entities.StatusReserved, "reserveduntil": bson.M{"$gt": time.Time{}, "$lt": now}},
+	}
+}
+
+func (r *MongoRepository) AllocateSlot(ctx context.Context, shelfID, slotID, materialID, requester string, until, now time.Time) (bool, error) {
+	// the availability check and the update are a single document operation, so only one caller can win the slot
+	filter := bson.M{"id": shelfID, "slots": bson.M{"$elemMatch": bson.M{"id": slotID, "$or": availableSlot(now)}}}
+	update := bson.M{
+		"$set": bson.M{
+			"slots.$.status":        entities.StatusReserved,
+			"slots.$.materialid":    materialID,
+			"slots.$.reservedby":    requester,
+			"slots.$.reserveduntil": until,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	result, err := r.shelves().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *MongoRepository) ReleaseSlot(ctx context.Context, shelfID, slotID, requester string) (bool, error) {
+	filter := bson.M{"id": shelfID, "slots": bson.M{"$elemMatch": bson.M{
+		"id":            slotID,
+		"status":        entities.StatusReserved,
+		"reservedby":    requester,
+		"reserveduntil": bson.M{"$gt": time.Time{}},
+	}}}
+	update := bson.M{
+		"$set": bson.M{
+			"slots.$.status":        entities.StatusEmpty,
+			"slots.$.materialid":    "",
+			"slots.$.reservedby":    "",
+			"slots.$.reserveduntil": time.Time{},
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	result, err := r.shelves().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *MongoRepository) ReleaseExpiredAllocations(ctx context.Context, now time.Time) (int64, error) {
+	expired := bson.M{"status": entities.StatusReserved, "reserveduntil": bson.M{"$gt": time.Time{}, "$lt": now}}
+	filter := bson.M{"slots": bson.M{"$elemMatch": expired}}
+	update := bson.M{
+		"$set": bson.M{
+			"slots.$[expired].status":        entities.StatusEmpty,
+			"slots.$[expired].materialid":    "",
+			"slots.$[expired].reservedby":    "",
+			"slots.$[expired].reserveduntil": time.Time{},
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{
+		bson.M{"expired.status": entities.StatusReserved, "expired.reserveduntil": bson.M{"$gt": time.Time{}, "$lt": now}},
+	}})
+	result, err := r.shelves().UpdateMany(ctx, filter, update, opts)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
 // --- LayoutRepository Implementation ---
 
 func (r *MongoRepository) zones() *mongo.Collection {
@@ -98,8 +237,30 @@ func (r *MongoRepository) SaveZone(ctx context.Context, zone *entities.Zone) err
 	return err
 }
 
+func (r *MongoRepository) FindAllZones(ctx context.Context) ([]*entities.Zone, error) {
+	cursor, err := r.zones().Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var zones []*entities.Zone
+	if err = cursor.All(ctx, &zones); err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
 func (r *MongoRepository) FindAllShelvesInZone(ctx context.Context, zoneID string) ([]*entities.Shelf, error) {
-	cursor, err := r.shelves().Find(ctx, bson.M{"zoneid": zoneID})
+	return r.findShelves(ctx, bson.M{"zoneid": zoneID})
+}
+
+func (r *MongoRepository) FindAllShelves(ctx context.Context) ([]*entities.Shelf, error) {
+	return r.findShelves(ctx, bson.M{})
+}
+
+func (r *MongoRepository) findShelves(ctx context.Context, filter bson.M) ([]*entities.Shelf, error) {
+	cursor, err := r.shelves().Find(ctx, filter, options.Find().SetSort(bson.M{"id": 1}))
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +273,28 @@ func (r *MongoRepository) FindAllShelvesInZone(ctx context.Context, zoneID strin
 	return shelves, nil
 }
 
+// --- ProcessedEventRepository Implementation ---
+
+func (r *MongoRepository) processedEvents() *mongo.Collection {
+	return r.client.Database(r.database).Collection("processed_events")
+}
+
+func (r *MongoRepository) IsEventProcessed(ctx context.Context, eventID string) (bool, error) {
+	count, err := r.processedEvents().CountDocuments(ctx, bson.M{"_id": eventID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *MongoRepository) MarkEventProcessed(ctx context.Context, eventID, eventType string) error {
+	opts := options.Update().SetUpsert(true)
+	update := bson.M{"$setOnInsert": bson.M{"eventtype": eventType, "processedat": time.Now()}}
+	_, err := r.processedEvents().UpdateOne(ctx, bson.M{"_id": eventID}, update, opts)
+	return err
+}
+
 // Ensure MongoRepository implements the interfaces
 var _ repositories.ShelfRepository = (*MongoRepository)(nil)
-var _ repositories.LayoutRepository = (*MongoRepository)(nil)
\ No newline at end of file
+var _ repositories.LayoutRepository = (*MongoRepository)(nil)
+var _ repositories.ProcessedEventRepository = (*MongoRepository)(nil)
\ No newline at end of file
@@ -0,0 +1,124 @@
+package messaging
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/services"
+)
+
+// A failed message is retried, and a lost connection to Kafka restored, with a delay that doubles up to maxRetryDelay.
+const (
+	initialRetryDelay = time.Second
+	maxRetryDelay     = 30 * time.Second
+)
+
+// KafkaSubscriber consumes topics through Kafka consumer groups.
+type KafkaSubscriber struct {
+	brokers []string
+}
+
+var _ services.EventSubscriber = (*KafkaSubscriber)(nil)
+
+// NewKafkaSubscriber creates a new KafkaSubscriber.
+func NewKafkaSubscriber(brokers []string) *KafkaSubscriber {
+	return &KafkaSubscriber{brokers: brokers}
+}
+
+// Subscribe keeps consuming until the context is cancelled, reconnecting to Kafka whenever the group fails.
+func (s *KafkaSubscriber) Subscribe(ctx context.Context, topic, group string, handler services.EventHandler) error {
+	config := sarama.NewConfig()
+	// a new group starts from the oldest retained event, so the layout catches up with what happened before
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	var consumerGroup sarama.ConsumerGroup
+	delay := initialRetryDelay
+	for {
+		var err error
+		consumerGroup, err = sarama.NewConsumerGroup(s.brokers, group, config)
+		if err == nil {
+			break
+		}
+		log.Printf("failed to join Kafka consumer group %s, retrying in %s: %v", group, delay, err)
+		if !sleep(ctx, delay) {
+			return nil
+		}
+		delay = nextRetryDelay(delay)
+	}
+	defer consumerGroup.Close()
+
+	groupHandler := &kafkaGroupHandler{ctx: ctx, handler: handler}
+	delay = initialRetryDelay
+	for ctx.Err() == nil {
+		// Consume returns on every rebalance
+		if err := consumerGroup.Consume(ctx, []string{topic}, groupHandler); err != nil {
+			log.Printf("failed to consume Kafka topic %s, retrying in %s: %v", topic, delay, err)
+			sleep(ctx, delay)
+			delay = nextRetryDelay(delay)
+			continue
+		}
+		delay = initialRetryDelay
+	}
+	return nil
+}
+
+type kafkaGroupHandler struct {
+	ctx     context.Context
+	handler services.EventHandler
+}
+
+func (h *kafkaGroupHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *kafkaGroupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (h *kafkaGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		headers := make(map[string]string, len(msg.Headers))
+		for _, header := range msg.Headers {
+			headers[string(header.Key)] = string(header.Value)
+		}
+		eventMessage := services.EventMessage{
+			Topic:   msg.Topic,
+			Key:     string(msg.Key),
+			Headers: headers,
+			Payload: msg.Value,
+		}
+
+		// Retry the message in place so the other partitions of the session keep flowing. When the session
+		// ends first, the offset is left where it is and the next owner of the partition starts from the message.
+		delay := initialRetryDelay
+		for {
+			err := h.handler(h.ctx, eventMessage)
+			if err == nil {
+				break
+			}
+			log.Printf("failed to handle Kafka message at %s/%d offset %d, retrying in %s: %v", msg.Topic, msg.Partition, msg.Offset, delay, err)
+			if !sleep(session.Context(), delay) {
+				return nil
+			}
+			delay = nextRetryDelay(delay)
+		}
+		session.MarkMessage(msg, "")
+	}
+	return nil
+}
+
+// sleep waits for the delay and reports false if the context was cancelled first.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func nextRetryDelay(delay time.Duration) time.Duration {
+	if delay *= 2; delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
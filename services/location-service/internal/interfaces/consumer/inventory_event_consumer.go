@@ -0,0 +1,57 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"WMS/shared/events"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/application/commands"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/services"
+)
+
+// InventoryEventConsumer applies the events of inventory-service to the warehouse layout.
+type InventoryEventConsumer struct {
+	subscriber   services.EventSubscriber
+	topic        string
+	group        string
+	applyHandler *commands.ApplyInventoryEventCommandHandler
+}
+
+// NewInventoryEventConsumer creates a new InventoryEventConsumer.
+func NewInventoryEventConsumer(subscriber services.EventSubscriber, topic, group string, applyHandler *commands.ApplyInventoryEventCommandHandler) *InventoryEventConsumer {
+	return &InventoryEventConsumer{
+		subscriber:   subscriber,
+		topic:        topic,
+		group:        group,
+		applyHandler: applyHandler,
+	}
+}
+
+// Start consumes events until the context is cancelled.
+func (c *InventoryEventConsumer) Start(ctx context.Context) error {
+	return c.subscriber.Subscribe(ctx, c.topic, c.group, c.handle)
+}
+
+func (c *InventoryEventConsumer) handle(ctx context.Context, msg services.EventMessage) error {
+	var envelope events.BaseEvent
+	if err := json.Unmarshal(msg.Payload, &envelope); err != nil {
+		log.Printf("dropping undecodable message from %s: %v", msg.Topic, err)
+		return nil
+	}
+	if eventType := msg.Headers[events.HeaderEventType]; eventType != "" {
+		envelope.EventType = eventType
+	}
+
+	err := c.applyHandler.Handle(ctx, commands.ApplyInventoryEventCommand{
+		EventID:   envelope.EventID,
+		EventType: envelope.EventType,
+		Payload:   msg.Payload,
+	})
+	if errors.Is(err, commands.ErrInvalidEvent) {
+		log.Printf("dropping %s event %s: %v", envelope.EventType, envelope.EventID, err)
+		return nil
+	}
+	return err
+}
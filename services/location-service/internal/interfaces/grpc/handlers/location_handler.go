@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	pb "github.com/m1i3k0e7/warehouse-management-system/services/location-service/api/proto"
 	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/application/commands"
@@ -13,6 +15,12 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const (
+	maxPlacementCandidates = 50
+	defaultAllocationTTL   = 15 * time.Minute
+	maxAllocationTTL       = 24 * time.Hour
+)
+
 // LocationServer is the implementation of the gRPC LocationService.
 type LocationServer struct {
 	pb.UnimplementedLocationServiceServer
@@ -60,31 +68,309 @@ func (s *LocationServer) FindOptimalPath(ctx context.Context, req *pb.FindOptima
 }
 
 func (s *LocationServer) SuggestPlacement(ctx context.Context, req *pb.SuggestPlacementRequest) (*pb.SuggestPlacementResponse, error) {
-	cmd := commands.NewAllocateSlotCommandHandler(s.allocationService, s.shelfRepo)
-	shelf, slot, err := cmd.Handle(ctx, req.MaterialType, req.ZoneId, "") // materialID is empty because we are just suggesting
+	limit := int(req.Limit)
+	if limit < 0 || limit > maxPlacementCandidates {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d", maxPlacementCandidates)
+	}
+	if limit == 0 {
+		limit = 1
+	}
+
+	storageClass := entities.StorageClass(req.StorageClass)
+	if !storageClass.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported storage class %q", req.StorageClass)
+	}
+
+	q := queries.NewSuggestPlacementQueryHandler(s.allocationService)
+	candidates, err := q.Handle(ctx, services.PlacementRequest{
+		MaterialType: req.MaterialType,
+		ZoneID:       req.ZoneId,
+		ShelfID:      req.ShelfId,
+		StorageClass: storageClass,
+		Role:         req.Role,
+	}, limit)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to suggest placement: %v", err)
 	}
-	if shelf == nil || slot == nil {
-		return nil, status.Errorf(codes.NotFound, "no available slot found in zone %s", req.ZoneId)
+	if len(candidates) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no available slot for %s that the storage policies allow", req.MaterialType)
+	}
+
+	resp := &pb.SuggestPlacementResponse{
+		ShelfId:    candidates[0].Shelf.ID,
+		SlotId:     candidates[0].Slot.ID,
+		Candidates: make([]*pb.PlacementCandidate, 0, len(candidates)),
+	}
+	for _, candidate := range candidates {
+		resp.Candidates = append(resp.Candidates, &pb.PlacementCandidate{
+			ShelfId:  candidate.Shelf.ID,
+			SlotId:   candidate.Slot.ID,
+			Distance: int32(candidate.Distance),
+		})
+	}
+	return resp, nil
+}
+
+// AllocateSlot reserves a slot for a material. The reservation runs out after the TTL unless inventory-service
+// takes the slot over by reporting its status, or the requester releases it earlier.
+func (s *LocationServer) AllocateSlot(ctx context.Context, req *pb.AllocateSlotRequest) (*pb.AllocateSlotResponse, error) {
+	if req.MaterialId == "" || req.Requester == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "material_id and requester are required")
+	}
+	if (req.ShelfId == "") != (req.SlotId == "") {
+		return nil, status.Errorf(codes.InvalidArgument, "shelf_id and slot_id must be given together")
+	}
+	ttl := time.Duration(req.TtlSeconds) * time.Second
+	if ttl < 0 || ttl > maxAllocationTTL {
+		return nil, status.Errorf(codes.InvalidArgument, "ttl_seconds must be between 0 and %d", int(maxAllocationTTL.Seconds()))
+	}
+	if ttl == 0 {
+		ttl = defaultAllocationTTL
+	}
+	storageClass := entities.StorageClass(req.StorageClass)
+	if !storageClass.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported storage class %q", req.StorageClass)
+	}
+
+	cmd := commands.NewAllocateSlotCommandHandler(s.allocationService, s.shelfRepo)
+	allocation, err := cmd.Handle(ctx, commands.AllocateSlotCommand{
+		Placement: services.PlacementRequest{
+			MaterialType: req.MaterialType,
+			ZoneID:       req.ZoneId,
+			ShelfID:      req.ShelfId,
+			StorageClass: storageClass,
+			Role:         req.Role,
+		},
+		SlotID:     req.SlotId,
+		MaterialID: req.MaterialId,
+		Requester:  req.Requester,
+		TTL:        ttl,
+	})
+	if errors.Is(err, commands.ErrSlotUnavailable) {
+		return nil, status.Errorf(codes.FailedPrecondition, "slot %s is not available for this material", req.SlotId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to allocate slot: %v", err)
+	}
+	if allocation == nil {
+		return nil, status.Errorf(codes.NotFound, "no available slot for %s that the storage policies allow", req.MaterialType)
+	}
+
+	return &pb.AllocateSlotResponse{
+		ShelfId:   allocation.ShelfID,
+		SlotId:    allocation.SlotID,
+		ExpiresAt: allocation.ExpiresAt.Unix(),
+	}, nil
+}
+
+// ReleaseSlot frees a slot before its allocation runs out.
+func (s *LocationServer) ReleaseSlot(ctx context.Context, req *pb.ReleaseSlotRequest) (*pb.ReleaseSlotResponse, error) {
+	if req.ShelfId == "" || req.SlotId == "" || req.Requester == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "shelf_id, slot_id and requester are required")
+	}
+
+	cmd := commands.NewReleaseSlotCommandHandler(s.shelfRepo)
+	err := cmd.Handle(ctx, req.ShelfId, req.SlotId, req.Requester)
+	if errors.Is(err, commands.ErrNotAllocated) {
+		return nil, status.Errorf(codes.FailedPrecondition, "slot %s is not allocated to %s", req.SlotId, req.Requester)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to release slot %s: %v", req.SlotId, err)
+	}
+
+	return &pb.ReleaseSlotResponse{}, nil
+}
+
+// UpdateSlotStatus mirrors a slot change made by inventory-service so suggestions skip occupied slots.
+func (s *LocationServer) UpdateSlotStatus(ctx context.Context, req *pb.UpdateSlotStatusRequest) (*pb.UpdateSlotStatusResponse, error) {
+	if req.ShelfId == "" || req.SlotId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "shelf_id and slot_id are required")
 	}
 
-	return &pb.SuggestPlacementResponse{ShelfId: shelf.ID, SlotId: slot.ID}, nil
+	slotStatus := entities.SlotStatus(req.Status)
+	switch slotStatus {
+	case entities.StatusEmpty, entities.StatusOccupied, entities.StatusReserved, entities.StatusDisabled:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported slot status %q", req.Status)
+	}
+
+	if err := s.shelfRepo.UpdateSlotStatus(ctx, req.ShelfId, req.SlotId, slotStatus, req.MaterialId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update slot %s: %v", req.SlotId, err)
+	}
+
+	return &pb.UpdateSlotStatusResponse{}, nil
+}
+
+// --- Admin Endpoints ---
+
+func (s *LocationServer) UpsertZone(ctx context.Context, req *pb.Zone) (*pb.Zone, error) {
+	zone := fromProtoZone(req)
+	cmd := commands.NewUpsertZoneCommandHandler(s.layoutRepo)
+	if err := cmd.Handle(ctx, zone); err != nil {
+		if errors.Is(err, commands.ErrInvalidLayout) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to save zone %s: %v", req.Id, err)
+	}
+
+	return toProtoZone(zone), nil
+}
+
+func (s *LocationServer) UpsertShelf(ctx context.Context, req *pb.Shelf) (*pb.Shelf, error) {
+	shelf := fromProtoShelf(req)
+	cmd := commands.NewUpsertShelfCommandHandler(s.shelfRepo, s.layoutRepo)
+	if err := cmd.Handle(ctx, shelf); err != nil {
+		switch {
+		case errors.Is(err, commands.ErrInvalidLayout):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, commands.ErrShelfInUse):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, commands.ErrShelfChanged):
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to save shelf %s: %v", req.Id, err)
+	}
+
+	return toProtoShelf(shelf), nil
+}
+
+func (s *LocationServer) ListZones(ctx context.Context, req *pb.ListZonesRequest) (*pb.ListZonesResponse, error) {
+	q := queries.NewListZonesQueryHandler(s.layoutRepo)
+	zones, err := q.Handle(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list zones: %v", err)
+	}
+
+	resp := &pb.ListZonesResponse{Zones: make([]*pb.Zone, 0, len(zones))}
+	for _, zone := range zones {
+		resp.Zones = append(resp.Zones, toProtoZone(zone))
+	}
+	return resp, nil
+}
+
+func (s *LocationServer) ListShelves(ctx context.Context, req *pb.ListShelvesRequest) (*pb.ListShelvesResponse, error) {
+	q := queries.NewListShelvesQueryHandler(s.layoutRepo)
+	shelves, err := q.Handle(ctx, req.ZoneId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list shelves: %v", err)
+	}
+
+	resp := &pb.ListShelvesResponse{Shelves: make([]*pb.Shelf, 0, len(shelves))}
+	for _, shelf := range shelves {
+		resp.Shelves = append(resp.Shelves, toProtoShelf(shelf))
+	}
+	return resp, nil
+}
+
+func (s *LocationServer) DeleteShelf(ctx context.Context, req *pb.DeleteShelfRequest) (*pb.DeleteShelfResponse, error) {
+	if req.ShelfId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "shelf_id is required")
+	}
+
+	cmd := commands.NewDeleteShelfCommandHandler(s.shelfRepo)
+	err := cmd.Handle(ctx, req.ShelfId)
+	switch {
+	case errors.Is(err, commands.ErrShelfNotFound):
+		return nil, status.Errorf(codes.NotFound, "shelf with id %s not found", req.ShelfId)
+	case errors.Is(err, commands.ErrShelfInUse):
+		return nil, status.Errorf(codes.FailedPrecondition, "shelf %s still has occupied or reserved slots", req.ShelfId)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to delete shelf %s: %v", req.ShelfId, err)
+	}
+
+	return &pb.DeleteShelfResponse{}, nil
 }
 
 // --- Converters ---
 
 func toProtoShelf(shelf *entities.Shelf) *pb.Shelf {
-	// ... implementation ...
-	return &pb.Shelf{}
+	slots := make([]*pb.Slot, 0, len(shelf.Slots))
+	for _, slot := range shelf.Slots {
+		protoSlot := &pb.Slot{
+			Id:         slot.ID,
+			Position:   toProtoPoint(slot.Position),
+			Status:     string(slot.Status),
+			MaterialId: slot.MaterialID,
+			ReservedBy: slot.ReservedBy,
+		}
+		if !slot.ReservedUntil.IsZero() {
+			protoSlot.ReservedUntil = slot.ReservedUntil.Unix()
+		}
+		slots = append(slots, protoSlot)
+	}
+
+	return &pb.Shelf{
+		Id:       shelf.ID,
+		ZoneId:   shelf.ZoneID,
+		Position: toProtoPoint(shelf.Position),
+		Rows:     int32(shelf.Rows),
+		Columns:  int32(shelf.Columns),
+		Slots:    slots,
+	}
+}
+
+func toProtoPoint(p entities.Point) *pb.Point {
+	return &pb.Point{X: int32(p.X), Y: int32(p.Y), Z: int32(p.Z)}
 }
 
 func fromProtoPoint(p *pb.Point) entities.Point {
-	// ... implementation ...
-	return entities.Point{}
+	return entities.Point{X: int(p.GetX()), Y: int(p.GetY()), Z: int(p.GetZ())}
 }
 
 func toProtoPath(points []entities.Point) []*pb.Point {
-	// ... implementation ...
-	return []*pb.Point{}
+	path := make([]*pb.Point, 0, len(points))
+	for _, p := range points {
+		path = append(path, toProtoPoint(p))
+	}
+	return path
+}
+
+func fromProtoShelf(shelf *pb.Shelf) *entities.Shelf {
+	slots := make([]entities.Slot, 0, len(shelf.GetSlots()))
+	for _, slot := range shelf.GetSlots() {
+		slots = append(slots, entities.Slot{
+			ID:         slot.GetId(),
+			Position:   fromProtoPoint(slot.GetPosition()),
+			Status:     entities.SlotStatus(slot.GetStatus()),
+			MaterialID: slot.GetMaterialId(),
+		})
+	}
+
+	return &entities.Shelf{
+		ID:       shelf.GetId(),
+		ZoneID:   shelf.GetZoneId(),
+		Position: fromProtoPoint(shelf.GetPosition()),
+		Rows:     int(shelf.GetRows()),
+		Columns:  int(shelf.GetColumns()),
+		Slots:    slots,
+	}
+}
+
+func toProtoZone(zone *entities.Zone) *pb.Zone {
+	return &pb.Zone{
+		Id:                   zone.ID,
+		Name:                 zone.Name,
+		BoundaryPoints:       toProtoPath(zone.BoundaryPoints),
+		StorageClass:         string(zone.Class()),
+		AllowedMaterialTypes: zone.AllowedMaterialTypes,
+		Capacity:             int32(zone.Capacity),
+		AccessRoles:          zone.AccessRoles,
+	}
+}
+
+func fromProtoZone(zone *pb.Zone) *entities.Zone {
+	boundary := make([]entities.Point, 0, len(zone.GetBoundaryPoints()))
+	for _, p := range zone.GetBoundaryPoints() {
+		boundary = append(boundary, fromProtoPoint(p))
+	}
+
+	return &entities.Zone{
+		ID:                   zone.GetId(),
+		Name:                 zone.GetName(),
+		BoundaryPoints:       boundary,
+		StorageClass:         entities.StorageClass(zone.GetStorageClass()),
+		AllowedMaterialTypes: zone.GetAllowedMaterialTypes(),
+		Capacity:             int(zone.GetCapacity()),
+		AccessRoles:          zone.GetAccessRoles(),
+	}
 }
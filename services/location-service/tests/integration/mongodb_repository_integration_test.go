@@ -0,0 +1,264 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var repo *database.MongoRepository
+
+func TestMain(m *testing.M) {
+	// Setup test database connection, e.g. TEST_MONGO_URI=mongodb://localhost:27017 against a throwaway mongo container
+	uri := os.Getenv("TEST_MONGO_URI")
+	databaseName := os.Getenv("TEST_MONGO_DATABASE")
+	if databaseName == "" {
+		databaseName = "location_service_test"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		log.Fatalf("Failed to connect to test database: %v", err)
+	}
+	// start from an empty database, the repository creates its indexes again
+	if err := client.Database(databaseName).Drop(ctx); err != nil {
+		log.Fatalf("Failed to reset test database: %v", err)
+	}
+	repo, err = database.NewMongoRepository(ctx, uri, databaseName)
+	if err != nil {
+		log.Fatalf("Failed to connect to test database: %v", err)
+	}
+
+	// Run tests
+	code := m.Run()
+
+	// Teardown
+	client.Database(databaseName).Drop(context.Background())
+	client.Disconnect(context.Background())
+	repo.Disconnect(context.Background())
+
+	os.Exit(code)
+}
+
+// Mongo stores times with millisecond precision
+func now() time.Time {
+	return time.Now().Truncate(time.Millisecond)
+}
+
+func saveShelf(t *testing.T, id string, slots ...entities.Slot) {
+	saved, err := repo.Save(context.Background(), &entities.Shelf{ID: id, ZoneID: "zone-" + id, Rows: 1, Columns: len(slots), Slots: slots})
+	if !assert.NoError(t, err) || !assert.True(t, saved) {
+		t.FailNow()
+	}
+}
+
+func findSlot(t *testing.T, shelfID, slotID string) entities.Slot {
+	shelf, err := repo.FindByID(context.Background(), shelfID)
+	if !assert.NoError(t, err) || !assert.NotNil(t, shelf) {
+		t.FailNow()
+	}
+	for _, slot := range shelf.Slots {
+		if slot.ID == slotID {
+			return slot
+		}
+	}
+	t.Fatalf("shelf %s has no slot %s", shelfID, slotID)
+	return entities.Slot{}
+}
+
+func TestMongoRepository_AllocateSlotHasOneWinner(t *testing.T) {
+	ctx := context.Background()
+	saveShelf(t, "alloc-shelf-1", entities.Slot{ID: "alloc-slot-1", Status: entities.StatusEmpty})
+
+	start := now()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	winners := make([]string, 0)
+	for i := 0; i < 20; i++ {
+		requester := fmt.Sprintf("requester-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			allocated, err := repo.AllocateSlot(ctx, "alloc-shelf-1", "alloc-slot-1", "mat-"+requester, requester, start.Add(time.Minute), start)
+			assert.NoError(t, err)
+			if allocated {
+				mu.Lock()
+				winners = append(winners, requester)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if assert.Len(t, winners, 1) {
+		slot := findSlot(t, "alloc-shelf-1", "alloc-slot-1")
+		assert.Equal(t, entities.StatusReserved, slot.Status)
+		assert.Equal(t, winners[0], slot.ReservedBy)
+		assert.Equal(t, "mat-"+winners[0], slot.MaterialID)
+	}
+}
+
+func TestMongoRepository_AllocateSlotOnlyTakesAvailableSlots(t *testing.T) {
+	ctx := context.Background()
+	saveShelf(t, "alloc-shelf-2",
+		entities.Slot{ID: "alloc-slot-occupied", Status: entities.StatusOccupied, MaterialID: "mat-1"},
+		entities.Slot{ID: "alloc-slot-disabled", Status: entities.StatusDisabled},
+		// reserved by inventory-service, which sets no expiry
+		entities.Slot{ID: "alloc-slot-reserved", Status: entities.StatusReserved},
+	)
+
+	for _, slotID := range []string{"alloc-slot-occupied", "alloc-slot-disabled", "alloc-slot-reserved", "alloc-slot-missing"} {
+		allocated, err := repo.AllocateSlot(ctx, "alloc-shelf-2", slotID, "mat-2", "requester-1", now().Add(time.Minute), now())
+		assert.NoError(t, err)
+		assert.False(t, allocated, slotID)
+	}
+}
+
+func TestMongoRepository_AllocationExpires(t *testing.T) {
+	ctx := context.Background()
+	saveShelf(t, "alloc-shelf-3", entities.Slot{ID: "alloc-slot-3", Status: entities.StatusEmpty})
+
+	start := now()
+	until := start.Add(time.Minute)
+	allocated, err := repo.AllocateSlot(ctx, "alloc-shelf-3", "alloc-slot-3", "mat-1", "requester-1", until, start)
+	assert.NoError(t, err)
+	assert.True(t, allocated)
+
+	slot := findSlot(t, "alloc-shelf-3", "alloc-slot-3")
+	assert.True(t, until.Equal(slot.ReservedUntil))
+	assert.False(t, slot.IsAvailable(start.Add(30*time.Second)))
+	assert.True(t, slot.IsAvailable(until.Add(time.Second)))
+
+	// still held by the first requester
+	allocated, err = repo.AllocateSlot(ctx, "alloc-shelf-3", "alloc-slot-3", "mat-2", "requester-2", start.Add(2*time.Minute), start.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.False(t, allocated)
+
+	// the allocation ran out, so the slot can be taken again without waiting for the cleanup
+	later := until.Add(time.Second)
+	allocated, err = repo.AllocateSlot(ctx, "alloc-shelf-3", "alloc-slot-3", "mat-2", "requester-2", later.Add(time.Minute), later)
+	assert.NoError(t, err)
+	assert.True(t, allocated)
+	assert.Equal(t, "requester-2", findSlot(t, "alloc-shelf-3", "alloc-slot-3").ReservedBy)
+}
+
+func TestMongoRepository_ReleaseSlot(t *testing.T) {
+	ctx := context.Background()
+	saveShelf(t, "alloc-shelf-4", entities.Slot{ID: "alloc-slot-4", Status: entities.StatusEmpty})
+
+	allocated, err := repo.AllocateSlot(ctx, "alloc-shelf-4", "alloc-slot-4", "mat-1", "requester-1", now().Add(time.Minute), now())
+	assert.NoError(t, err)
+	assert.True(t, allocated)
+
+	released, err := repo.ReleaseSlot(ctx, "alloc-shelf-4", "alloc-slot-4", "requester-2")
+	assert.NoError(t, err)
+	assert.False(t, released)
+
+	released, err = repo.ReleaseSlot(ctx, "alloc-shelf-4", "alloc-slot-4", "requester-1")
+	assert.NoError(t, err)
+	assert.True(t, released)
+
+	slot := findSlot(t, "alloc-shelf-4", "alloc-slot-4")
+	assert.Equal(t, entities.StatusEmpty, slot.Status)
+	assert.Empty(t, slot.MaterialID)
+	assert.Empty(t, slot.ReservedBy)
+	assert.True(t, slot.ReservedUntil.IsZero())
+}
+
+func TestMongoRepository_ReleaseExpiredAllocations(t *testing.T) {
+	ctx := context.Background()
+	start := now()
+	saveShelf(t, "alloc-shelf-5",
+		entities.Slot{ID: "alloc-slot-expired", Status: entities.StatusReserved, MaterialID: "mat-1", ReservedBy: "requester-1", ReservedUntil: start.Add(-time.Minute)},
+		entities.Slot{ID: "alloc-slot-live", Status: entities.StatusReserved, MaterialID: "mat-2", ReservedBy: "requester-2", ReservedUntil: start.Add(time.Minute)},
+		entities.Slot{ID: "alloc-slot-inventory", Status: entities.StatusReserved},
+	)
+	saveShelf(t, "alloc-shelf-6",
+		entities.Slot{ID: "alloc-slot-live-2", Status: entities.StatusReserved, MaterialID: "mat-3", ReservedBy: "requester-3", ReservedUntil: start.Add(time.Minute)},
+	)
+
+	changed, err := repo.ReleaseExpiredAllocations(ctx, start)
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, changed, int64(1))
+	expired := findSlot(t, "alloc-shelf-5", "alloc-slot-expired")
+	assert.Equal(t, entities.StatusEmpty, expired.Status)
+	assert.Empty(t, expired.MaterialID)
+	assert.Equal(t, entities.StatusReserved, findSlot(t, "alloc-shelf-5", "alloc-slot-live").Status)
+	assert.Equal(t, entities.StatusReserved, findSlot(t, "alloc-shelf-5", "alloc-slot-inventory").Status)
+	assert.Equal(t, entities.StatusReserved, findSlot(t, "alloc-shelf-6", "alloc-slot-live-2").Status)
+}
+
+func TestMongoRepository_UpdateSlotStatusReplacesAllocation(t *testing.T) {
+	ctx := context.Background()
+	saveShelf(t, "alloc-shelf-7", entities.Slot{ID: "alloc-slot-7", Status: entities.StatusEmpty})
+
+	allocated, err := repo.AllocateSlot(ctx, "alloc-shelf-7", "alloc-slot-7", "mat-1", "requester-1", now().Add(time.Minute), now())
+	assert.NoError(t, err)
+	assert.True(t, allocated)
+
+	assert.NoError(t, repo.UpdateSlotStatus(ctx, "alloc-shelf-7", "alloc-slot-7", entities.StatusOccupied, "mat-1"))
+
+	slot := findSlot(t, "alloc-shelf-7", "alloc-slot-7")
+	assert.Equal(t, entities.StatusOccupied, slot.Status)
+	assert.Empty(t, slot.ReservedBy)
+	assert.True(t, slot.ReservedUntil.IsZero())
+}
+
+func TestMongoRepository_DeleteIfUnused(t *testing.T) {
+	ctx := context.Background()
+	saveShelf(t, "delete-shelf-1", entities.Slot{ID: "delete-slot-1", Status: entities.StatusEmpty}, entities.Slot{ID: "delete-slot-2", Status: entities.StatusDisabled})
+	saveShelf(t, "delete-shelf-2", entities.Slot{ID: "delete-slot-3", Status: entities.StatusEmpty}, entities.Slot{ID: "delete-slot-4", Status: entities.StatusOccupied, MaterialID: "mat-1"})
+	saveShelf(t, "delete-shelf-3", entities.Slot{ID: "delete-slot-5", Status: entities.StatusEmpty})
+
+	allocated, err := repo.AllocateSlot(ctx, "delete-shelf-3", "delete-slot-5", "mat-2", "requester-1", now().Add(time.Minute), now())
+	assert.NoError(t, err)
+	assert.True(t, allocated)
+
+	for shelfID, want := range map[string]bool{"delete-shelf-1": true, "delete-shelf-2": false, "delete-shelf-3": false, "delete-shelf-missing": false} {
+		deleted, err := repo.DeleteIfUnused(ctx, shelfID)
+		assert.NoError(t, err)
+		assert.Equal(t, want, deleted, shelfID)
+	}
+	shelf, err := repo.FindByID(ctx, "delete-shelf-2")
+	assert.NoError(t, err)
+	assert.NotNil(t, shelf)
+}
+
+func TestMongoRepository_SaveOnlyOverwritesTheVersionItRead(t *testing.T) {
+	ctx := context.Background()
+	saveShelf(t, "save-version", entities.Slot{ID: "save-version-1", Status: entities.StatusEmpty})
+
+	shelf, err := repo.FindByID(ctx, "save-version")
+	if !assert.NoError(t, err) || !assert.NotNil(t, shelf) {
+		t.FailNow()
+	}
+	assert.Equal(t, 1, shelf.Version)
+
+	// the slot is allocated after the layout was read
+	allocated, err := repo.AllocateSlot(ctx, "save-version", "save-version-1", "mat-1", "requester-1", now().Add(time.Minute), now())
+	assert.NoError(t, err)
+	assert.True(t, allocated)
+
+	saved, err := repo.Save(ctx, shelf)
+	assert.NoError(t, err)
+	assert.False(t, saved)
+	assert.Equal(t, entities.StatusReserved, findSlot(t, "save-version", "save-version-1").Status)
+
+	// a second shelf with the same ID is not created either
+	saved, err = repo.Save(ctx, &entities.Shelf{ID: "save-version", ZoneID: "zone-save-version"})
+	assert.NoError(t, err)
+	assert.False(t, saved)
+}
@@ -0,0 +1,261 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/application/commands"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPolicyLayout sets up a general zone, a high-value cage dedicated to CPUs and a quarantine zone
+func newPolicyLayout() *memoryRepository {
+	repo := newMemoryRepository()
+	repo.addZone(&entities.Zone{ID: "zone-general"})
+	repo.addZone(&entities.Zone{
+		ID:                   "zone-cage",
+		StorageClass:         entities.StorageClassHighValue,
+		AllowedMaterialTypes: []string{"CPU"},
+		AccessRoles:          []string{"supervisor"},
+	})
+	repo.addZone(&entities.Zone{ID: "zone-quarantine", StorageClass: entities.StorageClassQuarantine})
+
+	repo.addShelf(&entities.Shelf{ID: "shelf-g", ZoneID: "zone-general", Slots: []entities.Slot{
+		{ID: "g-1", Position: entities.Point{X: 1}, Status: entities.StatusEmpty},
+		{ID: "g-2", Position: entities.Point{X: 2}, Status: entities.StatusEmpty},
+		{ID: "g-3", Position: entities.Point{X: 3}, Status: entities.StatusOccupied, MaterialID: "mat-1"},
+	}})
+	repo.addShelf(&entities.Shelf{ID: "shelf-c", ZoneID: "zone-cage", Slots: []entities.Slot{
+		{ID: "c-1", Position: entities.Point{X: 5}, Status: entities.StatusEmpty},
+	}})
+	repo.addShelf(&entities.Shelf{ID: "shelf-q", ZoneID: "zone-quarantine", Slots: []entities.Slot{
+		{ID: "q-1", Position: entities.Point{X: 9}, Status: entities.StatusEmpty},
+	}})
+	// a shelf whose zone was never set up is general storage
+	repo.addShelf(&entities.Shelf{ID: "shelf-u", ZoneID: "zone-unknown", Slots: []entities.Slot{
+		{ID: "u-1", Position: entities.Point{X: 4}, Status: entities.StatusEmpty},
+	}})
+	return repo
+}
+
+func slotIDs(candidates []services.PlacementCandidate) []string {
+	ids := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.Slot.ID)
+	}
+	return ids
+}
+
+func TestSuggestSlots_AppliesStoragePolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    services.PlacementRequest
+		expect []string
+	}{
+		{
+			name:   "unlisted types go to general storage, closest slot first",
+			req:    services.PlacementRequest{MaterialType: "Memory"},
+			expect: []string{"g-1", "g-2", "u-1"},
+		},
+		{
+			name:   "a listed type only goes to the zones listing it",
+			req:    services.PlacementRequest{MaterialType: "CPU", Role: "supervisor"},
+			expect: []string{"c-1"},
+		},
+		{
+			name:   "a listed type is not placed elsewhere when its zone denies the role",
+			req:    services.PlacementRequest{MaterialType: "CPU", Role: "operator"},
+			expect: []string{},
+		},
+		{
+			name:   "a zone listing types takes no other types",
+			req:    services.PlacementRequest{MaterialType: "Memory", StorageClass: entities.StorageClassHighValue, Role: "supervisor"},
+			expect: []string{},
+		},
+		{
+			name:   "quarantine is used when asked for",
+			req:    services.PlacementRequest{MaterialType: "Memory", StorageClass: entities.StorageClassQuarantine},
+			expect: []string{"q-1"},
+		},
+		{
+			name:   "general storage class matches zones without a class",
+			req:    services.PlacementRequest{MaterialType: "Memory", StorageClass: entities.StorageClassGeneral},
+			expect: []string{"g-1", "g-2", "u-1"},
+		},
+		{
+			name:   "zone and shelf narrow the search",
+			req:    services.PlacementRequest{MaterialType: "Memory", ZoneID: "zone-general", ShelfID: "shelf-g"},
+			expect: []string{"g-1", "g-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocationService := services.NewAllocationService(newPolicyLayout())
+
+			candidates, err := allocationService.SuggestSlots(context.Background(), tt.req, 0)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, slotIDs(candidates))
+		})
+	}
+}
+
+func TestSuggestSlots_LimitsCandidates(t *testing.T) {
+	allocationService := services.NewAllocationService(newPolicyLayout())
+
+	candidates, err := allocationService.SuggestSlots(context.Background(), services.PlacementRequest{MaterialType: "Memory"}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"g-1", "g-2"}, slotIDs(candidates))
+}
+
+func newCapacityLayout(capacity int, slots ...entities.Slot) *memoryRepository {
+	repo := newMemoryRepository()
+	repo.addZone(&entities.Zone{ID: "zone-1", Capacity: capacity})
+	repo.addShelf(&entities.Shelf{ID: "shelf-1", ZoneID: "zone-1", Slots: slots})
+	return repo
+}
+
+func TestSuggestSlots_ZoneCapacity(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		capacity int
+		slots    []entities.Slot
+		expect   []string
+	}{
+		{
+			name:     "a zone at capacity takes nothing",
+			capacity: 1,
+			slots: []entities.Slot{
+				{ID: "s-1", Status: entities.StatusOccupied, MaterialID: "mat-1"},
+				{ID: "s-2", Position: entities.Point{X: 1}, Status: entities.StatusEmpty},
+			},
+			expect: []string{},
+		},
+		{
+			name:     "reservations count against capacity",
+			capacity: 1,
+			slots: []entities.Slot{
+				{ID: "s-1", Status: entities.StatusReserved, ReservedBy: "op-1", ReservedUntil: now.Add(time.Hour)},
+				{ID: "s-2", Position: entities.Point{X: 1}, Status: entities.StatusEmpty},
+			},
+			expect: []string{},
+		},
+		{
+			name:     "a zone below capacity offers every available slot",
+			capacity: 2,
+			slots: []entities.Slot{
+				{ID: "s-1", Status: entities.StatusOccupied, MaterialID: "mat-1"},
+				{ID: "s-2", Position: entities.Point{X: 1}, Status: entities.StatusEmpty},
+				{ID: "s-3", Position: entities.Point{X: 2}, Status: entities.StatusEmpty},
+			},
+			expect: []string{"s-2", "s-3"},
+		},
+		{
+			name:     "expired allocations and disabled slots do not count",
+			capacity: 1,
+			slots: []entities.Slot{
+				{ID: "s-1", Status: entities.StatusReserved, ReservedBy: "op-1", ReservedUntil: now.Add(-time.Minute)},
+				{ID: "s-2", Position: entities.Point{X: 1}, Status: entities.StatusDisabled},
+			},
+			expect: []string{"s-1"},
+		},
+		{
+			name:     "no capacity means no limit",
+			capacity: 0,
+			slots: []entities.Slot{
+				{ID: "s-1", Status: entities.StatusOccupied, MaterialID: "mat-1"},
+				{ID: "s-2", Position: entities.Point{X: 1}, Status: entities.StatusEmpty},
+			},
+			expect: []string{"s-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocationService := services.NewAllocationService(newCapacityLayout(tt.capacity, tt.slots...))
+
+			candidates, err := allocationService.SuggestSlots(context.Background(), services.PlacementRequest{MaterialType: "Memory"}, 0)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, slotIDs(candidates))
+		})
+	}
+}
+
+func TestZone_AllowsRole(t *testing.T) {
+	open := &entities.Zone{ID: "zone-1"}
+	restricted := &entities.Zone{ID: "zone-2", AccessRoles: []string{"supervisor", "quality"}}
+
+	assert.True(t, open.AllowsRole(""))
+	assert.True(t, open.AllowsRole("operator"))
+	assert.True(t, restricted.AllowsRole("quality"))
+	assert.False(t, restricted.AllowsRole("operator"))
+	assert.False(t, restricted.AllowsRole(""))
+}
+
+func TestAllocateSlot_AllowsAnExplicitSlotInAZoneWithRoom(t *testing.T) {
+	repo := newCapacityLayout(2,
+		entities.Slot{ID: "s-1", Status: entities.StatusOccupied, MaterialID: "mat-1"},
+		entities.Slot{ID: "s-2", Position: entities.Point{X: 1}, Status: entities.StatusEmpty},
+		entities.Slot{ID: "s-3", Position: entities.Point{X: 2}, Status: entities.StatusEmpty},
+	)
+	handler := commands.NewAllocateSlotCommandHandler(services.NewAllocationService(repo), repo)
+
+	// s-3 is not the closest slot, the zone still has room for it
+	allocation, err := handler.Handle(context.Background(), commands.AllocateSlotCommand{
+		Placement:  services.PlacementRequest{MaterialType: "Memory", ShelfID: "shelf-1"},
+		SlotID:     "s-3",
+		MaterialID: "mat-2",
+		Requester:  "op-1",
+		TTL:        time.Minute,
+	})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, allocation) {
+		assert.Equal(t, "s-3", allocation.SlotID)
+	}
+	assert.Equal(t, entities.StatusReserved, repo.slot("shelf-1", "s-3").Status)
+}
+
+// racingRepository lets another caller take a slot right before each allocation
+type racingRepository struct {
+	*memoryRepository
+	race func()
+}
+
+func (r *racingRepository) AllocateSlot(ctx context.Context, shelfID, slotID, materialID, requester string, until, now time.Time) (bool, error) {
+	if r.race != nil {
+		r.race()
+		r.race = nil
+	}
+	return r.memoryRepository.AllocateSlot(ctx, shelfID, slotID, materialID, requester, until, now)
+}
+
+func TestAllocateSlot_GivesBackASlotThatTookTheZoneOverCapacity(t *testing.T) {
+	repo := newCapacityLayout(1,
+		entities.Slot{ID: "s-1", Status: entities.StatusEmpty},
+		entities.Slot{ID: "s-2", Position: entities.Point{X: 1}, Status: entities.StatusEmpty},
+	)
+	racing := &racingRepository{memoryRepository: repo, race: func() {
+		_, _ = repo.AllocateSlot(context.Background(), "shelf-1", "s-2", "mat-9", "op-9", time.Now().Add(time.Minute), time.Now())
+	}}
+	handler := commands.NewAllocateSlotCommandHandler(services.NewAllocationService(repo), racing)
+
+	allocation, err := handler.Handle(context.Background(), commands.AllocateSlotCommand{
+		Placement:  services.PlacementRequest{MaterialType: "Memory"},
+		MaterialID: "mat-1",
+		Requester:  "op-1",
+		TTL:        time.Minute,
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, allocation)
+	assert.Equal(t, entities.StatusEmpty, repo.slot("shelf-1", "s-1").Status)
+	assert.Equal(t, "op-9", repo.slot("shelf-1", "s-2").ReservedBy)
+}
@@ -0,0 +1,320 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"WMS/shared/events"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/application/commands"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/services"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/interfaces/consumer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEventRepository() *memoryRepository {
+	repo := newMemoryRepository()
+	repo.addShelf(&entities.Shelf{ID: "shelf-1", ZoneID: "zone-1", Rows: 1, Columns: 3, Slots: []entities.Slot{
+		{ID: "slot-1", Status: entities.StatusEmpty},
+		{ID: "slot-2", Status: entities.StatusOccupied, MaterialID: "mat-1"},
+		{ID: "slot-3", Status: entities.StatusOccupied, MaterialID: "mat-2"},
+	}})
+	repo.addShelf(&entities.Shelf{ID: "shelf-2", ZoneID: "zone-1", Rows: 1, Columns: 1, Slots: []entities.Slot{
+		{ID: "slot-4", Status: entities.StatusEmpty},
+	}})
+	return repo
+}
+
+func eventPayload(t *testing.T, event events.Event) []byte {
+	payload, err := json.Marshal(event)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return payload
+}
+
+func base(eventType, shelfID string) events.BaseEvent {
+	return events.BaseEvent{EventID: "event-1", EventType: eventType, AggregateID: shelfID}
+}
+
+func TestApplyInventoryEvent_UpdatesSlots(t *testing.T) {
+	type slotState struct {
+		shelfID, slotID string
+		status          entities.SlotStatus
+		materialID      string
+	}
+
+	tests := []struct {
+		name   string
+		event  events.Event
+		expect []slotState
+	}{
+		{
+			name:   "material placed occupies the slot",
+			event:  &events.MaterialPlacedEvent{BaseEvent: base("material.placed", "shelf-1"), MaterialID: "mat-9", SlotID: "slot-1", ShelfID: "shelf-1"},
+			expect: []slotState{{"shelf-1", "slot-1", entities.StatusOccupied, "mat-9"}},
+		},
+		{
+			name:   "physical placement requested occupies the slot",
+			event:  &events.PhysicalPlacementEvent{BaseEvent: base("physical.placement.requested", "shelf-1"), MaterialID: "mat-9", SlotID: "slot-1", ShelfID: "shelf-1"},
+			expect: []slotState{{"shelf-1", "slot-1", entities.StatusOccupied, "mat-9"}},
+		},
+		{
+			name:   "physical placement failed empties the slot",
+			event:  &events.PhysicalPlacementEvent{BaseEvent: base("physical.placement.failed", "shelf-1"), MaterialID: "mat-1", SlotID: "slot-2", ShelfID: "shelf-1"},
+			expect: []slotState{{"shelf-1", "slot-2", entities.StatusEmpty, ""}},
+		},
+		{
+			name:   "late placement failure leaves a slot used again alone",
+			event:  &events.PhysicalPlacementEvent{BaseEvent: base("physical.placement.failed", "shelf-1"), MaterialID: "mat-old", SlotID: "slot-2", ShelfID: "shelf-1"},
+			expect: []slotState{{"shelf-1", "slot-2", entities.StatusOccupied, "mat-1"}},
+		},
+		{
+			name:   "requested removal keeps the material in the slot",
+			event:  &events.MaterialRemovedEvent{BaseEvent: base("material.removed", "shelf-1"), MaterialID: "mat-1", SlotID: "slot-2", ShelfID: "shelf-1"},
+			expect: []slotState{{"shelf-1", "slot-2", entities.StatusOccupied, "mat-1"}},
+		},
+		{
+			name:   "confirmed removal empties the slot",
+			event:  &events.PhysicalPlacementEvent{BaseEvent: base("physical.removal.confirmed", "shelf-1"), MaterialID: "mat-1", SlotID: "slot-2", ShelfID: "shelf-1"},
+			expect: []slotState{{"shelf-1", "slot-2", entities.StatusEmpty, ""}},
+		},
+		{
+			name:   "late removal confirmation leaves a slot used again alone",
+			event:  &events.PhysicalPlacementEvent{BaseEvent: base("physical.removal.confirmed", "shelf-1"), MaterialID: "mat-old", SlotID: "slot-3", ShelfID: "shelf-1"},
+			expect: []slotState{{"shelf-1", "slot-3", entities.StatusOccupied, "mat-2"}},
+		},
+		{
+			name:   "failed removal keeps the slot occupied",
+			event:  &events.PhysicalPlacementEvent{BaseEvent: base("physical.removal.failed", "shelf-1"), MaterialID: "mat-1", SlotID: "slot-2", ShelfID: "shelf-1"},
+			expect: []slotState{{"shelf-1", "slot-2", entities.StatusOccupied, "mat-1"}},
+		},
+		{
+			name:  "moved material leaves its slot on another shelf",
+			event: &events.MaterialMovedEvent{BaseEvent: base("material.moved", "shelf-2"), MaterialID: "mat-1", FromSlotID: "slot-2", ToSlotID: "slot-4", ShelfID: "shelf-2"},
+			expect: []slotState{
+				{"shelf-1", "slot-2", entities.StatusEmpty, ""},
+				{"shelf-2", "slot-4", entities.StatusOccupied, "mat-1"},
+			},
+		},
+		{
+			name:  "reserved slots are looked up on the shelf of the event",
+			event: &events.SlotsReservedEvent{BaseEvent: base("slots.reserved", "shelf-1"), SlotIDs: []string{"slot-1", "slot-9"}, OperatorID: "op-1"},
+			expect: []slotState{
+				{"shelf-1", "slot-1", entities.StatusReserved, ""},
+			},
+		},
+		{
+			name:   "slot under maintenance is disabled with its material",
+			event:  &events.SlotTakenOutOfServiceEvent{BaseEvent: base("slot.taken_out_of_service", "shelf-1"), TicketID: "ticket-1", SlotID: "slot-2", ShelfID: "shelf-1", MaterialID: "mat-1"},
+			expect: []slotState{{"shelf-1", "slot-2", entities.StatusDisabled, "mat-1"}},
+		},
+		{
+			name:   "slot returned to service as occupied keeps its material",
+			event:  &events.SlotReturnedToServiceEvent{BaseEvent: base("slot.returned_to_service", "shelf-1"), TicketID: "ticket-1", SlotID: "slot-2", ShelfID: "shelf-1", Status: "occupied"},
+			expect: []slotState{{"shelf-1", "slot-2", entities.StatusOccupied, "mat-1"}},
+		},
+		{
+			name:   "slot returned to service as empty is cleared",
+			event:  &events.SlotReturnedToServiceEvent{BaseEvent: base("slot.returned_to_service", "shelf-1"), TicketID: "ticket-1", SlotID: "slot-2", ShelfID: "shelf-1", Status: "empty"},
+			expect: []slotState{{"shelf-1", "slot-2", entities.StatusEmpty, ""}},
+		},
+		{
+			name:   "unplanned placement occupies the slot",
+			event:  &events.UnplannedSlotEvent{BaseEvent: base("unplanned.placement", "shelf-1"), SlotID: "slot-1", ShelfID: "shelf-1", MaterialBarcode: "BC-1"},
+			expect: []slotState{{"shelf-1", "slot-1", entities.StatusOccupied, ""}},
+		},
+		{
+			name:   "unplanned removal empties the slot",
+			event:  &events.UnplannedSlotEvent{BaseEvent: base("unplanned.removal", "shelf-1"), SlotID: "slot-2", ShelfID: "shelf-1", MaterialBarcode: "BC-1"},
+			expect: []slotState{{"shelf-1", "slot-2", entities.StatusEmpty, ""}},
+		},
+		{
+			name:   "slots of unknown shelves are skipped",
+			event:  &events.MaterialPlacedEvent{BaseEvent: base("material.placed", "shelf-9"), MaterialID: "mat-9", SlotID: "slot-1", ShelfID: "shelf-9"},
+			expect: []slotState{{"shelf-1", "slot-1", entities.StatusEmpty, ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newEventRepository()
+			handler := commands.NewApplyInventoryEventCommandHandler(repo, repo)
+			envelope := tt.event.Envelope()
+
+			err := handler.Handle(context.Background(), commands.ApplyInventoryEventCommand{
+				EventID:   envelope.EventID,
+				EventType: envelope.EventType,
+				Payload:   eventPayload(t, tt.event),
+			})
+
+			assert.NoError(t, err)
+			for _, want := range tt.expect {
+				slot := repo.slot(want.shelfID, want.slotID)
+				if !assert.NotNil(t, slot, want.slotID) {
+					continue
+				}
+				assert.Equal(t, want.status, slot.Status, want.slotID)
+				assert.Equal(t, want.materialID, slot.MaterialID, want.slotID)
+			}
+			processed, _ := repo.IsEventProcessed(context.Background(), envelope.EventID)
+			assert.True(t, processed)
+		})
+	}
+}
+
+func TestApplyInventoryEvent_AppliesAnEventOnce(t *testing.T) {
+	repo := newEventRepository()
+	handler := commands.NewApplyInventoryEventCommandHandler(repo, repo)
+	ctx := context.Background()
+	event := &events.MaterialPlacedEvent{BaseEvent: base("material.placed", "shelf-1"), MaterialID: "mat-9", SlotID: "slot-1", ShelfID: "shelf-1"}
+	cmd := commands.ApplyInventoryEventCommand{EventID: "event-1", EventType: "material.placed", Payload: eventPayload(t, event)}
+
+	assert.NoError(t, handler.Handle(ctx, cmd))
+	// the slot is released by a later event, a redelivery of the placement must not occupy it again
+	assert.NoError(t, repo.UpdateSlotStatus(ctx, "shelf-1", "slot-1", entities.StatusEmpty, ""))
+	assert.NoError(t, handler.Handle(ctx, cmd))
+
+	slot := repo.slot("shelf-1", "slot-1")
+	assert.Equal(t, entities.StatusEmpty, slot.Status)
+	assert.Equal(t, 2, repo.updates)
+}
+
+func TestApplyInventoryEvent_SkipsUpdatesAlreadyInPlace(t *testing.T) {
+	repo := newEventRepository()
+	handler := commands.NewApplyInventoryEventCommandHandler(repo, repo)
+	event := &events.MaterialPlacedEvent{BaseEvent: base("material.placed", "shelf-1"), MaterialID: "mat-1", SlotID: "slot-2", ShelfID: "shelf-1"}
+
+	err := handler.Handle(context.Background(), commands.ApplyInventoryEventCommand{EventType: "material.placed", Payload: eventPayload(t, event)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, repo.updates)
+}
+
+func TestApplyInventoryEvent_IgnoresOtherEventTypes(t *testing.T) {
+	repo := newEventRepository()
+	handler := commands.NewApplyInventoryEventCommandHandler(repo, repo)
+	ctx := context.Background()
+
+	err := handler.Handle(ctx, commands.ApplyInventoryEventCommand{EventID: "event-1", EventType: "shelf.health_alert", Payload: []byte(`{}`)})
+
+	assert.NoError(t, err)
+	processed, _ := repo.IsEventProcessed(ctx, "event-1")
+	assert.False(t, processed)
+}
+
+func TestApplyInventoryEvent_RejectsInvalidEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     commands.ApplyInventoryEventCommand
+		wantErr error
+	}{
+		{
+			name:    "undecodable payload",
+			cmd:     commands.ApplyInventoryEventCommand{EventID: "event-1", EventType: "material.placed", Payload: []byte(`{"slot_id": 1}`)},
+			wantErr: commands.ErrInvalidEvent,
+		},
+		{
+			name:    "event without a slot",
+			cmd:     commands.ApplyInventoryEventCommand{EventID: "event-1", EventType: "material.placed", Payload: []byte(`{"shelf_id": "shelf-1"}`)},
+			wantErr: commands.ErrInvalidEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newEventRepository()
+			handler := commands.NewApplyInventoryEventCommandHandler(repo, repo)
+
+			err := handler.Handle(context.Background(), tt.cmd)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			processed, _ := repo.IsEventProcessed(context.Background(), tt.cmd.EventID)
+			assert.False(t, processed)
+		})
+	}
+}
+
+func TestApplyInventoryEvent_LeavesFailedEventsForRedelivery(t *testing.T) {
+	repo := newEventRepository()
+	repo.updateErr = errors.New("connection reset")
+	handler := commands.NewApplyInventoryEventCommandHandler(repo, repo)
+	ctx := context.Background()
+	event := &events.MaterialPlacedEvent{BaseEvent: base("material.placed", "shelf-1"), MaterialID: "mat-9", SlotID: "slot-1", ShelfID: "shelf-1"}
+
+	err := handler.Handle(ctx, commands.ApplyInventoryEventCommand{EventID: "event-1", EventType: "material.placed", Payload: eventPayload(t, event)})
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, commands.ErrInvalidEvent)
+	processed, _ := repo.IsEventProcessed(ctx, "event-1")
+	assert.False(t, processed)
+}
+
+// recordingSubscriber hands the handler of the consumer to the test instead of a message bus
+type recordingSubscriber struct {
+	handler services.EventHandler
+}
+
+func (s *recordingSubscriber) Subscribe(ctx context.Context, topic, group string, handler services.EventHandler) error {
+	s.handler = handler
+	return nil
+}
+
+func TestInventoryEventConsumer_DropsOrRetries(t *testing.T) {
+	placed := &events.MaterialPlacedEvent{BaseEvent: base("material.placed", "shelf-1"), MaterialID: "mat-9", SlotID: "slot-1", ShelfID: "shelf-1"}
+	placedPayload := eventPayload(t, placed)
+
+	tests := []struct {
+		name      string
+		msg       services.EventMessage
+		updateErr error
+		wantRetry bool
+		wantSlot  entities.SlotStatus
+	}{
+		{
+			name:     "applies the event",
+			msg:      services.EventMessage{Payload: placedPayload},
+			wantSlot: entities.StatusOccupied,
+		},
+		{
+			name:     "the event type header takes precedence over the payload",
+			msg:      services.EventMessage{Headers: map[string]string{events.HeaderEventType: "unplanned.placement"}, Payload: []byte(`{"event_id": "event-2", "event_type": "shelf.health_alert", "slot_id": "slot-1", "shelf_id": "shelf-1"}`)},
+			wantSlot: entities.StatusOccupied,
+		},
+		{
+			name:     "drops messages that are not JSON",
+			msg:      services.EventMessage{Payload: []byte(`not json`)},
+			wantSlot: entities.StatusEmpty,
+		},
+		{
+			name:     "drops events that can never be applied",
+			msg:      services.EventMessage{Payload: []byte(`{"event_id": "event-3", "event_type": "material.placed", "shelf_id": "shelf-1"}`)},
+			wantSlot: entities.StatusEmpty,
+		},
+		{
+			name:      "asks for redelivery when the layout cannot be updated",
+			msg:       services.EventMessage{Payload: placedPayload},
+			updateErr: errors.New("connection reset"),
+			wantRetry: true,
+			wantSlot:  entities.StatusEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newEventRepository()
+			repo.updateErr = tt.updateErr
+			subscriber := &recordingSubscriber{}
+			c := consumer.NewInventoryEventConsumer(subscriber, "inventory-events", "location-service", commands.NewApplyInventoryEventCommandHandler(repo, repo))
+			ctx := context.Background()
+			assert.NoError(t, c.Start(ctx))
+
+			err := subscriber.handler(ctx, tt.msg)
+
+			assert.Equal(t, tt.wantRetry, err != nil, "error: %v", err)
+			assert.Equal(t, tt.wantSlot, repo.slot("shelf-1", "slot-1").Status)
+		})
+	}
+}
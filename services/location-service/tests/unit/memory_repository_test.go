@@ -0,0 +1,265 @@
+package unit
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+)
+
+// memoryRepository is an in-memory ShelfRepository, LayoutRepository and ProcessedEventRepository.
+// Shelves are copied in and out, so callers cannot change stored state behind its back.
+type memoryRepository struct {
+	mu              sync.Mutex
+	zones           map[string]*entities.Zone
+	shelves         map[string]*entities.Shelf
+	processedEvents map[string]string
+	updateErr       error  // returned by UpdateSlotStatus when set
+	updates         int    // number of slot updates written
+	beforeSave      func() // called by Save before it compares versions, e.g. to change a slot concurrently
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		zones:           make(map[string]*entities.Zone),
+		shelves:         make(map[string]*entities.Shelf),
+		processedEvents: make(map[string]string),
+	}
+}
+
+func copyShelf(shelf *entities.Shelf) *entities.Shelf {
+	c := *shelf
+	c.Slots = append([]entities.Slot(nil), shelf.Slots...)
+	return &c
+}
+
+func (r *memoryRepository) addShelf(shelf *entities.Shelf) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.shelves[shelf.ID] = copyShelf(shelf)
+}
+
+func (r *memoryRepository) addZone(zone *entities.Zone) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *zone
+	r.zones[zone.ID] = &c
+}
+
+// slot returns a copy of a stored slot, or nil if it does not exist.
+func (r *memoryRepository) slot(shelfID, slotID string) *entities.Slot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shelf, ok := r.shelves[shelfID]
+	if !ok {
+		return nil
+	}
+	for _, slot := range shelf.Slots {
+		if slot.ID == slotID {
+			return &slot
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) findSlot(shelfID, slotID string) *entities.Slot {
+	shelf, ok := r.shelves[shelfID]
+	if !ok {
+		return nil
+	}
+	for i := range shelf.Slots {
+		if shelf.Slots[i].ID == slotID {
+			return &shelf.Slots[i]
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) FindByID(ctx context.Context, id string) (*entities.Shelf, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shelf, ok := r.shelves[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyShelf(shelf), nil
+}
+
+func (r *memoryRepository) FindBySlotID(ctx context.Context, slotID string) (*entities.Shelf, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, shelf := range r.shelves {
+		for _, slot := range shelf.Slots {
+			if slot.ID == slotID {
+				return copyShelf(shelf), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRepository) Save(ctx context.Context, shelf *entities.Shelf) (bool, error) {
+	if r.beforeSave != nil {
+		r.beforeSave()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	version := 0
+	if stored, ok := r.shelves[shelf.ID]; ok {
+		version = stored.Version
+	}
+	if version != shelf.Version {
+		return false, nil
+	}
+	shelf.Version++
+	r.shelves[shelf.ID] = copyShelf(shelf)
+	return true, nil
+}
+
+func (r *memoryRepository) DeleteIfUnused(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shelf, ok := r.shelves[id]
+	if !ok {
+		return false, nil
+	}
+	for _, slot := range shelf.Slots {
+		if slot.Status == entities.StatusOccupied || slot.Status == entities.StatusReserved {
+			return false, nil
+		}
+	}
+	delete(r.shelves, id)
+	return true, nil
+}
+
+func (r *memoryRepository) UpdateSlotStatus(ctx context.Context, shelfID string, slotID string, status entities.SlotStatus, materialID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	if slot := r.findSlot(shelfID, slotID); slot != nil {
+		slot.Status = status
+		slot.MaterialID = materialID
+		slot.ReservedBy = ""
+		slot.ReservedUntil = time.Time{}
+		r.shelves[shelfID].Version++
+		r.updates++
+	}
+	return nil
+}
+
+func (r *memoryRepository) AllocateSlot(ctx context.Context, shelfID, slotID, materialID, requester string, until, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	slot := r.findSlot(shelfID, slotID)
+	if slot == nil || !slot.IsAvailable(now) {
+		return false, nil
+	}
+	slot.Status = entities.StatusReserved
+	slot.MaterialID = materialID
+	slot.ReservedBy = requester
+	slot.ReservedUntil = until
+	r.shelves[shelfID].Version++
+	return true, nil
+}
+
+func (r *memoryRepository) ReleaseSlot(ctx context.Context, shelfID, slotID, requester string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	slot := r.findSlot(shelfID, slotID)
+	if slot == nil || slot.Status != entities.StatusReserved || slot.ReservedBy != requester {
+		return false, nil
+	}
+	*slot = entities.Slot{ID: slot.ID, Position: slot.Position, Status: entities.StatusEmpty}
+	r.shelves[shelfID].Version++
+	return true, nil
+}
+
+func (r *memoryRepository) ReleaseExpiredAllocations(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var changed int64
+	for _, shelf := range r.shelves {
+		released := false
+		for i, slot := range shelf.Slots {
+			if slot.Status == entities.StatusReserved && !slot.ReservedUntil.IsZero() && now.After(slot.ReservedUntil) {
+				shelf.Slots[i] = entities.Slot{ID: slot.ID, Position: slot.Position, Status: entities.StatusEmpty}
+				released = true
+			}
+		}
+		if released {
+			shelf.Version++
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+func (r *memoryRepository) FindZoneByID(ctx context.Context, id string) (*entities.Zone, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	zone, ok := r.zones[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *zone
+	return &c, nil
+}
+
+func (r *memoryRepository) SaveZone(ctx context.Context, zone *entities.Zone) error {
+	r.addZone(zone)
+	return nil
+}
+
+func (r *memoryRepository) FindAllZones(ctx context.Context) ([]*entities.Zone, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	zones := make([]*entities.Zone, 0, len(r.zones))
+	for _, zone := range r.zones {
+		c := *zone
+		zones = append(zones, &c)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].ID < zones[j].ID })
+	return zones, nil
+}
+
+func (r *memoryRepository) FindAllShelvesInZone(ctx context.Context, zoneID string) ([]*entities.Shelf, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shelves := make([]*entities.Shelf, 0)
+	for _, shelf := range r.shelves {
+		if shelf.ZoneID == zoneID {
+			shelves = append(shelves, copyShelf(shelf))
+		}
+	}
+	sort.Slice(shelves, func(i, j int) bool { return shelves[i].ID < shelves[j].ID })
+	return shelves, nil
+}
+
+func (r *memoryRepository) FindAllShelves(ctx context.Context) ([]*entities.Shelf, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shelves := make([]*entities.Shelf, 0, len(r.shelves))
+	for _, shelf := range r.shelves {
+		shelves = append(shelves, copyShelf(shelf))
+	}
+	sort.Slice(shelves, func(i, j int) bool { return shelves[i].ID < shelves[j].ID })
+	return shelves, nil
+}
+
+func (r *memoryRepository) IsEventProcessed(ctx context.Context, eventID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.processedEvents[eventID]
+	return ok, nil
+}
+
+func (r *memoryRepository) MarkEventProcessed(ctx context.Context, eventID, eventType string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processedEvents[eventID] = eventType
+	return nil
+}
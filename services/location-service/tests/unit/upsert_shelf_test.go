@@ -0,0 +1,211 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/application/commands"
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func newShelfLayout() *memoryRepository {
+	repo := newMemoryRepository()
+	repo.addZone(&entities.Zone{ID: "zone-1", BoundaryPoints: []entities.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}})
+	return repo
+}
+
+// gridShelf builds a shelf of one row with a slot per column
+func gridShelf(id string, slots ...entities.Slot) *entities.Shelf {
+	for i := range slots {
+		slots[i].Position = entities.Point{X: 1 + i, Y: 1}
+	}
+	return &entities.Shelf{ID: id, ZoneID: "zone-1", Position: entities.Point{X: 1, Y: 1}, Rows: 1, Columns: len(slots), Slots: slots}
+}
+
+func TestUpsertShelf_RemovingSlots(t *testing.T) {
+	tests := []struct {
+		name    string
+		removed entities.Slot
+		wantErr error
+	}{
+		{name: "an empty slot can be removed", removed: entities.Slot{ID: "slot-2", Status: entities.StatusEmpty}},
+		{name: "a disabled slot can be removed", removed: entities.Slot{ID: "slot-2", Status: entities.StatusDisabled}},
+		{name: "an occupied slot is kept", removed: entities.Slot{ID: "slot-2", Status: entities.StatusOccupied, MaterialID: "mat-1"}, wantErr: commands.ErrShelfInUse},
+		{name: "a reserved slot is kept", removed: entities.Slot{ID: "slot-2", Status: entities.StatusReserved}, wantErr: commands.ErrShelfInUse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newShelfLayout()
+			repo.addShelf(gridShelf("shelf-1", entities.Slot{ID: "slot-1", Status: entities.StatusEmpty}, tt.removed))
+			handler := commands.NewUpsertShelfCommandHandler(repo, repo)
+
+			err := handler.Handle(context.Background(), gridShelf("shelf-1", entities.Slot{ID: "slot-1"}))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.NotNil(t, repo.slot("shelf-1", "slot-2"))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Nil(t, repo.slot("shelf-1", "slot-2"))
+		})
+	}
+}
+
+func TestUpsertShelf_KeepsStoredSlotState(t *testing.T) {
+	repo := newShelfLayout()
+	repo.addShelf(gridShelf("shelf-1", entities.Slot{ID: "slot-1", Status: entities.StatusOccupied, MaterialID: "mat-1"}))
+	handler := commands.NewUpsertShelfCommandHandler(repo, repo)
+
+	err := handler.Handle(context.Background(), gridShelf("shelf-1", entities.Slot{ID: "slot-1"}, entities.Slot{ID: "slot-2"}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.StatusOccupied, repo.slot("shelf-1", "slot-1").Status)
+	assert.Equal(t, "mat-1", repo.slot("shelf-1", "slot-1").MaterialID)
+	assert.Equal(t, entities.StatusEmpty, repo.slot("shelf-1", "slot-2").Status)
+}
+
+func TestUpsertShelf_KeepsConcurrentSlotChanges(t *testing.T) {
+	repo := newShelfLayout()
+	repo.addShelf(gridShelf("shelf-1", entities.Slot{ID: "slot-1", Status: entities.StatusEmpty}))
+	handler := commands.NewUpsertShelfCommandHandler(repo, repo)
+	allocated := false
+	repo.beforeSave = func() {
+		// the slot is allocated after the handler read the shelf, the first save must not overwrite it
+		if !allocated {
+			allocated = true
+			_, err := repo.AllocateSlot(context.Background(), "shelf-1", "slot-1", "mat-1", "requester-1", time.Now().Add(time.Minute), time.Now())
+			assert.NoError(t, err)
+		}
+	}
+
+	err := handler.Handle(context.Background(), gridShelf("shelf-1", entities.Slot{ID: "slot-1"}, entities.Slot{ID: "slot-2"}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.StatusReserved, repo.slot("shelf-1", "slot-1").Status)
+	assert.Equal(t, "requester-1", repo.slot("shelf-1", "slot-1").ReservedBy)
+	assert.Equal(t, entities.StatusEmpty, repo.slot("shelf-1", "slot-2").Status)
+}
+
+func TestUpsertShelf_GivesUpOnAShelfThatKeepsChanging(t *testing.T) {
+	repo := newShelfLayout()
+	repo.addShelf(gridShelf("shelf-1", entities.Slot{ID: "slot-1", Status: entities.StatusEmpty}))
+	handler := commands.NewUpsertShelfCommandHandler(repo, repo)
+	repo.beforeSave = func() {
+		assert.NoError(t, repo.UpdateSlotStatus(context.Background(), "shelf-1", "slot-1", entities.StatusOccupied, "mat-1"))
+	}
+
+	err := handler.Handle(context.Background(), gridShelf("shelf-1", entities.Slot{ID: "slot-1"}, entities.Slot{ID: "slot-2"}))
+
+	assert.ErrorIs(t, err, commands.ErrShelfChanged)
+	assert.Nil(t, repo.slot("shelf-1", "slot-2"))
+}
+
+func TestUpsertShelf_RejectsInvalidGrids(t *testing.T) {
+	tests := []struct {
+		name  string
+		shelf func() *entities.Shelf
+	}{
+		{name: "missing shelf id", shelf: func() *entities.Shelf {
+			shelf := gridShelf("shelf-1", entities.Slot{ID: "slot-1"})
+			shelf.ID = ""
+			return shelf
+		}},
+		{name: "missing zone id", shelf: func() *entities.Shelf {
+			shelf := gridShelf("shelf-1", entities.Slot{ID: "slot-1"})
+			shelf.ZoneID = ""
+			return shelf
+		}},
+		{name: "no rows", shelf: func() *entities.Shelf {
+			shelf := gridShelf("shelf-1", entities.Slot{ID: "slot-1"})
+			shelf.Rows = 0
+			return shelf
+		}},
+		{name: "slots do not fill the grid", shelf: func() *entities.Shelf {
+			shelf := gridShelf("shelf-1", entities.Slot{ID: "slot-1"}, entities.Slot{ID: "slot-2"})
+			shelf.Columns = 3
+			return shelf
+		}},
+		{name: "slot without id", shelf: func() *entities.Shelf {
+			return gridShelf("shelf-1", entities.Slot{ID: "slot-1"}, entities.Slot{})
+		}},
+		{name: "duplicate slot id", shelf: func() *entities.Shelf {
+			return gridShelf("shelf-1", entities.Slot{ID: "slot-1"}, entities.Slot{ID: "slot-1"})
+		}},
+		{name: "duplicate position", shelf: func() *entities.Shelf {
+			shelf := gridShelf("shelf-1", entities.Slot{ID: "slot-1"}, entities.Slot{ID: "slot-2"})
+			shelf.Slots[1].Position = shelf.Slots[0].Position
+			return shelf
+		}},
+		{name: "unsupported status", shelf: func() *entities.Shelf {
+			return gridShelf("shelf-1", entities.Slot{ID: "slot-1", Status: "BROKEN"})
+		}},
+		{name: "unknown zone", shelf: func() *entities.Shelf {
+			shelf := gridShelf("shelf-1", entities.Slot{ID: "slot-1"})
+			shelf.ZoneID = "zone-missing"
+			return shelf
+		}},
+		{name: "shelf outside its zone", shelf: func() *entities.Shelf {
+			shelf := gridShelf("shelf-1", entities.Slot{ID: "slot-1"})
+			shelf.Position = entities.Point{X: 11, Y: 1}
+			return shelf
+		}},
+		{name: "slot outside its zone", shelf: func() *entities.Shelf {
+			shelf := gridShelf("shelf-1", entities.Slot{ID: "slot-1"}, entities.Slot{ID: "slot-2"})
+			shelf.Slots[1].Position = entities.Point{X: 1, Y: 12}
+			return shelf
+		}},
+		{name: "slot of another shelf", shelf: func() *entities.Shelf {
+			return gridShelf("shelf-1", entities.Slot{ID: "slot-9"})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newShelfLayout()
+			repo.addShelf(gridShelf("shelf-2", entities.Slot{ID: "slot-9", Status: entities.StatusEmpty}))
+			handler := commands.NewUpsertShelfCommandHandler(repo, repo)
+
+			err := handler.Handle(context.Background(), tt.shelf())
+
+			assert.ErrorIs(t, err, commands.ErrInvalidLayout)
+			assert.Nil(t, repo.slot("shelf-1", "slot-1"))
+		})
+	}
+}
+
+func TestDeleteShelf(t *testing.T) {
+	tests := []struct {
+		name    string
+		shelfID string
+		slot    entities.Slot
+		wantErr error
+	}{
+		{name: "a shelf of empty slots is deleted", shelfID: "shelf-1", slot: entities.Slot{ID: "slot-2", Status: entities.StatusEmpty}},
+		{name: "disabled slots do not keep a shelf", shelfID: "shelf-1", slot: entities.Slot{ID: "slot-2", Status: entities.StatusDisabled}},
+		{name: "an occupied slot keeps the shelf", shelfID: "shelf-1", slot: entities.Slot{ID: "slot-2", Status: entities.StatusOccupied, MaterialID: "mat-1"}, wantErr: commands.ErrShelfInUse},
+		{name: "a reserved slot keeps the shelf", shelfID: "shelf-1", slot: entities.Slot{ID: "slot-2", Status: entities.StatusReserved}, wantErr: commands.ErrShelfInUse},
+		{name: "a missing shelf is reported", shelfID: "shelf-missing", slot: entities.Slot{ID: "slot-2", Status: entities.StatusEmpty}, wantErr: commands.ErrShelfNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newShelfLayout()
+			repo.addShelf(gridShelf("shelf-1", entities.Slot{ID: "slot-1", Status: entities.StatusEmpty}, tt.slot))
+			handler := commands.NewDeleteShelfCommandHandler(repo)
+
+			err := handler.Handle(context.Background(), tt.shelfID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.NotNil(t, repo.slot("shelf-1", "slot-1"))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Nil(t, repo.slot("shelf-1", "slot-1"))
+		})
+	}
+}
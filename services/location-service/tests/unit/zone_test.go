@@ -0,0 +1,45 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/m1i3k0e7/warehouse-management-system/services/location-service/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZone_Contains(t *testing.T) {
+	// an L-shaped zone, the square 0..4 without its upper right quarter
+	zone := &entities.Zone{ID: "zone-1", BoundaryPoints: []entities.Point{
+		{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 4}, {X: 0, Y: 4},
+	}}
+
+	tests := []struct {
+		name   string
+		point  entities.Point
+		expect bool
+	}{
+		{name: "inside", point: entities.Point{X: 1, Y: 1}, expect: true},
+		{name: "inside the arm", point: entities.Point{X: 1, Y: 3}, expect: true},
+		{name: "on an edge", point: entities.Point{X: 3, Y: 0}, expect: true},
+		{name: "on an inner edge", point: entities.Point{X: 3, Y: 2}, expect: true},
+		{name: "on a vertex", point: entities.Point{X: 4, Y: 2}, expect: true},
+		{name: "on the inner corner", point: entities.Point{X: 2, Y: 2}, expect: true},
+		{name: "height is ignored", point: entities.Point{X: 1, Y: 1, Z: 7}, expect: true},
+		{name: "in the cut out corner", point: entities.Point{X: 3, Y: 3}, expect: false},
+		{name: "outside", point: entities.Point{X: 5, Y: 1}, expect: false},
+		{name: "below", point: entities.Point{X: 1, Y: -1}, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, zone.Contains(tt.point))
+		})
+	}
+}
+
+func TestZone_ContainsNeedsAPolygon(t *testing.T) {
+	line := &entities.Zone{ID: "zone-1", BoundaryPoints: []entities.Point{{X: 0, Y: 0}, {X: 4, Y: 0}}}
+
+	assert.False(t, line.Contains(entities.Point{X: 2, Y: 0}))
+	assert.False(t, (&entities.Zone{ID: "zone-2"}).Contains(entities.Point{}))
+}
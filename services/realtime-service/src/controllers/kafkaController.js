@@ -60,7 +60,8 @@ class KafkaController {
 
   async handleMessage(topic, message) {
     const eventData = JSON.parse(message.value.toString());
-    const eventType = eventData.event_type || message.key?.toString();
+    // the message key is the shelf ID, the event type travels in the envelope and as a header
+    const eventType = eventData.event_type || message.headers?.event_type?.toString();
     
     logger.info(`Processing Kafka message`, { topic, eventType });
 
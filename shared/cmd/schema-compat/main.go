@@ -0,0 +1,54 @@
+// Command schema-compat fails when the event schemas break consumers of a previous version.
+//
+// Usage, e.g. in CI against the schemas of the main branch:
+//
+//	git archive origin/main shared/events/schemas | tar -x -C /tmp/base
+//	go run ./cmd/schema-compat /tmp/base/shared/events/schemas
+//
+// The new schemas default to the ones embedded in WMS/shared/events, pass a second directory to compare two checkouts.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"WMS/shared/events"
+)
+
+func main() {
+	if len(os.Args) < 2 || len(os.Args) > 3 {
+		fmt.Fprintln(os.Stderr, "usage: schema-compat <old-schema-dir> [new-schema-dir]")
+		os.Exit(2)
+	}
+
+	old, err := events.LoadSchemaDocuments(os.DirFS(os.Args[1]))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to load old schemas:", err)
+		os.Exit(2)
+	}
+
+	var current map[string]*events.SchemaDocument
+	if len(os.Args) == 3 {
+		current, err = events.LoadSchemaDocuments(os.DirFS(os.Args[2]))
+	} else {
+		var registry *events.SchemaRegistry
+		registry, err = events.NewSchemaRegistry()
+		if err == nil {
+			current = registry.Documents()
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to load new schemas:", err)
+		os.Exit(2)
+	}
+
+	problems := events.CheckSchemaCompatibility(old, current)
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		fmt.Printf("%d incompatible schema changes\n", len(problems))
+		os.Exit(1)
+	}
+	fmt.Printf("%d event schemas are compatible\n", len(current))
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Commands other services send to the inventory service over the message bus,
+// e.g. placement requests from the ERP adapter or allocation results from the location service
+const (
+	CommandTypePlaceMaterial       = "inventory.place_material"
+	CommandTypeBatchPlaceMaterials = "inventory.batch_place_materials"
+	CommandTypeRemoveMaterial      = "inventory.remove_material"
+	CommandTypeMoveMaterial        = "inventory.move_material"
+	CommandTypeReserveSlots        = "inventory.reserve_slots"
+)
+
+// Message headers set by senders, mirroring the event headers in shared/events
+const (
+	HeaderCommandType   = "command_type"
+	HeaderCorrelationID = "correlation_id"
+)
+
+// Envelope wraps every command. The payload is decoded according to the command type.
+type Envelope struct {
+	CommandID     string          `json:"command_id"`
+	CommandType   string          `json:"command_type"`
+	Source        string          `json:"source"`                   // the sending service, e.g. "erp-adapter"
+	CorrelationID string          `json:"correlation_id,omitempty"` // carried over to the events the command causes
+	IssuedAt      time.Time       `json:"issued_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+type PlaceMaterial struct {
+	MaterialBarcode string `json:"material_barcode"`
+	SlotID          string `json:"slot_id"`
+	OperatorID      string `json:"operator_id"`
+}
+
+type BatchPlaceMaterials struct {
+	Placements []PlaceMaterial `json:"placements"`
+}
+
+type RemoveMaterial struct {
+	SlotID     string `json:"slot_id"`
+	OperatorID string `json:"operator_id"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+type MoveMaterial struct {
+	FromSlotID string `json:"from_slot_id"`
+	ToSlotID   string `json:"to_slot_id"`
+	OperatorID string `json:"operator_id"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// ReserveSlots is sent by the location service once it allocated slots for incoming material
+type ReserveSlots struct {
+	SlotIDs    []string `json:"slot_ids"`
+	OperatorID string   `json:"operator_id"`
+	Duration   int      `json:"duration"`
+	Purpose    string   `json:"purpose,omitempty"`
+}
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EnvelopeVersion is the version of the BaseEvent envelope itself.
+// Payload changes are versioned per event type through BaseEvent.Version.
+const EnvelopeVersion = "v1"
+
+// Message headers set by publishers, so consumers can route without decoding the payload
+const (
+	HeaderEventType     = "event_type"
+	HeaderEventVersion  = "event_version"
+	HeaderCorrelationID = "correlation_id"
+)
+
+// BaseEvent is the envelope shared by every event published on the bus.
+// Payload fields are embedded next to it, so consumers reading flat JSON keep working.
+type BaseEvent struct {
+	EventID       string    `json:"event_id"`
+	EventType     string    `json:"event_type"`
+	Version       string    `json:"version"`
+	Timestamp     time.Time `json:"timestamp"`
+	Source        string    `json:"source"`
+	AggregateID   string    `json:"aggregate_id,omitempty"`   // e.g. the shelf ID, used as partition key to keep per-aggregate order
+	CorrelationID string    `json:"correlation_id,omitempty"` // shared by every event caused by the same request
+	CausationID   string    `json:"causation_id,omitempty"`   // ID of the message that directly caused this event
+}
+
+// Event is implemented by every event embedding BaseEvent
+type Event interface {
+	Envelope() *BaseEvent
+}
+
+func (e *BaseEvent) Envelope() *BaseEvent {
+	return e
+}
+
+// NewBaseEvent creates an envelope with a fresh ID and the current time
+func NewBaseEvent(eventType, source, aggregateID string) BaseEvent {
+	return BaseEvent{
+		EventID:     generateUUID(),
+		EventType:   eventType,
+		Version:     EnvelopeVersion,
+		Timestamp:   time.Now(),
+		Source:      source,
+		AggregateID: aggregateID,
+	}
+}
+
+// PartitionKey returns the aggregate ID, or the event type for events that belong to no aggregate
+func (e *BaseEvent) PartitionKey() string {
+	if e.AggregateID != "" {
+		return e.AggregateID
+	}
+	return e.EventType
+}
+
+type traceContextKey struct{}
+
+type traceIDs struct {
+	correlationID string
+	causationID   string
+}
+
+// WithTrace returns a context carrying the correlation and causation IDs for events published while handling it
+func WithTrace(ctx context.Context, correlationID, causationID string) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, traceIDs{correlationID: correlationID, causationID: causationID})
+}
+
+// TraceFromContext returns the correlation and causation IDs stored by WithTrace, if any
+func TraceFromContext(ctx context.Context) (correlationID, causationID string) {
+	ids, _ := ctx.Value(traceContextKey{}).(traceIDs)
+	return ids.correlationID, ids.causationID
+}
+
+func generateUUID() string {
+	return uuid.New().String()
+}
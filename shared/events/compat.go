@@ -0,0 +1,105 @@
+package events
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// CheckSchemaCompatibility compares two sets of event schemas keyed by event type and returns every change
+// that would break a consumer written against the old set. An empty result means the new set is safe to publish.
+func CheckSchemaCompatibility(old, new map[string]*SchemaDocument) []string {
+	var problems []string
+	for _, eventType := range sortedKeys(old) {
+		newDocument, ok := new[eventType]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: schema removed", eventType))
+			continue
+		}
+		for _, problem := range CheckCompatibility(old[eventType], newDocument) {
+			problems = append(problems, fmt.Sprintf("%s: %s", eventType, problem))
+		}
+	}
+	return problems
+}
+
+// CheckCompatibility returns the changes from old to new that break consumers of the old schema.
+// Adding optional properties is compatible; removing properties, making required ones optional,
+// widening types and adding enum values are not, since consumers may not handle the new payloads.
+func CheckCompatibility(old, new *SchemaDocument) []string {
+	return checkProperties("", old.Properties, old.Required, new.Properties, new.Required)
+}
+
+func checkProperties(prefix string, oldProperties map[string]*PropertySchema, oldRequired []string, newProperties map[string]*PropertySchema, newRequired []string) []string {
+	var problems []string
+	for _, name := range sortedKeys(oldProperties) {
+		field := prefix + name
+		newProperty, ok := newProperties[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("property %s removed", field))
+			continue
+		}
+		if contains(oldRequired, name) && !contains(newRequired, name) {
+			problems = append(problems, fmt.Sprintf("property %s is no longer required", field))
+		}
+		problems = append(problems, checkProperty(field, oldProperties[name], newProperty)...)
+	}
+	return problems
+}
+
+func checkProperty(field string, old, new *PropertySchema) []string {
+	var problems []string
+
+	for _, jsonType := range new.Types {
+		if !old.Types.Allows(jsonType) {
+			problems = append(problems, fmt.Sprintf("property %s now accepts type %s", field, jsonType))
+		}
+	}
+	if len(old.Types) > 0 && len(new.Types) == 0 {
+		problems = append(problems, fmt.Sprintf("property %s no longer restricts its type", field))
+	}
+
+	if old.Const != nil && !reflect.DeepEqual(old.Const, new.Const) {
+		problems = append(problems, fmt.Sprintf("property %s changed its constant from %v to %v", field, old.Const, new.Const))
+	}
+
+	if len(old.Enum) > 0 {
+		if len(new.Enum) == 0 {
+			problems = append(problems, fmt.Sprintf("property %s no longer restricts its values", field))
+		}
+		for _, value := range new.Enum {
+			if !containsValue(old.Enum, value) {
+				problems = append(problems, fmt.Sprintf("property %s accepts the new value %v", field, value))
+			}
+		}
+	}
+
+	if old.Items != nil {
+		if new.Items == nil {
+			problems = append(problems, fmt.Sprintf("property %s no longer restricts its items", field))
+		} else {
+			problems = append(problems, checkProperty(field+"[]", old.Items, new.Items)...)
+		}
+	}
+
+	problems = append(problems, checkProperties(field+".", old.Properties, old.Required, new.Properties, new.Required)...)
+	return problems
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
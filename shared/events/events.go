@@ -1,7 +1,5 @@
 package events
 
-import "time"
-
 // 材料移動事件
 type MaterialMovedEvent struct {
     BaseEvent
@@ -45,13 +43,7 @@ type ShelfHealthEvent struct {
 // 事件工廠函數
 func NewMaterialMovedEvent(materialID, fromSlotID, toSlotID, shelfID, operatorID string) *MaterialMovedEvent {
     return &MaterialMovedEvent{
-        BaseEvent: BaseEvent{
-            EventID:   generateUUID(),
-            EventType: "material.moved",
-            Version:   "v1",
-            Timestamp: time.Now(),
-            Source:    "inventory-service",
-        },
+        BaseEvent:  NewBaseEvent("material.moved", "inventory-service", shelfID),
         MaterialID: materialID,
         FromSlotID: fromSlotID,
         ToSlotID:   toSlotID,
@@ -62,13 +54,7 @@ func NewMaterialMovedEvent(materialID, fromSlotID, toSlotID, shelfID, operatorID
 
 func NewShelfHealthEvent(shelfID string, healthScore float64, totalSlots, healthySlots, errorSlots int) *ShelfHealthEvent {
     return &ShelfHealthEvent{
-        BaseEvent: BaseEvent{
-            EventID:   generateUUID(),
-            EventType: "shelf.health",
-            Version:   "v1",
-            Timestamp: time.Now(),
-            Source:    "inventory-service",
-        },
+        BaseEvent:    NewBaseEvent("shelf.health", "inventory-service", shelfID),
         ShelfID:      shelfID,
         HealthScore:  healthScore,
         TotalSlots:   totalSlots,
@@ -0,0 +1,114 @@
+package events
+
+// Events published by the inventory service. The event type is carried by the envelope,
+// so one struct may serve several event types, e.g. PhysicalPlacementEvent.
+
+type MaterialPlacedEvent struct {
+	BaseEvent
+	MaterialID  string `json:"material_id"`
+	SlotID      string `json:"slot_id"`
+	ShelfID     string `json:"shelf_id"`
+	OperatorID  string `json:"operator_id"`
+	OperationID string `json:"operation_id,omitempty"`
+}
+
+type MaterialRemovedEvent struct {
+	BaseEvent
+	MaterialID  string `json:"material_id"`
+	SlotID      string `json:"slot_id"`
+	ShelfID     string `json:"shelf_id"`
+	OperatorID  string `json:"operator_id"`
+	OperationID string `json:"operation_id,omitempty"`
+}
+
+type SlotsReservedEvent struct {
+	BaseEvent
+	SlotIDs    []string `json:"slot_ids"`
+	OperatorID string   `json:"operator_id"`
+	Duration   int      `json:"duration,omitempty"` // reservation length in minutes
+	Purpose    string   `json:"purpose,omitempty"`
+}
+
+// PhysicalPlacementEvent tracks a placement waiting for, or resolved by, the shelf sensors
+type PhysicalPlacementEvent struct {
+	BaseEvent
+	OperationID     string `json:"operation_id"`
+	MaterialID      string `json:"material_id"`
+	MaterialBarcode string `json:"material_barcode,omitempty"` // what the shelf is expected to read once the material is in place
+	SlotID          string `json:"slot_id"`
+	ShelfID         string `json:"shelf_id"`
+	OperatorID      string `json:"operator_id"`
+}
+
+// UnplannedSlotEvent reports material detected in or taken out of a slot without a pending operation
+type UnplannedSlotEvent struct {
+	BaseEvent
+	SlotID          string `json:"slot_id"`
+	ShelfID         string `json:"shelf_id,omitempty"`
+	MaterialBarcode string `json:"material_barcode"`
+}
+
+type SlotErrorEvent struct {
+	BaseEvent
+	SlotID      string                 `json:"slot_id"`
+	ShelfID     string                 `json:"shelf_id"`
+	ErrorType   string                 `json:"error_type"`
+	Remediation string                 `json:"remediation"`
+	Details     map[string]interface{} `json:"details,omitempty"`
+}
+
+type RelocationSuggestedEvent struct {
+	BaseEvent
+	TicketID         string   `json:"ticket_id"`
+	SlotID           string   `json:"slot_id"`
+	ShelfID          string   `json:"shelf_id"`
+	MaterialID       string   `json:"material_id"`
+	SuggestedSlotIDs []string `json:"suggested_slot_ids"`
+}
+
+// SlotTakenOutOfServiceEvent reports a slot put under maintenance, material left in it stays there
+type SlotTakenOutOfServiceEvent struct {
+	BaseEvent
+	TicketID   string `json:"ticket_id"`
+	SlotID     string `json:"slot_id"`
+	ShelfID    string `json:"shelf_id"`
+	Reason     string `json:"reason"`
+	MaterialID string `json:"material_id,omitempty"`
+}
+
+type SlotReturnedToServiceEvent struct {
+	BaseEvent
+	TicketID string `json:"ticket_id"`
+	SlotID   string `json:"slot_id"`
+	ShelfID  string `json:"shelf_id"`
+	Status   string `json:"status"`
+}
+
+type ShelfHealthAlertEvent struct {
+	BaseEvent
+	AlertType   string  `json:"type"`
+	ShelfID     string  `json:"shelf_id"`
+	HealthScore float64 `json:"health_score"`
+	Message     string  `json:"message"`
+	Severity    string  `json:"severity"`
+}
+
+type SystemAlertEvent struct {
+	BaseEvent
+	AlertType string                 `json:"alert_type"`
+	Severity  string                 `json:"severity"`
+	Message   string                 `json:"message"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+type AuditLogEvent struct {
+	BaseEvent
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type,omitempty"`
+	EntityID   string                 `json:"entity_id,omitempty"`
+	OperatorID string                 `json:"operator_id,omitempty"`
+	Changes    map[string]interface{} `json:"changes,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Success    bool                   `json:"success"`
+	ErrorMsg   string                 `json:"error_message,omitempty"`
+}
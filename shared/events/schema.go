@@ -0,0 +1,282 @@
+package events
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// SchemaBaseURL is the $id prefix of the event schemas, relative $refs between them resolve against it
+const SchemaBaseURL = "https://schemas.wms.local/events/"
+
+// envelopeSchema holds the BaseEvent fields, every event schema references it
+const envelopeSchema = "envelope.json"
+
+// schemaFiles holds one JSON Schema per event type, named <event_type>.json.
+// Consumers in other languages can validate against the same files.
+//
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// SchemaRegistry validates event payloads against the schema of their event type
+type SchemaRegistry struct {
+	validators map[string]*jsonschema.Schema
+	documents  map[string]*SchemaDocument
+}
+
+// SchemaDocument is the part of an event schema the compatibility checker and the contract tests look at.
+// Properties and Required include the envelope fields pulled in through allOf.
+type SchemaDocument struct {
+	Title      string
+	Properties map[string]*PropertySchema
+	Required   []string
+	Closed     bool // true when properties not declared by the schema are rejected
+}
+
+type PropertySchema struct {
+	Types      SchemaTypes                `json:"type,omitempty"`
+	Const      interface{}                `json:"const,omitempty"`
+	Enum       []interface{}              `json:"enum,omitempty"`
+	Items      *PropertySchema            `json:"items,omitempty"`
+	Properties map[string]*PropertySchema `json:"properties,omitempty"`
+	Required   []string                   `json:"required,omitempty"`
+}
+
+// SchemaTypes is the "type" keyword, which may be a single type or a list
+type SchemaTypes []string
+
+func (t *SchemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = SchemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// Allows reports whether a value of the given JSON type is accepted. No type keyword accepts everything.
+func (t SchemaTypes) Allows(jsonType string) bool {
+	if len(t) == 0 {
+		return true
+	}
+	for _, allowed := range t {
+		if allowed == jsonType || (allowed == "number" && jsonType == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// rawSchema is an event schema file as written on disk
+type rawSchema struct {
+	Title                 string                     `json:"title"`
+	AllOf                 []rawSchemaRef             `json:"allOf"`
+	Properties            map[string]*PropertySchema `json:"properties"`
+	Required              []string                   `json:"required"`
+	UnevaluatedProperties *bool                      `json:"unevaluatedProperties"`
+	AdditionalProperties  *bool                      `json:"additionalProperties"`
+}
+
+type rawSchemaRef struct {
+	Ref string `json:"$ref"`
+}
+
+// NewSchemaRegistry loads the schemas embedded in this package
+func NewSchemaRegistry() (*SchemaRegistry, error) {
+	sub, err := fs.Sub(schemaFiles, "schemas")
+	if err != nil {
+		return nil, err
+	}
+	return LoadSchemaRegistry(sub)
+}
+
+// LoadSchemaRegistry loads the event schemas found at the root of fsys
+func LoadSchemaRegistry(fsys fs.FS) (*SchemaRegistry, error) {
+	files, err := readSchemaFiles(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	for name, data := range files {
+		if err := compiler.AddResource(SchemaBaseURL+name, bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("invalid schema %s: %w", name, err)
+		}
+	}
+
+	documents, err := parseSchemaDocuments(files)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := &SchemaRegistry{
+		validators: make(map[string]*jsonschema.Schema, len(documents)),
+		documents:  documents,
+	}
+	for eventType := range documents {
+		schema, err := compiler.Compile(SchemaBaseURL + eventType + ".json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile schema for %s: %w", eventType, err)
+		}
+		registry.validators[eventType] = schema
+	}
+	return registry, nil
+}
+
+// LoadSchemaDocuments parses the event schemas found at the root of fsys without compiling them
+func LoadSchemaDocuments(fsys fs.FS) (map[string]*SchemaDocument, error) {
+	files, err := readSchemaFiles(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return parseSchemaDocuments(files)
+}
+
+// EventTypes returns the event types that have a schema, in order
+func (r *SchemaRegistry) EventTypes() []string {
+	eventTypes := make([]string, 0, len(r.documents))
+	for eventType := range r.documents {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	return eventTypes
+}
+
+// Document returns the parsed schema of an event type
+func (r *SchemaRegistry) Document(eventType string) (*SchemaDocument, bool) {
+	document, ok := r.documents[eventType]
+	return document, ok
+}
+
+// Documents returns the parsed schemas keyed by event type
+func (r *SchemaRegistry) Documents() map[string]*SchemaDocument {
+	return r.documents
+}
+
+// Validate checks a JSON payload against the schema of its event type.
+// Event types without a schema are rejected, so new events cannot be published unnoticed.
+func (r *SchemaRegistry) Validate(eventType string, payload []byte) error {
+	schema, ok := r.validators[eventType]
+	if !ok {
+		return fmt.Errorf("no schema registered for event type %q", eventType)
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", eventType, err)
+	}
+
+	if err := schema.Validate(value); err != nil {
+		return fmt.Errorf("%s payload does not match its schema: %w", eventType, err)
+	}
+	return nil
+}
+
+func readSchemaFiles(fsys fs.FS) (map[string][]byte, error) {
+	names, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(names))
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = data
+	}
+	if _, ok := files[envelopeSchema]; !ok {
+		return nil, fmt.Errorf("%s not found", envelopeSchema)
+	}
+	return files, nil
+}
+
+func parseSchemaDocuments(files map[string][]byte) (map[string]*SchemaDocument, error) {
+	raw := make(map[string]*rawSchema, len(files))
+	for name, data := range files {
+		var schema rawSchema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return nil, fmt.Errorf("invalid schema %s: %w", name, err)
+		}
+		raw[name] = &schema
+	}
+
+	documents := make(map[string]*SchemaDocument, len(raw)-1)
+	for name, schema := range raw {
+		if name == envelopeSchema {
+			continue
+		}
+
+		document := &SchemaDocument{
+			Title:      schema.Title,
+			Properties: make(map[string]*PropertySchema),
+			Closed:     isFalse(schema.UnevaluatedProperties) || isFalse(schema.AdditionalProperties),
+		}
+		for _, ref := range schema.AllOf {
+			base, ok := raw[ref.Ref]
+			if !ok {
+				return nil, fmt.Errorf("schema %s references unknown schema %s", name, ref.Ref)
+			}
+			mergeSchema(document, base)
+		}
+		mergeSchema(document, schema)
+
+		documents[strings.TrimSuffix(name, path.Ext(name))] = document
+	}
+	return documents, nil
+}
+
+// mergeSchema adds the properties of schema to document, later definitions narrow earlier ones
+func mergeSchema(document *SchemaDocument, schema *rawSchema) {
+	for name, property := range schema.Properties {
+		if existing, ok := document.Properties[name]; ok {
+			merged := *existing
+			if len(property.Types) > 0 {
+				merged.Types = property.Types
+			}
+			if property.Const != nil {
+				merged.Const = property.Const
+			}
+			if len(property.Enum) > 0 {
+				merged.Enum = property.Enum
+			}
+			document.Properties[name] = &merged
+			continue
+		}
+		document.Properties[name] = property
+	}
+	for _, name := range schema.Required {
+		if !contains(document.Required, name) {
+			document.Required = append(document.Required, name)
+		}
+	}
+}
+
+func isFalse(b *bool) bool {
+	return b != nil && !*b
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,1584 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: inventory.proto
+
+package inventory
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 枚舉類型
+type MaterialStatus int32
+
+const (
+	MaterialStatus_MATERIAL_STATUS_UNSPECIFIED MaterialStatus = 0
+	MaterialStatus_MATERIAL_STATUS_AVAILABLE   MaterialStatus = 1
+	MaterialStatus_MATERIAL_STATUS_IN_USE      MaterialStatus = 2
+	MaterialStatus_MATERIAL_STATUS_RESERVED    MaterialStatus = 3
+	MaterialStatus_MATERIAL_STATUS_MAINTENANCE MaterialStatus = 4
+)
+
+// Enum value maps for MaterialStatus.
+var (
+	MaterialStatus_name = map[int32]string{
+		0: "MATERIAL_STATUS_UNSPECIFIED",
+		1: "MATERIAL_STATUS_AVAILABLE",
+		2: "MATERIAL_STATUS_IN_USE",
+		3: "MATERIAL_STATUS_RESERVED",
+		4: "MATERIAL_STATUS_MAINTENANCE",
+	}
+	MaterialStatus_value = map[string]int32{
+		"MATERIAL_STATUS_UNSPECIFIED": 0,
+		"MATERIAL_STATUS_AVAILABLE":   1,
+		"MATERIAL_STATUS_IN_USE":      2,
+		"MATERIAL_STATUS_RESERVED":    3,
+		"MATERIAL_STATUS_MAINTENANCE": 4,
+	}
+)
+
+func (x MaterialStatus) Enum() *MaterialStatus {
+	p := new(MaterialStatus)
+	*p = x
+	return p
+}
+
+func (x MaterialStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MaterialStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_proto_enumTypes[0].Descriptor()
+}
+
+func (MaterialStatus) Type() protoreflect.EnumType {
+	return &file_inventory_proto_enumTypes[0]
+}
+
+func (x MaterialStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MaterialStatus.Descriptor instead.
+func (MaterialStatus) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+type SlotStatus int32
+
+const (
+	SlotStatus_SLOT_STATUS_UNSPECIFIED     SlotStatus = 0
+	SlotStatus_SLOT_STATUS_EMPTY           SlotStatus = 1
+	SlotStatus_SLOT_STATUS_OCCUPIED        SlotStatus = 2
+	SlotStatus_SLOT_STATUS_RESERVED        SlotStatus = 3
+	SlotStatus_SLOT_STATUS_MAINTENANCE     SlotStatus = 4
+	SlotStatus_SLOT_STATUS_REMOVAL_PENDING SlotStatus = 5
+)
+
+// Enum value maps for SlotStatus.
+var (
+	SlotStatus_name = map[int32]string{
+		0: "SLOT_STATUS_UNSPECIFIED",
+		1: "SLOT_STATUS_EMPTY",
+		2: "SLOT_STATUS_OCCUPIED",
+		3: "SLOT_STATUS_RESERVED",
+		4: "SLOT_STATUS_MAINTENANCE",
+		5: "SLOT_STATUS_REMOVAL_PENDING",
+	}
+	SlotStatus_value = map[string]int32{
+		"SLOT_STATUS_UNSPECIFIED":     0,
+		"SLOT_STATUS_EMPTY":           1,
+		"SLOT_STATUS_OCCUPIED":        2,
+		"SLOT_STATUS_RESERVED":        3,
+		"SLOT_STATUS_MAINTENANCE":     4,
+		"SLOT_STATUS_REMOVAL_PENDING": 5,
+	}
+)
+
+func (x SlotStatus) Enum() *SlotStatus {
+	p := new(SlotStatus)
+	*p = x
+	return p
+}
+
+func (x SlotStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SlotStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_proto_enumTypes[1].Descriptor()
+}
+
+func (SlotStatus) Type() protoreflect.EnumType {
+	return &file_inventory_proto_enumTypes[1]
+}
+
+func (x SlotStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SlotStatus.Descriptor instead.
+func (SlotStatus) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+type OperationType int32
+
+const (
+	OperationType_OPERATION_TYPE_UNSPECIFIED OperationType = 0
+	OperationType_OPERATION_TYPE_PLACEMENT   OperationType = 1
+	OperationType_OPERATION_TYPE_REMOVAL     OperationType = 2
+	OperationType_OPERATION_TYPE_MOVE        OperationType = 3
+	OperationType_OPERATION_TYPE_RESERVATION OperationType = 4
+)
+
+// Enum value maps for OperationType.
+var (
+	OperationType_name = map[int32]string{
+		0: "OPERATION_TYPE_UNSPECIFIED",
+		1: "OPERATION_TYPE_PLACEMENT",
+		2: "OPERATION_TYPE_REMOVAL",
+		3: "OPERATION_TYPE_MOVE",
+		4: "OPERATION_TYPE_RESERVATION",
+	}
+	OperationType_value = map[string]int32{
+		"OPERATION_TYPE_UNSPECIFIED": 0,
+		"OPERATION_TYPE_PLACEMENT":   1,
+		"OPERATION_TYPE_REMOVAL":     2,
+		"OPERATION_TYPE_MOVE":        3,
+		"OPERATION_TYPE_RESERVATION": 4,
+	}
+)
+
+func (x OperationType) Enum() *OperationType {
+	p := new(OperationType)
+	*p = x
+	return p
+}
+
+func (x OperationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_proto_enumTypes[2].Descriptor()
+}
+
+func (OperationType) Type() protoreflect.EnumType {
+	return &file_inventory_proto_enumTypes[2]
+}
+
+func (x OperationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperationType.Descriptor instead.
+func (OperationType) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+type OperationStatus int32
+
+const (
+	OperationStatus_OPERATION_STATUS_UNSPECIFIED                   OperationStatus = 0
+	OperationStatus_OPERATION_STATUS_PENDING                       OperationStatus = 1
+	OperationStatus_OPERATION_STATUS_COMPLETED                     OperationStatus = 2
+	OperationStatus_OPERATION_STATUS_FAILED                        OperationStatus = 3
+	OperationStatus_OPERATION_STATUS_CANCELLED                     OperationStatus = 4
+	OperationStatus_OPERATION_STATUS_PENDING_PHYSICAL_CONFIRMATION OperationStatus = 5
+	OperationStatus_OPERATION_STATUS_PENDING_REMOVAL_CONFIRMATION  OperationStatus = 6
+)
+
+// Enum value maps for OperationStatus.
+var (
+	OperationStatus_name = map[int32]string{
+		0: "OPERATION_STATUS_UNSPECIFIED",
+		1: "OPERATION_STATUS_PENDING",
+		2: "OPERATION_STATUS_COMPLETED",
+		3: "OPERATION_STATUS_FAILED",
+		4: "OPERATION_STATUS_CANCELLED",
+		5: "OPERATION_STATUS_PENDING_PHYSICAL_CONFIRMATION",
+		6: "OPERATION_STATUS_PENDING_REMOVAL_CONFIRMATION",
+	}
+	OperationStatus_value = map[string]int32{
+		"OPERATION_STATUS_UNSPECIFIED":                   0,
+		"OPERATION_STATUS_PENDING":                       1,
+		"OPERATION_STATUS_COMPLETED":                     2,
+		"OPERATION_STATUS_FAILED":                        3,
+		"OPERATION_STATUS_CANCELLED":                     4,
+		"OPERATION_STATUS_PENDING_PHYSICAL_CONFIRMATION": 5,
+		"OPERATION_STATUS_PENDING_REMOVAL_CONFIRMATION":  6,
+	}
+)
+
+func (x OperationStatus) Enum() *OperationStatus {
+	p := new(OperationStatus)
+	*p = x
+	return p
+}
+
+func (x OperationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_proto_enumTypes[3].Descriptor()
+}
+
+func (OperationStatus) Type() protoreflect.EnumType {
+	return &file_inventory_proto_enumTypes[3]
+}
+
+func (x OperationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperationStatus.Descriptor instead.
+func (OperationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+// 請求/響應消息
+type PlaceMaterialRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MaterialBarcode string                 `protobuf:"bytes,1,opt,name=material_barcode,json=materialBarcode,proto3" json:"material_barcode,omitempty"`
+	SlotId          string                 `protobuf:"bytes,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	OperatorId      string                 `protobuf:"bytes,3,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PlaceMaterialRequest) Reset() {
+	*x = PlaceMaterialRequest{}
+	mi := &file_inventory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceMaterialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceMaterialRequest) ProtoMessage() {}
+
+func (x *PlaceMaterialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceMaterialRequest.ProtoReflect.Descriptor instead.
+func (*PlaceMaterialRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlaceMaterialRequest) GetMaterialBarcode() string {
+	if x != nil {
+		return x.MaterialBarcode
+	}
+	return ""
+}
+
+func (x *PlaceMaterialRequest) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *PlaceMaterialRequest) GetOperatorId() string {
+	if x != nil {
+		return x.OperatorId
+	}
+	return ""
+}
+
+type PlaceMaterialResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Operation     *Operation             `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceMaterialResponse) Reset() {
+	*x = PlaceMaterialResponse{}
+	mi := &file_inventory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceMaterialResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceMaterialResponse) ProtoMessage() {}
+
+func (x *PlaceMaterialResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceMaterialResponse.ProtoReflect.Descriptor instead.
+func (*PlaceMaterialResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlaceMaterialResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PlaceMaterialResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PlaceMaterialResponse) GetOperation() *Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+type RemoveMaterialRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlotId        string                 `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	OperatorId    string                 `protobuf:"bytes,2,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMaterialRequest) Reset() {
+	*x = RemoveMaterialRequest{}
+	mi := &file_inventory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMaterialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMaterialRequest) ProtoMessage() {}
+
+func (x *RemoveMaterialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMaterialRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMaterialRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RemoveMaterialRequest) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *RemoveMaterialRequest) GetOperatorId() string {
+	if x != nil {
+		return x.OperatorId
+	}
+	return ""
+}
+
+func (x *RemoveMaterialRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RemoveMaterialResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMaterialResponse) Reset() {
+	*x = RemoveMaterialResponse{}
+	mi := &file_inventory_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMaterialResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMaterialResponse) ProtoMessage() {}
+
+func (x *RemoveMaterialResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMaterialResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMaterialResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RemoveMaterialResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RemoveMaterialResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetShelfStatusRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId        string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	IncludeDetails bool                   `protobuf:"varint,2,opt,name=include_details,json=includeDetails,proto3" json:"include_details,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetShelfStatusRequest) Reset() {
+	*x = GetShelfStatusRequest{}
+	mi := &file_inventory_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShelfStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShelfStatusRequest) ProtoMessage() {}
+
+func (x *GetShelfStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShelfStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetShelfStatusRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetShelfStatusRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *GetShelfStatusRequest) GetIncludeDetails() bool {
+	if x != nil {
+		return x.IncludeDetails
+	}
+	return false
+}
+
+type GetShelfStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *ShelfStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShelfStatusResponse) Reset() {
+	*x = GetShelfStatusResponse{}
+	mi := &file_inventory_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShelfStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShelfStatusResponse) ProtoMessage() {}
+
+func (x *GetShelfStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShelfStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetShelfStatusResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetShelfStatusResponse) GetStatus() *ShelfStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type SearchMaterialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Status        MaterialStatus         `protobuf:"varint,3,opt,name=status,proto3,enum=warehouse.inventory.v1.MaterialStatus" json:"status,omitempty"`
+	ShelfId       string                 `protobuf:"bytes,4,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMaterialsRequest) Reset() {
+	*x = SearchMaterialsRequest{}
+	mi := &file_inventory_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMaterialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMaterialsRequest) ProtoMessage() {}
+
+func (x *SearchMaterialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMaterialsRequest.ProtoReflect.Descriptor instead.
+func (*SearchMaterialsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchMaterialsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchMaterialsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SearchMaterialsRequest) GetStatus() MaterialStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MaterialStatus_MATERIAL_STATUS_UNSPECIFIED
+}
+
+func (x *SearchMaterialsRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *SearchMaterialsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchMaterialsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type SearchMaterialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Materials     []*Material            `protobuf:"bytes,1,rep,name=materials,proto3" json:"materials,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	TypeFacets    []*FacetCount          `protobuf:"bytes,3,rep,name=type_facets,json=typeFacets,proto3" json:"type_facets,omitempty"`
+	StatusFacets  []*FacetCount          `protobuf:"bytes,4,rep,name=status_facets,json=statusFacets,proto3" json:"status_facets,omitempty"`
+	ShelfFacets   []*FacetCount          `protobuf:"bytes,5,rep,name=shelf_facets,json=shelfFacets,proto3" json:"shelf_facets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMaterialsResponse) Reset() {
+	*x = SearchMaterialsResponse{}
+	mi := &file_inventory_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMaterialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMaterialsResponse) ProtoMessage() {}
+
+func (x *SearchMaterialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMaterialsResponse.ProtoReflect.Descriptor instead.
+func (*SearchMaterialsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchMaterialsResponse) GetMaterials() []*Material {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+func (x *SearchMaterialsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchMaterialsResponse) GetTypeFacets() []*FacetCount {
+	if x != nil {
+		return x.TypeFacets
+	}
+	return nil
+}
+
+func (x *SearchMaterialsResponse) GetStatusFacets() []*FacetCount {
+	if x != nil {
+		return x.StatusFacets
+	}
+	return nil
+}
+
+func (x *SearchMaterialsResponse) GetShelfFacets() []*FacetCount {
+	if x != nil {
+		return x.ShelfFacets
+	}
+	return nil
+}
+
+type GetOperationHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId       string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	SlotId        string                 `protobuf:"bytes,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	OperatorId    string                 `protobuf:"bytes,3,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	MaterialId    string                 `protobuf:"bytes,4,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	Type          OperationType          `protobuf:"varint,5,opt,name=type,proto3,enum=warehouse.inventory.v1.OperationType" json:"type,omitempty"`
+	Status        OperationStatus        `protobuf:"varint,6,opt,name=status,proto3,enum=warehouse.inventory.v1.OperationStatus" json:"status,omitempty"`
+	From          int64                  `protobuf:"varint,7,opt,name=from,proto3" json:"from,omitempty"` // unix 秒，0 表示不限
+	To            int64                  `protobuf:"varint,8,opt,name=to,proto3" json:"to,omitempty"`
+	Cursor        string                 `protobuf:"bytes,9,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上一頁返回的 next_cursor
+	Limit         int32                  `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOperationHistoryRequest) Reset() {
+	*x = GetOperationHistoryRequest{}
+	mi := &file_inventory_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOperationHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationHistoryRequest) ProtoMessage() {}
+
+func (x *GetOperationHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOperationHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetOperationHistoryRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *GetOperationHistoryRequest) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *GetOperationHistoryRequest) GetOperatorId() string {
+	if x != nil {
+		return x.OperatorId
+	}
+	return ""
+}
+
+func (x *GetOperationHistoryRequest) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *GetOperationHistoryRequest) GetType() OperationType {
+	if x != nil {
+		return x.Type
+	}
+	return OperationType_OPERATION_TYPE_UNSPECIFIED
+}
+
+func (x *GetOperationHistoryRequest) GetStatus() OperationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OperationStatus_OPERATION_STATUS_UNSPECIFIED
+}
+
+func (x *GetOperationHistoryRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetOperationHistoryRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetOperationHistoryRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetOperationHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetOperationHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operations    []*Operation           `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 最後一頁為空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOperationHistoryResponse) Reset() {
+	*x = GetOperationHistoryResponse{}
+	mi := &file_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOperationHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationHistoryResponse) ProtoMessage() {}
+
+func (x *GetOperationHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOperationHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOperationHistoryResponse) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *GetOperationHistoryResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetOperationHistoryResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// 數據模型，時間字段均為 unix 秒
+type Material struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Barcode       string                 `protobuf:"bytes,2,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Status        MaterialStatus         `protobuf:"varint,5,opt,name=status,proto3,enum=warehouse.inventory.v1.MaterialStatus" json:"status,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Material) Reset() {
+	*x = Material{}
+	mi := &file_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Material) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Material) ProtoMessage() {}
+
+func (x *Material) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Material.ProtoReflect.Descriptor instead.
+func (*Material) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Material) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Material) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *Material) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Material) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Material) GetStatus() MaterialStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MaterialStatus_MATERIAL_STATUS_UNSPECIFIED
+}
+
+func (x *Material) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Material) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type Slot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShelfId       string                 `protobuf:"bytes,2,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	Row           int32                  `protobuf:"varint,3,opt,name=row,proto3" json:"row,omitempty"`
+	Column        int32                  `protobuf:"varint,4,opt,name=column,proto3" json:"column,omitempty"`
+	Status        SlotStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=warehouse.inventory.v1.SlotStatus" json:"status,omitempty"`
+	MaterialId    string                 `protobuf:"bytes,6,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int64                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Slot) Reset() {
+	*x = Slot{}
+	mi := &file_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Slot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Slot) ProtoMessage() {}
+
+func (x *Slot) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Slot.ProtoReflect.Descriptor instead.
+func (*Slot) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Slot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Slot) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *Slot) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *Slot) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *Slot) GetStatus() SlotStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SlotStatus_SLOT_STATUS_UNSPECIFIED
+}
+
+func (x *Slot) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *Slot) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Slot) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Operation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          OperationType          `protobuf:"varint,2,opt,name=type,proto3,enum=warehouse.inventory.v1.OperationType" json:"type,omitempty"`
+	MaterialId    string                 `protobuf:"bytes,3,opt,name=material_id,json=materialId,proto3" json:"material_id,omitempty"`
+	SlotId        string                 `protobuf:"bytes,4,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	OperatorId    string                 `protobuf:"bytes,5,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	ShelfId       string                 `protobuf:"bytes,6,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Status        OperationStatus        `protobuf:"varint,8,opt,name=status,proto3,enum=warehouse.inventory.v1.OperationStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Operation) Reset() {
+	*x = Operation{}
+	mi := &file_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operation) ProtoMessage() {}
+
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Operation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Operation) GetType() OperationType {
+	if x != nil {
+		return x.Type
+	}
+	return OperationType_OPERATION_TYPE_UNSPECIFIED
+}
+
+func (x *Operation) GetMaterialId() string {
+	if x != nil {
+		return x.MaterialId
+	}
+	return ""
+}
+
+func (x *Operation) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *Operation) GetOperatorId() string {
+	if x != nil {
+		return x.OperatorId
+	}
+	return ""
+}
+
+func (x *Operation) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *Operation) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Operation) GetStatus() OperationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OperationStatus_OPERATION_STATUS_UNSPECIFIED
+}
+
+type ShelfStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId       string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	TotalSlots    int32                  `protobuf:"varint,2,opt,name=total_slots,json=totalSlots,proto3" json:"total_slots,omitempty"`
+	EmptySlots    int32                  `protobuf:"varint,3,opt,name=empty_slots,json=emptySlots,proto3" json:"empty_slots,omitempty"`
+	OccupiedSlots int32                  `protobuf:"varint,4,opt,name=occupied_slots,json=occupiedSlots,proto3" json:"occupied_slots,omitempty"`
+	Slots         []*Slot                `protobuf:"bytes,5,rep,name=slots,proto3" json:"slots,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShelfStatus) Reset() {
+	*x = ShelfStatus{}
+	mi := &file_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShelfStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShelfStatus) ProtoMessage() {}
+
+func (x *ShelfStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShelfStatus.ProtoReflect.Descriptor instead.
+func (*ShelfStatus) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ShelfStatus) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *ShelfStatus) GetTotalSlots() int32 {
+	if x != nil {
+		return x.TotalSlots
+	}
+	return 0
+}
+
+func (x *ShelfStatus) GetEmptySlots() int32 {
+	if x != nil {
+		return x.EmptySlots
+	}
+	return 0
+}
+
+func (x *ShelfStatus) GetOccupiedSlots() int32 {
+	if x != nil {
+		return x.OccupiedSlots
+	}
+	return 0
+}
+
+func (x *ShelfStatus) GetSlots() []*Slot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+func (x *ShelfStatus) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type FacetCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetCount) Reset() {
+	*x = FacetCount{}
+	mi := &file_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetCount) ProtoMessage() {}
+
+func (x *FacetCount) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetCount.ProtoReflect.Descriptor instead.
+func (*FacetCount) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FacetCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FacetCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_inventory_proto protoreflect.FileDescriptor
+
+const file_inventory_proto_rawDesc = "" +
+	"\n" +
+	"\x0finventory.proto\x12\x16warehouse.inventory.v1\"{\n" +
+	"\x14PlaceMaterialRequest\x12)\n" +
+	"\x10material_barcode\x18\x01 \x01(\tR\x0fmaterialBarcode\x12\x17\n" +
+	"\aslot_id\x18\x02 \x01(\tR\x06slotId\x12\x1f\n" +
+	"\voperator_id\x18\x03 \x01(\tR\n" +
+	"operatorId\"\x8c\x01\n" +
+	"\x15PlaceMaterialResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12?\n" +
+	"\toperation\x18\x03 \x01(\v2!.warehouse.inventory.v1.OperationR\toperation\"i\n" +
+	"\x15RemoveMaterialRequest\x12\x17\n" +
+	"\aslot_id\x18\x01 \x01(\tR\x06slotId\x12\x1f\n" +
+	"\voperator_id\x18\x02 \x01(\tR\n" +
+	"operatorId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"L\n" +
+	"\x16RemoveMaterialResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"[\n" +
+	"\x15GetShelfStatusRequest\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\x12'\n" +
+	"\x0finclude_details\x18\x02 \x01(\bR\x0eincludeDetails\"U\n" +
+	"\x16GetShelfStatusResponse\x12;\n" +
+	"\x06status\x18\x01 \x01(\v2#.warehouse.inventory.v1.ShelfStatusR\x06status\"\xcb\x01\n" +
+	"\x16SearchMaterialsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12>\n" +
+	"\x06status\x18\x03 \x01(\x0e2&.warehouse.inventory.v1.MaterialStatusR\x06status\x12\x19\n" +
+	"\bshelf_id\x18\x04 \x01(\tR\ashelfId\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\"\xcf\x02\n" +
+	"\x17SearchMaterialsResponse\x12>\n" +
+	"\tmaterials\x18\x01 \x03(\v2 .warehouse.inventory.v1.MaterialR\tmaterials\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12C\n" +
+	"\vtype_facets\x18\x03 \x03(\v2\".warehouse.inventory.v1.FacetCountR\n" +
+	"typeFacets\x12G\n" +
+	"\rstatus_facets\x18\x04 \x03(\v2\".warehouse.inventory.v1.FacetCountR\fstatusFacets\x12E\n" +
+	"\fshelf_facets\x18\x05 \x03(\v2\".warehouse.inventory.v1.FacetCountR\vshelfFacets\"\xe0\x02\n" +
+	"\x1aGetOperationHistoryRequest\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\x12\x17\n" +
+	"\aslot_id\x18\x02 \x01(\tR\x06slotId\x12\x1f\n" +
+	"\voperator_id\x18\x03 \x01(\tR\n" +
+	"operatorId\x12\x1f\n" +
+	"\vmaterial_id\x18\x04 \x01(\tR\n" +
+	"materialId\x129\n" +
+	"\x04type\x18\x05 \x01(\x0e2%.warehouse.inventory.v1.OperationTypeR\x04type\x12?\n" +
+	"\x06status\x18\x06 \x01(\x0e2'.warehouse.inventory.v1.OperationStatusR\x06status\x12\x12\n" +
+	"\x04from\x18\a \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\b \x01(\x03R\x02to\x12\x16\n" +
+	"\x06cursor\x18\t \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\n" +
+	" \x01(\x05R\x05limit\"\xa2\x01\n" +
+	"\x1bGetOperationHistoryResponse\x12A\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2!.warehouse.inventory.v1.OperationR\n" +
+	"operations\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\xda\x01\n" +
+	"\bMaterial\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\abarcode\x18\x02 \x01(\tR\abarcode\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12>\n" +
+	"\x06status\x18\x05 \x01(\x0e2&.warehouse.inventory.v1.MaterialStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\"\xf1\x01\n" +
+	"\x04Slot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bshelf_id\x18\x02 \x01(\tR\ashelfId\x12\x10\n" +
+	"\x03row\x18\x03 \x01(\x05R\x03row\x12\x16\n" +
+	"\x06column\x18\x04 \x01(\x05R\x06column\x12:\n" +
+	"\x06status\x18\x05 \x01(\x0e2\".warehouse.inventory.v1.SlotStatusR\x06status\x12\x1f\n" +
+	"\vmaterial_id\x18\x06 \x01(\tR\n" +
+	"materialId\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\"\xab\x02\n" +
+	"\tOperation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\x04type\x18\x02 \x01(\x0e2%.warehouse.inventory.v1.OperationTypeR\x04type\x12\x1f\n" +
+	"\vmaterial_id\x18\x03 \x01(\tR\n" +
+	"materialId\x12\x17\n" +
+	"\aslot_id\x18\x04 \x01(\tR\x06slotId\x12\x1f\n" +
+	"\voperator_id\x18\x05 \x01(\tR\n" +
+	"operatorId\x12\x19\n" +
+	"\bshelf_id\x18\x06 \x01(\tR\ashelfId\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\x12?\n" +
+	"\x06status\x18\b \x01(\x0e2'.warehouse.inventory.v1.OperationStatusR\x06status\"\xe4\x01\n" +
+	"\vShelfStatus\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\x12\x1f\n" +
+	"\vtotal_slots\x18\x02 \x01(\x05R\n" +
+	"totalSlots\x12\x1f\n" +
+	"\vempty_slots\x18\x03 \x01(\x05R\n" +
+	"emptySlots\x12%\n" +
+	"\x0eoccupied_slots\x18\x04 \x01(\x05R\roccupiedSlots\x122\n" +
+	"\x05slots\x18\x05 \x03(\v2\x1c.warehouse.inventory.v1.SlotR\x05slots\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\"8\n" +
+	"\n" +
+	"FacetCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count*\xab\x01\n" +
+	"\x0eMaterialStatus\x12\x1f\n" +
+	"\x1bMATERIAL_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19MATERIAL_STATUS_AVAILABLE\x10\x01\x12\x1a\n" +
+	"\x16MATERIAL_STATUS_IN_USE\x10\x02\x12\x1c\n" +
+	"\x18MATERIAL_STATUS_RESERVED\x10\x03\x12\x1f\n" +
+	"\x1bMATERIAL_STATUS_MAINTENANCE\x10\x04*\xb2\x01\n" +
+	"\n" +
+	"SlotStatus\x12\x1b\n" +
+	"\x17SLOT_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11SLOT_STATUS_EMPTY\x10\x01\x12\x18\n" +
+	"\x14SLOT_STATUS_OCCUPIED\x10\x02\x12\x18\n" +
+	"\x14SLOT_STATUS_RESERVED\x10\x03\x12\x1b\n" +
+	"\x17SLOT_STATUS_MAINTENANCE\x10\x04\x12\x1f\n" +
+	"\x1bSLOT_STATUS_REMOVAL_PENDING\x10\x05*\xa2\x01\n" +
+	"\rOperationType\x12\x1e\n" +
+	"\x1aOPERATION_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18OPERATION_TYPE_PLACEMENT\x10\x01\x12\x1a\n" +
+	"\x16OPERATION_TYPE_REMOVAL\x10\x02\x12\x17\n" +
+	"\x13OPERATION_TYPE_MOVE\x10\x03\x12\x1e\n" +
+	"\x1aOPERATION_TYPE_RESERVATION\x10\x04*\x95\x02\n" +
+	"\x0fOperationStatus\x12 \n" +
+	"\x1cOPERATION_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18OPERATION_STATUS_PENDING\x10\x01\x12\x1e\n" +
+	"\x1aOPERATION_STATUS_COMPLETED\x10\x02\x12\x1b\n" +
+	"\x17OPERATION_STATUS_FAILED\x10\x03\x12\x1e\n" +
+	"\x1aOPERATION_STATUS_CANCELLED\x10\x04\x122\n" +
+	".OPERATION_STATUS_PENDING_PHYSICAL_CONFIRMATION\x10\x05\x121\n" +
+	"-OPERATION_STATUS_PENDING_REMOVAL_CONFIRMATION\x10\x062\xd6\x04\n" +
+	"\x10InventoryService\x12l\n" +
+	"\rPlaceMaterial\x12,.warehouse.inventory.v1.PlaceMaterialRequest\x1a-.warehouse.inventory.v1.PlaceMaterialResponse\x12o\n" +
+	"\x0eRemoveMaterial\x12-.warehouse.inventory.v1.RemoveMaterialRequest\x1a..warehouse.inventory.v1.RemoveMaterialResponse\x12o\n" +
+	"\x0eGetShelfStatus\x12-.warehouse.inventory.v1.GetShelfStatusRequest\x1a..warehouse.inventory.v1.GetShelfStatusResponse\x12r\n" +
+	"\x0fSearchMaterials\x12..warehouse.inventory.v1.SearchMaterialsRequest\x1a/.warehouse.inventory.v1.SearchMaterialsResponse\x12~\n" +
+	"\x13GetOperationHistory\x122.warehouse.inventory.v1.GetOperationHistoryRequest\x1a3.warehouse.inventory.v1.GetOperationHistoryResponseB&Z$WMS/shared/proto/inventory;inventoryb\x06proto3"
+
+var (
+	file_inventory_proto_rawDescOnce sync.Once
+	file_inventory_proto_rawDescData []byte
+)
+
+func file_inventory_proto_rawDescGZIP() []byte {
+	file_inventory_proto_rawDescOnce.Do(func() {
+		file_inventory_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_inventory_proto_rawDesc), len(file_inventory_proto_rawDesc)))
+	})
+	return file_inventory_proto_rawDescData
+}
+
+var file_inventory_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_inventory_proto_goTypes = []any{
+	(MaterialStatus)(0),                 // 0: warehouse.inventory.v1.MaterialStatus
+	(SlotStatus)(0),                     // 1: warehouse.inventory.v1.SlotStatus
+	(OperationType)(0),                  // 2: warehouse.inventory.v1.OperationType
+	(OperationStatus)(0),                // 3: warehouse.inventory.v1.OperationStatus
+	(*PlaceMaterialRequest)(nil),        // 4: warehouse.inventory.v1.PlaceMaterialRequest
+	(*PlaceMaterialResponse)(nil),       // 5: warehouse.inventory.v1.PlaceMaterialResponse
+	(*RemoveMaterialRequest)(nil),       // 6: warehouse.inventory.v1.RemoveMaterialRequest
+	(*RemoveMaterialResponse)(nil),      // 7: warehouse.inventory.v1.RemoveMaterialResponse
+	(*GetShelfStatusRequest)(nil),       // 8: warehouse.inventory.v1.GetShelfStatusRequest
+	(*GetShelfStatusResponse)(nil),      // 9: warehouse.inventory.v1.GetShelfStatusResponse
+	(*SearchMaterialsRequest)(nil),      // 10: warehouse.inventory.v1.SearchMaterialsRequest
+	(*SearchMaterialsResponse)(nil),     // 11: warehouse.inventory.v1.SearchMaterialsResponse
+	(*GetOperationHistoryRequest)(nil),  // 12: warehouse.inventory.v1.GetOperationHistoryRequest
+	(*GetOperationHistoryResponse)(nil), // 13: warehouse.inventory.v1.GetOperationHistoryResponse
+	(*Material)(nil),                    // 14: warehouse.inventory.v1.Material
+	(*Slot)(nil),                        // 15: warehouse.inventory.v1.Slot
+	(*Operation)(nil),                   // 16: warehouse.inventory.v1.Operation
+	(*ShelfStatus)(nil),                 // 17: warehouse.inventory.v1.ShelfStatus
+	(*FacetCount)(nil),                  // 18: warehouse.inventory.v1.FacetCount
+}
+var file_inventory_proto_depIdxs = []int32{
+	16, // 0: warehouse.inventory.v1.PlaceMaterialResponse.operation:type_name -> warehouse.inventory.v1.Operation
+	17, // 1: warehouse.inventory.v1.GetShelfStatusResponse.status:type_name -> warehouse.inventory.v1.ShelfStatus
+	0,  // 2: warehouse.inventory.v1.SearchMaterialsRequest.status:type_name -> warehouse.inventory.v1.MaterialStatus
+	14, // 3: warehouse.inventory.v1.SearchMaterialsResponse.materials:type_name -> warehouse.inventory.v1.Material
+	18, // 4: warehouse.inventory.v1.SearchMaterialsResponse.type_facets:type_name -> warehouse.inventory.v1.FacetCount
+	18, // 5: warehouse.inventory.v1.SearchMaterialsResponse.status_facets:type_name -> warehouse.inventory.v1.FacetCount
+	18, // 6: warehouse.inventory.v1.SearchMaterialsResponse.shelf_facets:type_name -> warehouse.inventory.v1.FacetCount
+	2,  // 7: warehouse.inventory.v1.GetOperationHistoryRequest.type:type_name -> warehouse.inventory.v1.OperationType
+	3,  // 8: warehouse.inventory.v1.GetOperationHistoryRequest.status:type_name -> warehouse.inventory.v1.OperationStatus
+	16, // 9: warehouse.inventory.v1.GetOperationHistoryResponse.operations:type_name -> warehouse.inventory.v1.Operation
+	0,  // 10: warehouse.inventory.v1.Material.status:type_name -> warehouse.inventory.v1.MaterialStatus
+	1,  // 11: warehouse.inventory.v1.Slot.status:type_name -> warehouse.inventory.v1.SlotStatus
+	2,  // 12: warehouse.inventory.v1.Operation.type:type_name -> warehouse.inventory.v1.OperationType
+	3,  // 13: warehouse.inventory.v1.Operation.status:type_name -> warehouse.inventory.v1.OperationStatus
+	15, // 14: warehouse.inventory.v1.ShelfStatus.slots:type_name -> warehouse.inventory.v1.Slot
+	4,  // 15: warehouse.inventory.v1.InventoryService.PlaceMaterial:input_type -> warehouse.inventory.v1.PlaceMaterialRequest
+	6,  // 16: warehouse.inventory.v1.InventoryService.RemoveMaterial:input_type -> warehouse.inventory.v1.RemoveMaterialRequest
+	8,  // 17: warehouse.inventory.v1.InventoryService.GetShelfStatus:input_type -> warehouse.inventory.v1.GetShelfStatusRequest
+	10, // 18: warehouse.inventory.v1.InventoryService.SearchMaterials:input_type -> warehouse.inventory.v1.SearchMaterialsRequest
+	12, // 19: warehouse.inventory.v1.InventoryService.GetOperationHistory:input_type -> warehouse.inventory.v1.GetOperationHistoryRequest
+	5,  // 20: warehouse.inventory.v1.InventoryService.PlaceMaterial:output_type -> warehouse.inventory.v1.PlaceMaterialResponse
+	7,  // 21: warehouse.inventory.v1.InventoryService.RemoveMaterial:output_type -> warehouse.inventory.v1.RemoveMaterialResponse
+	9,  // 22: warehouse.inventory.v1.InventoryService.GetShelfStatus:output_type -> warehouse.inventory.v1.GetShelfStatusResponse
+	11, // 23: warehouse.inventory.v1.InventoryService.SearchMaterials:output_type -> warehouse.inventory.v1.SearchMaterialsResponse
+	13, // 24: warehouse.inventory.v1.InventoryService.GetOperationHistory:output_type -> warehouse.inventory.v1.GetOperationHistoryResponse
+	20, // [20:25] is the sub-list for method output_type
+	15, // [15:20] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_inventory_proto_init() }
+func file_inventory_proto_init() {
+	if File_inventory_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_proto_rawDesc), len(file_inventory_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_inventory_proto_goTypes,
+		DependencyIndexes: file_inventory_proto_depIdxs,
+		EnumInfos:         file_inventory_proto_enumTypes,
+		MessageInfos:      file_inventory_proto_msgTypes,
+	}.Build()
+	File_inventory_proto = out.File
+	file_inventory_proto_goTypes = nil
+	file_inventory_proto_depIdxs = nil
+}
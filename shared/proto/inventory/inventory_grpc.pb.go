@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: inventory.proto
+
+package inventory
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	InventoryService_PlaceMaterial_FullMethodName       = "/warehouse.inventory.v1.InventoryService/PlaceMaterial"
+	InventoryService_RemoveMaterial_FullMethodName      = "/warehouse.inventory.v1.InventoryService/RemoveMaterial"
+	InventoryService_GetShelfStatus_FullMethodName      = "/warehouse.inventory.v1.InventoryService/GetShelfStatus"
+	InventoryService_SearchMaterials_FullMethodName     = "/warehouse.inventory.v1.InventoryService/SearchMaterials"
+	InventoryService_GetOperationHistory_FullMethodName = "/warehouse.inventory.v1.InventoryService/GetOperationHistory"
+)
+
+// InventoryServiceClient is the client API for InventoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 庫存服務定義
+type InventoryServiceClient interface {
+	PlaceMaterial(ctx context.Context, in *PlaceMaterialRequest, opts ...grpc.CallOption) (*PlaceMaterialResponse, error)
+	RemoveMaterial(ctx context.Context, in *RemoveMaterialRequest, opts ...grpc.CallOption) (*RemoveMaterialResponse, error)
+	GetShelfStatus(ctx context.Context, in *GetShelfStatusRequest, opts ...grpc.CallOption) (*GetShelfStatusResponse, error)
+	SearchMaterials(ctx context.Context, in *SearchMaterialsRequest, opts ...grpc.CallOption) (*SearchMaterialsResponse, error)
+	GetOperationHistory(ctx context.Context, in *GetOperationHistoryRequest, opts ...grpc.CallOption) (*GetOperationHistoryResponse, error)
+}
+
+type inventoryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInventoryServiceClient(cc grpc.ClientConnInterface) InventoryServiceClient {
+	return &inventoryServiceClient{cc}
+}
+
+func (c *inventoryServiceClient) PlaceMaterial(ctx context.Context, in *PlaceMaterialRequest, opts ...grpc.CallOption) (*PlaceMaterialResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaceMaterialResponse)
+	err := c.cc.Invoke(ctx, InventoryService_PlaceMaterial_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) RemoveMaterial(ctx context.Context, in *RemoveMaterialRequest, opts ...grpc.CallOption) (*RemoveMaterialResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveMaterialResponse)
+	err := c.cc.Invoke(ctx, InventoryService_RemoveMaterial_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetShelfStatus(ctx context.Context, in *GetShelfStatusRequest, opts ...grpc.CallOption) (*GetShelfStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShelfStatusResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetShelfStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SearchMaterials(ctx context.Context, in *SearchMaterialsRequest, opts ...grpc.CallOption) (*SearchMaterialsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchMaterialsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SearchMaterials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetOperationHistory(ctx context.Context, in *GetOperationHistoryRequest, opts ...grpc.CallOption) (*GetOperationHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOperationHistoryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetOperationHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InventoryServiceServer is the server API for InventoryService service.
+// All implementations must embed UnimplementedInventoryServiceServer
+// for forward compatibility.
+//
+// 庫存服務定義
+type InventoryServiceServer interface {
+	PlaceMaterial(context.Context, *PlaceMaterialRequest) (*PlaceMaterialResponse, error)
+	RemoveMaterial(context.Context, *RemoveMaterialRequest) (*RemoveMaterialResponse, error)
+	GetShelfStatus(context.Context, *GetShelfStatusRequest) (*GetShelfStatusResponse, error)
+	SearchMaterials(context.Context, *SearchMaterialsRequest) (*SearchMaterialsResponse, error)
+	GetOperationHistory(context.Context, *GetOperationHistoryRequest) (*GetOperationHistoryResponse, error)
+	mustEmbedUnimplementedInventoryServiceServer()
+}
+
+// UnimplementedInventoryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInventoryServiceServer struct{}
+
+func (UnimplementedInventoryServiceServer) PlaceMaterial(context.Context, *PlaceMaterialRequest) (*PlaceMaterialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceMaterial not implemented")
+}
+func (UnimplementedInventoryServiceServer) RemoveMaterial(context.Context, *RemoveMaterialRequest) (*RemoveMaterialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMaterial not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetShelfStatus(context.Context, *GetShelfStatusRequest) (*GetShelfStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShelfStatus not implemented")
+}
+func (UnimplementedInventoryServiceServer) SearchMaterials(context.Context, *SearchMaterialsRequest) (*SearchMaterialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMaterials not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetOperationHistory(context.Context, *GetOperationHistoryRequest) (*GetOperationHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperationHistory not implemented")
+}
+func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
+func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeInventoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InventoryServiceServer will
+// result in compilation errors.
+type UnsafeInventoryServiceServer interface {
+	mustEmbedUnimplementedInventoryServiceServer()
+}
+
+func RegisterInventoryServiceServer(s grpc.ServiceRegistrar, srv InventoryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedInventoryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InventoryService_ServiceDesc, srv)
+}
+
+func _InventoryService_PlaceMaterial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceMaterialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).PlaceMaterial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_PlaceMaterial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).PlaceMaterial(ctx, req.(*PlaceMaterialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_RemoveMaterial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMaterialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RemoveMaterial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RemoveMaterial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RemoveMaterial(ctx, req.(*RemoveMaterialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetShelfStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShelfStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetShelfStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetShelfStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetShelfStatus(ctx, req.(*GetShelfStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_SearchMaterials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMaterialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SearchMaterials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SearchMaterials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SearchMaterials(ctx, req.(*SearchMaterialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetOperationHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetOperationHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetOperationHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetOperationHistory(ctx, req.(*GetOperationHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InventoryService_ServiceDesc is the grpc.ServiceDesc for InventoryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InventoryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "warehouse.inventory.v1.InventoryService",
+	HandlerType: (*InventoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlaceMaterial",
+			Handler:    _InventoryService_PlaceMaterial_Handler,
+		},
+		{
+			MethodName: "RemoveMaterial",
+			Handler:    _InventoryService_RemoveMaterial_Handler,
+		},
+		{
+			MethodName: "GetShelfStatus",
+			Handler:    _InventoryService_GetShelfStatus_Handler,
+		},
+		{
+			MethodName: "SearchMaterials",
+			Handler:    _InventoryService_SearchMaterials_Handler,
+		},
+		{
+			MethodName: "GetOperationHistory",
+			Handler:    _InventoryService_GetOperationHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "inventory.proto",
+}